@@ -0,0 +1,79 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"eswiac.me/filingsdb/sic"
+	"gorm.io/gorm"
+)
+
+// Company is a registrant as of its most recent submission, with its filings
+type Company struct {
+	Cik            string
+	Name           string
+	Sic            string
+	SicDescription string
+	Tickers        []string
+
+	// Submissions of the company, most recent first, filtered by the form,
+	// fy, fp and as_of parameters
+	Filings *Page
+}
+
+// company serves /companies/{cik|ticker}
+func (s *Server) company(db *gorm.DB, r *http.Request, path []string) (interface{}, error) {
+	if len(path) != 1 {
+		return nil, errNotFound
+	}
+	cik, err := query.ResolveCIK(db, path[0])
+	if errors.Is(err, query.ErrUnknownTicker) {
+		return nil, fmt.Errorf("%v: %w", err, errNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f, err := filter(r)
+	if err != nil {
+		return nil, err
+	}
+	page, err := parsePage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := []models.DataSUB{}
+	err = db.Where("cik = ?", cik).Scopes(f.Scope).Order("accepted DESC").Limit(1).Find(&latest).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(latest) == 0 {
+		return nil, fmt.Errorf("no filings for CIK %s: %w", cik, errNotFound)
+	}
+	c := &Company{Cik: cik, Name: latest[0].Name, Sic: latest[0].Sic, SicDescription: sic.Description(latest[0].Sic)}
+	if err := db.Table("data_tickers").Where("cik = ?", cik).Order("ticker").Pluck("ticker", &c.Tickers).Error; err != nil {
+		return nil, err
+	}
+
+	params := r.URL.Query()
+	scope := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("cik = ?", cik).Scopes(f.Scope)
+		if forms := splitList(params.Get("form")); len(forms) > 0 {
+			tx = tx.Where("form IN ?", forms)
+		}
+		for _, column := range []string{"fy", "fp"} {
+			if v := params.Get(column); v != "" {
+				tx = tx.Where(column+" = ?", v)
+			}
+		}
+		return tx
+	}
+	c.Filings, err = page.find(db, &models.DataSUB{}, &[]models.DataSUB{}, scope, "accepted DESC")
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
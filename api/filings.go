@@ -0,0 +1,112 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"gorm.io/gorm"
+)
+
+// filing serves /filings/{adsh} and its sub resources
+func (s *Server) filing(db *gorm.DB, r *http.Request, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, errNotFound
+	}
+	subs := []models.DataSUB{}
+	if err := db.Where("adsh = ?", path[0]).Limit(1).Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf("unknown submission %s: %w", path[0], errNotFound)
+	}
+	switch {
+	case len(path) == 1:
+		return subs[0], nil
+	case len(path) == 2 && path[1] == "facts":
+		return s.facts(db, r, subs[0])
+	case len(path) == 3 && path[1] == "statements":
+		return s.statement(db, r, subs[0], strings.ToUpper(path[2]))
+	case len(path) == 2 && path[1] == "reports":
+		return query.Reports(db, subs[0].Adsh)
+	case len(path) == 3 && path[1] == "reports":
+		return s.report(db, subs[0], path[2])
+	}
+	return nil, errNotFound
+}
+
+// facts serves /filings/{adsh}/facts, the numeric facts of the submission
+// filtered by the tag, version, ddate, qtrs, uom and dimh parameters
+func (s *Server) facts(db *gorm.DB, r *http.Request, sub models.DataSUB) (interface{}, error) {
+	page, err := parsePage(r)
+	if err != nil {
+		return nil, err
+	}
+	params := r.URL.Query()
+	if qtrs := params.Get("qtrs"); qtrs != "" {
+		if _, err := strconv.Atoi(qtrs); err != nil {
+			return nil, &badRequest{fmt.Sprintf("invalid qtrs `%s`", qtrs)}
+		}
+	}
+	scope := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("adsh = ?", sub.Adsh)
+		if tags := splitList(params.Get("tag")); len(tags) > 0 {
+			tx = tx.Where("tag IN ?", tags)
+		}
+		for _, column := range []string{"version", "ddate", "qtrs", "uom", "dimh"} {
+			if v := params.Get(column); v != "" {
+				tx = tx.Where(column+" = ?", v)
+			}
+		}
+		return tx
+	}
+	return page.find(db, &models.DataNUM{}, &[]models.DataNUM{}, scope, "tag, ddate, qtrs, uom, dimh")
+}
+
+// statement serves /filings/{adsh}/statements/{stmt}, with the values known
+// at the as_of parameter
+func (s *Server) statement(db *gorm.DB, r *http.Request, sub models.DataSUB, stmt string) (interface{}, error) {
+	f, err := filter(r)
+	if err != nil {
+		return nil, err
+	}
+	if !f.AsOf.IsZero() && sub.Accepted > query.AcceptedCutoff(f.AsOf) {
+		return nil, &badRequest{fmt.Sprintf("submission %s was accepted after as_of", sub.Adsh)}
+	}
+	var count int64
+	err = db.Model(&models.DataPRE{}).Where("adsh = ? AND stmt = ? AND inpth = '0'", sub.Adsh, stmt).Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("no %s statement in submission %s: %w", stmt, sub.Adsh, errNotFound)
+	}
+	return query.FilingStatement(db, sub.Adsh, stmt, f)
+}
+
+// report serves /filings/{adsh}/reports/{report}, a report given its number
+// (4 or R4) with its presentation lines and their values
+func (s *Server) report(db *gorm.DB, sub models.DataSUB, report string) (interface{}, error) {
+	var count int64
+	number := strings.TrimPrefix(strings.ToUpper(report), "R")
+	if err := db.Model(&models.DataREN{}).Where("adsh = ? AND report = ?", sub.Adsh, number).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("no report %s in submission %s: %w", report, sub.Adsh, errNotFound)
+	}
+	return query.FilingReport(db, sub.Adsh, report)
+}
+
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
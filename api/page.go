@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// Page is a paginated list
+type Page struct {
+	Data   interface{}
+	Offset int
+	Limit  int
+
+	// Count of items across all pages
+	Total int64
+}
+
+// parsePage reads the limit and offset parameters
+func parsePage(r *http.Request) (Page, error) {
+	p := Page{Limit: defaultLimit}
+	for name, dst := range map[string]*int{"limit": &p.Limit, "offset": &p.Offset} {
+		s := r.URL.Query().Get(name)
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return p, &badRequest{fmt.Sprintf("invalid %s `%s`", name, s)}
+		}
+		*dst = n
+	}
+	if p.Limit == 0 || p.Limit > maxLimit {
+		p.Limit = maxLimit
+	}
+	return p, nil
+}
+
+// find counts the rows of model matching scope and loads the requested page
+// of them into dst
+func (p Page) find(db *gorm.DB, model interface{}, dst interface{}, scope func(*gorm.DB) *gorm.DB, order string) (*Page, error) {
+	if err := db.Model(model).Scopes(scope).Count(&p.Total).Error; err != nil {
+		return nil, err
+	}
+	err := db.Model(model).Scopes(scope).Order(order).Offset(p.Offset).Limit(p.Limit).Find(dst).Error
+	if err != nil {
+		return nil, err
+	}
+	p.Data = dst
+	return &p, nil
+}
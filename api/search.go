@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SearchResult is a company or a tag matching a search
+type SearchResult struct {
+	// company or tag
+	Type string
+
+	// The CIK of a company or the name of a tag
+	ID string
+
+	// The name of a company or the label of a tag
+	Name string
+}
+
+// searchQueries look up companies by name or ticker and tags by name or label
+var searchQueries = map[string]string{
+	"company": `SELECT 'company' AS type, data_subs.cik AS id, MAX(data_subs.name) AS name
+		FROM data_subs
+		WHERE data_subs.name LIKE @like ESCAPE '\' OR data_subs.cik IN (SELECT cik FROM data_tickers WHERE ticker = @ticker)
+		GROUP BY data_subs.cik`,
+	"tag": `SELECT 'tag' AS type, tag AS id, MAX(COALESCE(tlabel, tag)) AS name
+		FROM data_tags
+		WHERE tag LIKE @like ESCAPE '\' OR tlabel LIKE @like ESCAPE '\'
+		GROUP BY tag`,
+}
+
+// search serves /search?q=, companies and tags matching q, optionally
+// restricted to one type with the type parameter
+func (s *Server) search(db *gorm.DB, r *http.Request, path []string) (interface{}, error) {
+	if len(path) != 0 {
+		return nil, errNotFound
+	}
+	params := r.URL.Query()
+	q := strings.TrimSpace(params.Get("q"))
+	if q == "" {
+		return nil, &badRequest{"missing q parameter"}
+	}
+	page, err := parsePage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	selects := []string{}
+	switch t := params.Get("type"); t {
+	case "":
+		selects = append(selects, searchQueries["company"], searchQueries["tag"])
+	case "company", "tag":
+		selects = append(selects, searchQueries[t])
+	default:
+		return nil, &badRequest{"invalid type `" + t + "`, expected company or tag"}
+	}
+	union := strings.Join(selects, " UNION ALL ")
+	args := map[string]interface{}{
+		"like":   "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(q) + "%",
+		"ticker": strings.ToUpper(q),
+	}
+
+	if err := db.Raw("SELECT COUNT(*) FROM ("+union+")", args).Scan(&page.Total).Error; err != nil {
+		return nil, err
+	}
+	results := []SearchResult{}
+	args["limit"], args["offset"] = page.Limit, page.Offset
+	err = db.Raw("SELECT * FROM ("+union+") ORDER BY type, name LIMIT @limit OFFSET @offset", args).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	page.Data = results
+	return &page, nil
+}
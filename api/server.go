@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"eswiac.me/filingsdb/query"
+	"gorm.io/gorm"
+)
+
+// errNotFound is reported as a 404
+var errNotFound = errors.New("not found")
+
+// Server exposes a filings database as read-only JSON endpoints:
+//
+//	GET /companies/{cik|ticker}
+//	GET /filings/{adsh}
+//	GET /filings/{adsh}/facts
+//	GET /filings/{adsh}/statements/{BS|IS|CF|EQ|CI}
+//	GET /filings/{adsh}/reports
+//	GET /filings/{adsh}/reports/{report}
+//	GET /tags?q=
+//	GET /tags/{tag}
+//	GET /search?q=
+//
+// Lists are paginated with the limit and offset parameters.
+type Server struct {
+	db  *gorm.DB
+	mux *http.ServeMux
+}
+
+// NewServer returns the REST API over db
+func NewServer(db *gorm.DB) *Server {
+	s := &Server{db: db, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/companies/", s.handle(s.company))
+	s.mux.HandleFunc("/filings/", s.handle(s.filing))
+	s.mux.HandleFunc("/tags", s.handle(s.tags))
+	s.mux.HandleFunc("/tags/", s.handle(s.tags))
+	s.mux.HandleFunc("/search", s.handle(s.search))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handlerFunc returns the value to encode as the JSON response, querying db
+// which is bound to the context of the request
+type handlerFunc func(db *gorm.DB, r *http.Request, path []string) (interface{}, error)
+
+// handle adapts h to http, splitting the path after the route prefix into
+// its segments and mapping errors to status codes
+func (s *Server) handle(h handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		path := []string{}
+		for _, segment := range strings.Split(strings.Trim(r.URL.Path, "/"), "/")[1:] {
+			if segment != "" {
+				path = append(path, segment)
+			}
+		}
+		v, err := h(s.db.WithContext(r.Context()), r, path)
+		switch {
+		case errors.Is(err, errNotFound):
+			writeError(w, http.StatusNotFound, err)
+		case errors.As(err, new(*badRequest)):
+			writeError(w, http.StatusBadRequest, err)
+		case err != nil:
+			log.Printf("%s %s: %v", r.Method, r.URL, err)
+			writeError(w, http.StatusInternalServerError, errors.New("internal error"))
+		default:
+			writeJSON(w, http.StatusOK, v)
+		}
+	}
+}
+
+// badRequest is an invalid parameter, reported as a 400
+type badRequest struct {
+	msg string
+}
+
+func (e *badRequest) Error() string {
+	return e.msg
+}
+
+// filter parses the as_of parameter
+func filter(r *http.Request) (query.Filter, error) {
+	f := query.Filter{}
+	if asOf := r.URL.Query().Get("as_of"); asOf != "" {
+		t, err := query.ParseAsOf(asOf)
+		if err != nil {
+			return f, &badRequest{err.Error()}
+		}
+		f.AsOf = t
+	}
+	return f, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println(err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"Error": err.Error()})
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"gorm.io/gorm"
+)
+
+// tags serves /tags?q=, the tags whose name, label or documentation
+// contain q (all of them if empty) with their usage, filtered by the custom
+// (true or false) and version (e.g. us-gaap/2019 or us-gaap) parameters and
+// sorted by the sort parameter: tag (the default) or usage. Sorting by usage
+// counts the facts of every matching tag, so it requires q or version.
+func (s *Server) tags(db *gorm.DB, r *http.Request, path []string) (interface{}, error) {
+	if len(path) != 0 {
+		return s.tag(db, r, path)
+	}
+	page, err := parsePage(r)
+	if err != nil {
+		return nil, err
+	}
+	params := r.URL.Query()
+	q := query.TagQuery{
+		Text:    params.Get("q"),
+		Version: params.Get("version"),
+		Sort:    params.Get("sort"),
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+	}
+	if q.Sort == "" {
+		q.Sort = "tag"
+	}
+	if q.Sort != "usage" && q.Sort != "tag" {
+		return nil, &badRequest{"invalid sort `" + q.Sort + "`, expected tag or usage"}
+	}
+	if q.Sort == "usage" && q.Text == "" && q.Version == "" {
+		return nil, &badRequest{"sort=usage requires q or version"}
+	}
+	if custom := params.Get("custom"); custom != "" {
+		isCustom, err := strconv.ParseBool(custom)
+		if err != nil {
+			return nil, &badRequest{"invalid custom `" + custom + "`, expected true or false"}
+		}
+		q.Custom = &isCustom
+	}
+	result, err := query.Tags(db, q)
+	if err != nil {
+		return nil, err
+	}
+	page.Data, page.Total = result.Tags, int64(result.Total)
+	return &page, nil
+}
+
+// tag serves /tags/{tag}, the definitions of a tag in each taxonomy version
+// or custom extension, filtered by the version parameter
+func (s *Server) tag(db *gorm.DB, r *http.Request, path []string) (interface{}, error) {
+	if len(path) != 1 {
+		return nil, errNotFound
+	}
+	page, err := parsePage(r)
+	if err != nil {
+		return nil, err
+	}
+	version := r.URL.Query().Get("version")
+	scope := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("tag = ?", path[0])
+		if version != "" {
+			tx = tx.Where("version = ?", version)
+		}
+		return tx
+	}
+	p, err := page.find(db, &models.DataTAG{}, &[]models.DataTAG{}, scope, "version")
+	if err != nil {
+		return nil, err
+	}
+	if p.Total == 0 {
+		return nil, fmt.Errorf("unknown tag %s: %w", path[0], errNotFound)
+	}
+	return p, nil
+}
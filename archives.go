@@ -0,0 +1,78 @@
+package filingsdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+
+	"eswiac.me/filingsdb/models"
+	"github.com/antchfx/htmlquery"
+)
+
+const secGov = "https://www.sec.gov"
+
+// datasetPages are the pages of sec.gov listing the archives of each
+// variant of the data sets, with the pattern of the archive names
+var datasetPages = map[string]struct {
+	page    string
+	archive *regexp.Regexp
+}{
+	models.DatasetNotes:      {"/dera/data/financial-statement-and-notes-data-set.html", regexp.MustCompile(`_notes\.zip$`)},
+	models.DatasetStatements: {"/dera/data/financial-statement-data-sets.html", regexp.MustCompile(`^\d{4}q[1-4]\.zip$`)},
+}
+
+// Archive is a data set published on sec.gov
+type Archive struct {
+	// File name, e.g. 2019q1_notes.zip
+	Name string `json:"name"`
+
+	// URL, or path of a local file
+	URL string `json:"url"`
+}
+
+// ListArchives lists the Financial Statement and Notes data sets published
+// on sec.gov, using DefaultClient
+func ListArchives(ctx context.Context) ([]Archive, error) {
+	return DefaultClient.ListArchives(ctx)
+}
+
+// ListArchives lists the Financial Statement and Notes data sets published
+// on sec.gov
+func (c *Client) ListArchives(ctx context.Context) ([]Archive, error) {
+	return c.ListDatasetArchives(ctx, models.DatasetNotes)
+}
+
+// ListDatasetArchives lists the archives of a variant of the data sets
+// published on sec.gov: models.DatasetNotes or models.DatasetStatements
+func (c *Client) ListDatasetArchives(ctx context.Context, dataset string) ([]Archive, error) {
+	index, ok := datasetPages[dataset]
+	if !ok {
+		return nil, fmt.Errorf("unknown data sets %q", dataset)
+	}
+	resp, err := c.Get(ctx, c.BaseURL+index.page, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	doc, err := htmlquery.Parse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	list := htmlquery.Find(doc, "//a/@href")
+	archives := []Archive{}
+	for _, n := range list {
+		link := htmlquery.SelectAttr(n, "href") // output @href value
+		if index.archive.MatchString(path.Base(link)) {
+			archives = append(archives, Archive{Name: path.Base(link), URL: c.BaseURL + link})
+		}
+	}
+	return archives, nil
+}
+
+// Download downloads url to the file filepath with DefaultClient, teeing the
+// content into progress if not nil
+func Download(ctx context.Context, url string, filepath string, progress io.Writer) error {
+	return DefaultClient.Download(ctx, url, filepath, progress)
+}
@@ -0,0 +1,295 @@
+package filingsdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cache is a local directory of downloaded archives, so that rebuilding a
+// database doesn't download them again. Archives are stored by the SHA-256
+// of their content along with the ETag and Last-Modified headers they were
+// served with, to only download them again once changed:
+//
+//	index.json               the entries
+//	sha256/<hash>.zip        the archives
+//	partial/<hash of url>    interrupted downloads, resumed by the next fetch
+//	                         unless their validator shows the archive changed
+type Cache struct {
+	dir    string
+	client *Client
+
+	mu sync.Mutex
+}
+
+// CacheEntry is an archive of the cache
+type CacheEntry struct {
+	URL          string
+	SHA256       string
+	Size         int64
+	ETag         string    `json:",omitempty"`
+	LastModified string    `json:",omitempty"`
+	Fetched      time.Time // when the content was downloaded
+	Checked      time.Time // when sec.gov last confirmed it's up to date
+}
+
+// Name returns the file name of the archive, e.g. 2019q1_notes.zip
+func (e CacheEntry) Name() string {
+	return path.Base(e.URL)
+}
+
+// DefaultCacheDir returns the cache directory of the user, e.g.
+// ~/.cache/filingsdb on Linux
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "filingsdb"), nil
+}
+
+// OpenCache opens the cache directory dir, creating it if needed. Archives
+// are downloaded with client, DefaultClient if nil.
+func OpenCache(dir string, client *Client) (*Cache, error) {
+	if client == nil {
+		client = DefaultClient
+	}
+	for _, sub := range []string{"sha256", "partial"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, err
+		}
+	}
+	return &Cache{dir: dir, client: client}, nil
+}
+
+// Dir returns the directory of the cache
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Path returns the path of the archive of an entry
+func (c *Cache) Path(e CacheEntry) string {
+	return filepath.Join(c.dir, "sha256", e.SHA256+".zip")
+}
+
+// WithCache downloads the archives ingested from sec.gov through c
+func WithCache(c *Cache) Option {
+	return func(db *DB) {
+		db.cache = c
+	}
+}
+
+// Fetch returns the path of the archive at url in the cache, downloading it
+// if it's not cached or changed since, teeing the content into progress if
+// not nil. The cached archive is used if sec.gov can't be reached.
+func (c *Cache) Fetch(ctx context.Context, url string, progress io.Writer) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.load()
+	if err != nil {
+		return "", err
+	}
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].URL >= url })
+	cached := i < len(entries) && entries[i].URL == url && c.present(entries[i])
+
+	header := http.Header{}
+	if cached {
+		if entries[i].ETag != "" {
+			header.Set("If-None-Match", entries[i].ETag)
+		}
+		if entries[i].LastModified != "" {
+			header.Set("If-Modified-Since", entries[i].LastModified)
+		}
+	}
+	partial := filepath.Join(c.dir, "partial", hash([]byte(url)))
+	respHeader, err := c.client.download(ctx, url, partial, header, progress)
+	if info, statErr := os.Stat(partial + ".tmp"); statErr == nil && info.Size() == 0 {
+		// nothing to resume
+		os.Remove(partial + ".tmp")
+		os.Remove(partial + ".tmp" + validatorSuffix)
+	}
+	var statusErr *StatusError
+	switch {
+	case cached && errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotModified:
+		entries[i].Checked = time.Now()
+		return c.Path(entries[i]), c.save(entries)
+	case cached && err != nil && ctx.Err() == nil && !errors.As(err, &statusErr):
+		if c.client.Logger != nil {
+			c.client.Logger.Printf("%v, using the cached %s", err, entries[i].Name())
+		}
+		return c.Path(entries[i]), nil
+	case err != nil:
+		return "", err
+	}
+
+	entry := CacheEntry{
+		URL:          url,
+		ETag:         respHeader.Get("ETag"),
+		LastModified: respHeader.Get("Last-Modified"),
+		Fetched:      time.Now(),
+	}
+	entry.Checked = entry.Fetched
+	if entry.SHA256, entry.Size, err = hashFile(partial); err != nil {
+		return "", err
+	}
+	if err := os.Rename(partial, c.Path(entry)); err != nil {
+		return "", err
+	}
+	if i < len(entries) && entries[i].URL == url {
+		entries[i] = entry
+	} else {
+		entries = append(entries[:i], append([]CacheEntry{entry}, entries[i:]...)...)
+	}
+	return c.Path(entry), c.save(entries)
+}
+
+// present reports whether the archive of e is in the cache, with the
+// expected size
+func (c *Cache) present(e CacheEntry) bool {
+	info, err := os.Stat(c.Path(e))
+	return err == nil && info.Size() == e.Size
+}
+
+// Entries returns the archives of the cache sorted by URL
+func (c *Cache) Entries() ([]CacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.load()
+}
+
+// Verify checks the SHA-256 of every archive, returning the entries missing
+// or corrupted. They are removed from the cache, to be downloaded again by
+// the next fetch.
+func (c *Cache) Verify(ctx context.Context) ([]CacheEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	kept, bad := []CacheEntry{}, []CacheEntry{}
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sum, _, err := hashFile(c.Path(e))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if sum == e.SHA256 {
+			kept = append(kept, e)
+			continue
+		}
+		bad = append(bad, e)
+		os.Remove(c.Path(e))
+	}
+	if len(bad) == 0 {
+		return bad, nil
+	}
+	return bad, c.save(kept)
+}
+
+// Prune removes the archives not fetched since before, along with the files
+// no entry refers to (older versions of archives, interrupted downloads).
+// It returns the count of bytes freed.
+func (c *Cache) Prune(before time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries, err := c.load()
+	if err != nil {
+		return 0, err
+	}
+	kept := []CacheEntry{}
+	used := map[string]bool{}
+	for _, e := range entries {
+		if e.Fetched.Before(before) {
+			continue
+		}
+		kept = append(kept, e)
+		used[c.Path(e)] = true
+	}
+	if err := c.save(kept); err != nil {
+		return 0, err
+	}
+
+	var freed int64
+	for _, sub := range []string{"sha256", "partial"} {
+		files, err := ioutil.ReadDir(filepath.Join(c.dir, sub))
+		if err != nil {
+			return freed, err
+		}
+		for _, f := range files {
+			file := filepath.Join(c.dir, sub, f.Name())
+			if used[file] {
+				continue
+			}
+			if err := os.Remove(file); err != nil {
+				return freed, err
+			}
+			freed += f.Size()
+		}
+	}
+	return freed, nil
+}
+
+func (c *Cache) load() ([]CacheEntry, error) {
+	entries := []CacheEntry{}
+	b, err := ioutil.ReadFile(filepath.Join(c.dir, "index.json"))
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Join(c.dir, "index.json"), err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].URL < entries[j].URL })
+	return entries, nil
+}
+
+// save writes the index atomically
+func (c *Cache) save(entries []CacheEntry) error {
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	index := filepath.Join(c.dir, "index.json")
+	if err := ioutil.WriteFile(index+".tmp", b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(index+".tmp", index)
+}
+
+func hash(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashFile returns the hexadecimal SHA-256 and the size of file
+func hashFile(file string) (string, int64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
@@ -0,0 +1,120 @@
+package filingsdb
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// archiveServer serves content with etag, answering range requests, and
+// records the Range and If-Range headers received
+type archiveServer struct {
+	content []byte
+	etag    string
+	ranges  []string
+}
+
+func (s *archiveServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.ranges = append(s.ranges, r.Header.Get("Range")+" "+r.Header.Get("If-Range"))
+	w.Header().Set("ETag", s.etag)
+	http.ServeContent(w, r, "archive.zip", time.Time{}, bytes.NewReader(s.content))
+}
+
+// fetchPartial fetches the archive of srv through a new cache holding an
+// interrupted download of it, partial, with validator if not empty. It
+// returns the content fetched.
+func fetchPartial(t *testing.T, srv *archiveServer, partial []byte, validator string) []byte {
+	dir, err := ioutil.TempDir("", "filingsdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	cache, err := OpenCache(dir, testClient(ts))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url := ts.URL + "/2019q1_notes.zip"
+	tmp := filepath.Join(dir, "partial", hash([]byte(url))+".tmp")
+	if err := ioutil.WriteFile(tmp, partial, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if validator != "" {
+		if err := ioutil.WriteFile(tmp+validatorSuffix, []byte(validator), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file, err := cache.Fetch(context.Background(), url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := cache.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].SHA256 != hash(srv.content) || entries[0].Size != int64(len(srv.content)) {
+		t.Errorf("cache entries %+v, expected the archive", entries)
+	}
+	files, err := ioutil.ReadDir(filepath.Join(dir, "partial"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		t.Errorf("partial/%s left behind", f.Name())
+	}
+	return b
+}
+
+func TestCacheResume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	srv := &archiveServer{content: content, etag: `"v1"`}
+	if b := fetchPartial(t, srv, content[:500], `"v1"`); !bytes.Equal(b, content) {
+		t.Errorf("fetched %d bytes, expected the %d of the archive", len(b), len(content))
+	}
+	if len(srv.ranges) != 1 || srv.ranges[0] != `bytes=500- "v1"` {
+		t.Errorf("requests with the ranges %q, expected a single one from 500", srv.ranges)
+	}
+}
+
+func TestCacheResumeChanged(t *testing.T) {
+	old := bytes.Repeat([]byte("a"), 1000)
+	content := bytes.Repeat([]byte("b"), 1000)
+	srv := &archiveServer{content: content, etag: `"v2"`}
+	if b := fetchPartial(t, srv, old[:500], `"v1"`); !bytes.Equal(b, content) {
+		t.Errorf("fetched %q..., expected the new archive", b[:10])
+	}
+}
+
+func TestCacheResumeShrunk(t *testing.T) {
+	content := bytes.Repeat([]byte("b"), 1000)
+	srv := &archiveServer{content: content, etag: `"v1"`}
+	if b := fetchPartial(t, srv, bytes.Repeat([]byte("a"), 1500), `"v1"`); !bytes.Equal(b, content) {
+		t.Errorf("fetched %d bytes, expected the %d of the archive", len(b), len(content))
+	}
+	if len(srv.ranges) != 2 || srv.ranges[1] != " " {
+		t.Errorf("requests with the ranges %q, expected the whole archive after a 416", srv.ranges)
+	}
+}
+
+func TestCacheResumeWithoutValidator(t *testing.T) {
+	content := bytes.Repeat([]byte("b"), 1000)
+	srv := &archiveServer{content: content, etag: `"v1"`}
+	if b := fetchPartial(t, srv, bytes.Repeat([]byte("a"), 500), ""); !bytes.Equal(b, content) {
+		t.Errorf("fetched %q..., expected the archive", b[:10])
+	}
+	if len(srv.ranges) != 1 || srv.ranges[0] != " " {
+		t.Errorf("requests with the ranges %q, expected the whole archive", srv.ranges)
+	}
+}
@@ -0,0 +1,370 @@
+package filingsdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client is the HTTP client of sec.gov. It follows the fair access policy
+// of the SEC: requests declare a User-Agent and are rate limited. Failed
+// requests (network errors, 429 and 5xx statuses) are retried with an
+// exponential backoff and interrupted downloads resume where they stopped.
+type Client struct {
+	/**
+	The User-Agent of the requests. The SEC asks for a contact,
+	e.g. "Sample Company Name AdminContact@sample.com".
+	*/
+	UserAgent string
+
+	// The address of sec.gov, to be replaced by a httptest server in tests
+	BaseURL string
+
+	// Maximum count of requests per second, unlimited if zero
+	RateLimit float64
+
+	// Count of retries of a failed request
+	Retries int
+
+	// Delay before the first retry, doubled at each retry up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	HTTPClient *http.Client
+
+	// Logger of the retries, discarded if nil
+	Logger Logger
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewClient returns a client of sec.gov declaring userAgent, limited to 10
+// requests per second and retrying failed requests 5 times
+func NewClient(userAgent string) *Client {
+	return &Client{
+		UserAgent:  userAgent,
+		BaseURL:    secGov,
+		RateLimit:  10,
+		Retries:    5,
+		Backoff:    time.Second,
+		MaxBackoff: time.Minute,
+		HTTPClient: &http.Client{Timeout: 30 * time.Minute},
+	}
+}
+
+// DefaultClient is the client of ListArchives and Download
+var DefaultClient = NewClient("filingsdb")
+
+// StatusError is the error of a request answered with an unexpected status
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: %s", e.URL, e.Status)
+}
+
+// temporary reports whether the request may succeed if retried
+func (e *StatusError) temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Get gets url, retrying on failure. The response has a 2xx status, other
+// statuses being returned as a *StatusError.
+func (c *Client) Get(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.get(ctx, url, header)
+		if err == nil {
+			return resp, nil
+		}
+		var retryAfter time.Duration
+		if resp != nil {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
+		if !c.retry(ctx, err, attempt, retryAfter) {
+			return nil, err
+		}
+	}
+}
+
+// get does a single request, returning the response of an unexpected status
+// along with its *StatusError
+func (c *Client) get(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		return resp, &StatusError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return resp, nil
+}
+
+// retry reports whether to retry after the failed attempt, waiting for the
+// backoff delay (or retryAfter if longer) first
+func (c *Client) retry(ctx context.Context, err error, attempt int, retryAfter time.Duration) bool {
+	if ctx.Err() != nil || attempt >= c.Retries {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && !statusErr.temporary() {
+		return false
+	}
+	delay := c.Backoff << uint(attempt)
+	if c.MaxBackoff > 0 && (delay > c.MaxBackoff || delay <= 0) {
+		delay = c.MaxBackoff
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	if c.Logger != nil {
+		c.Logger.Printf("%v, retrying in %v", err, delay)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// wait blocks until the rate limit allows another request
+func (c *Client) wait(ctx context.Context) error {
+	if c.RateLimit <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	now := time.Now()
+	at := c.next
+	if at.Before(now) {
+		at = now
+	}
+	c.next = at.Add(time.Duration(float64(time.Second) / c.RateLimit))
+	c.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses the delay in seconds of a Retry-After header
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Download downloads url to the file filepath, teeing the content into
+// progress if not nil. The content is written to filepath.tmp first, renamed
+// once complete: the download of an existing filepath.tmp, e.g. left by an
+// interrupted run, resumes where it stopped if the content at url didn't
+// change since, as told by its ETag or Last-Modified date.
+func (c *Client) Download(ctx context.Context, url string, filepath string, progress io.Writer) error {
+	_, err := c.download(ctx, url, filepath, nil, progress)
+	return err
+}
+
+// validatorSuffix is the suffix of the file keeping the validator of a
+// partial download next to it
+const validatorSuffix = ".validator"
+
+// errStale is the error of a partial download that can't be resumed, the
+// content at its URL having changed
+var errStale = errors.New("the content changed since the partial download")
+
+// download is Download sending header along, e.g. conditional request
+// headers. It returns the header of the response.
+func (c *Client) download(ctx context.Context, url string, filepath string, header http.Header, progress io.Writer) (http.Header, error) {
+	tmp := filepath + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		offset, err := out.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, err
+		}
+		validator := ""
+		if offset > 0 {
+			b, _ := ioutil.ReadFile(tmp + validatorSuffix)
+			validator = string(b)
+		}
+		if offset > 0 && validator == "" {
+			// no telling whether the content changed since
+			if offset, err = restart(out); err != nil {
+				return nil, err
+			}
+		}
+		resp, err = c.downloadFrom(ctx, url, out, offset, validator, header, progress)
+		if errors.Is(err, errStale) && offset > 0 {
+			// start over right away, without the range
+			if _, err := restart(out); err != nil {
+				return nil, err
+			}
+			attempt--
+			continue
+		}
+		if err == nil {
+			break
+		}
+		var retryAfter time.Duration
+		if resp != nil {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
+		if !c.retry(ctx, err, attempt, retryAfter) {
+			return nil, err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return nil, err
+	}
+	os.Remove(tmp + validatorSuffix)
+
+	// Rename the tmp file back to the original file
+	return resp.Header, os.Rename(tmp, filepath)
+}
+
+// restart empties out, returning its new offset
+func restart(out *os.File) (int64, error) {
+	if err := out.Truncate(0); err != nil {
+		return 0, err
+	}
+	return out.Seek(0, io.SeekStart)
+}
+
+// downloadFrom appends the content of url from offset to out in a single
+// request, returning its response. The request is for the range from offset
+// if the content still has validator (If-Range), the whole content being
+// written again otherwise. It fails with errStale if the range can't be
+// downloaded, and with an error to retry if the content is cut short.
+func (c *Client) downloadFrom(ctx context.Context, url string, out *os.File, offset int64, validator string, header http.Header, progress io.Writer) (*http.Response, error) {
+	h := http.Header{}
+	for k, v := range header {
+		h[k] = v
+	}
+	if offset > 0 {
+		h.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		h.Set("If-Range", validator)
+	}
+	resp, err := c.get(ctx, url, h)
+	var statusErr *StatusError
+	if offset > 0 && errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// the partial content is longer than the content, which changed
+		return resp, errStale
+	}
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	size := resp.ContentLength
+	if offset > 0 && resp.StatusCode == http.StatusPartialContent {
+		start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			return resp, errStale
+		}
+		if etag := resp.Header.Get("ETag"); etag != "" && strings.HasPrefix(validator, `"`) && etag != validator {
+			// a server ignoring If-Range
+			return resp, errStale
+		}
+		size = total
+	} else {
+		// the whole content, changed or the range ignored: start over
+		if offset, err = restart(out); err != nil {
+			return resp, err
+		}
+		v := validatorOf(resp.Header)
+		if v == "" {
+			os.Remove(out.Name() + validatorSuffix)
+		} else if err := ioutil.WriteFile(out.Name()+validatorSuffix, []byte(v), 0644); err != nil {
+			return resp, err
+		}
+	}
+	var body io.Reader = resp.Body
+	if progress != nil {
+		body = io.TeeReader(resp.Body, progress)
+	}
+	n, err := io.Copy(out, body)
+	if err != nil {
+		return resp, err
+	}
+	if size >= 0 && offset+n != size {
+		return resp, fmt.Errorf("GET %s: got %d bytes of %d", url, offset+n, size)
+	}
+	return resp, nil
+}
+
+// validatorOf returns the validator of a response to send as If-Range: its
+// ETag if strong, else its Last-Modified date, empty if none
+func validatorOf(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return header.Get("Last-Modified")
+}
+
+// parseContentRange parses the first position and the complete length of a
+// Content-Range header, e.g. bytes 500-999/1000, the length being -1 if
+// unknown (*)
+func parseContentRange(value string) (int64, int64, bool) {
+	var first, last int64
+	var total string
+	if _, err := fmt.Sscanf(value, "bytes %d-%d/%s", &first, &last, &total); err != nil {
+		return 0, 0, false
+	}
+	if total == "*" {
+		return first, -1, true
+	}
+	length, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return first, length, true
+}
@@ -0,0 +1,175 @@
+package filingsdb
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testClient returns a client of srv without rate limit nor backoff delay
+func testClient(srv *httptest.Server) *Client {
+	c := NewClient("filingsdb test@example.com")
+	c.BaseURL = srv.URL
+	c.RateLimit = 0
+	c.Backoff = time.Millisecond
+	c.MaxBackoff = time.Millisecond
+	return c
+}
+
+func TestClientUserAgent(t *testing.T) {
+	var userAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	}))
+	defer srv.Close()
+
+	resp, err := testClient(srv).Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if userAgent != "filingsdb test@example.com" {
+		t.Errorf("User-Agent is %q", userAgent)
+	}
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	var mu sync.Mutex
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		times = append(times, time.Now())
+		if len(times) == 1 {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	resp, err := testClient(srv).Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(times) != 2 {
+		t.Fatalf("%d requests, expected 2", len(times))
+	}
+	if delay := times[1].Sub(times[0]); delay < time.Second {
+		t.Errorf("retried after %v, before the Retry-After delay", delay)
+	}
+}
+
+func TestClientNotRetried(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	_, err := testClient(srv).Get(context.Background(), srv.URL, nil)
+	if statusErr, ok := err.(*StatusError); !ok || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("got %v, expected a 404 StatusError", err)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, a 404 isn't to be retried", requests)
+	}
+}
+
+// interrupting serves content, its first response being cut after half of
+// it, and records the Range headers received
+type interrupting struct {
+	content []byte
+	ranges  []string
+}
+
+func (s *interrupting) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	w.Header().Set("ETag", `"v1"`)
+	if len(s.ranges) == 1 {
+		w.Header().Set("Content-Length", "1000")
+		w.Write(s.content[:500])
+		return // the connection is closed before the end of the body
+	}
+	http.ServeContent(w, r, "archive.zip", time.Time{}, bytes.NewReader(s.content))
+}
+
+func TestDownloadResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filingsdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	srv := &interrupting{content: bytes.Repeat([]byte("0123456789"), 100)}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	file := filepath.Join(dir, "archive.zip")
+	if err := testClient(ts).Download(context.Background(), ts.URL, file, nil); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, srv.content) {
+		t.Errorf("downloaded %d bytes, expected the %d of the content", len(b), len(srv.content))
+	}
+	if len(srv.ranges) != 2 || srv.ranges[0] != "" || srv.ranges[1] != "bytes=500-" {
+		t.Errorf("requested the ranges %q, expected none then bytes=500-", srv.ranges)
+	}
+}
+
+func TestFetchURLRemovesPartial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filingsdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Setenv("TMPDIR", os.Getenv("TMPDIR"))
+	os.Setenv("TMPDIR", dir)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.Write([]byte(strings.Repeat("x", 500)))
+	}))
+	defer srv.Close()
+
+	client := testClient(srv)
+	client.Retries = 1
+	if _, _, err := fetchURL(context.Background(), client, nil, srv.URL+"/2019q1_notes.zip", nil); err == nil {
+		t.Fatal("fetched a truncated archive")
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		t.Errorf("%s left behind", f.Name())
+	}
+
+	complete := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("archive"))
+	}))
+	defer complete.Close()
+	file, release, err := fetchURL(context.Background(), testClient(complete), nil, complete.URL+"/2019q1_notes.zip", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadFile(file); string(b) != "archive" {
+		t.Errorf("fetched %q", b)
+	}
+	release()
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("%s not released", file)
+	}
+}
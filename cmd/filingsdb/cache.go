@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"eswiac.me/filingsdb"
+	"github.com/dustin/go-humanize"
+)
+
+// cacheDirFlag declares the flag of the archive cache directory
+func cacheDirFlag(fs *flag.FlagSet, usage string) *string {
+	dir, err := filingsdb.DefaultCacheDir()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return fs.String("cache", dir, usage)
+}
+
+func cacheCmd(args []string) {
+	if len(args) == 0 || (args[0] != "ls" && args[0] != "prune" && args[0] != "verify") {
+		log.Fatal("expected a cache command: ls, prune or verify")
+	}
+	fs := flag.NewFlagSet("cache "+args[0], flag.ExitOnError)
+	dir := cacheDirFlag(fs, "archive cache directory")
+	olderThan := fs.Duration("older-than", 0, "prune: also remove the archives downloaded longer ago than this, e.g. 720h")
+	all := fs.Bool("all", false, "prune: remove every archive")
+	fs.Parse(args[1:])
+
+	cache, err := filingsdb.OpenCache(*dir, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch args[0] {
+	case "ls":
+		entries, err := cache.Entries()
+		if err != nil {
+			log.Fatal(err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "name\tsize\tfetched\tchecked\tsha256\turl")
+		var total int64
+		for _, e := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Name(), humanize.Bytes(uint64(e.Size)),
+				e.Fetched.Format("2006-01-02 15:04"), e.Checked.Format("2006-01-02 15:04"), e.SHA256[:12], e.URL)
+			total += e.Size
+		}
+		w.Flush()
+		fmt.Printf("%d archives, %s in %s\n", len(entries), humanize.Bytes(uint64(total)), cache.Dir())
+	case "prune":
+		before := time.Time{}
+		if *all {
+			before = time.Now()
+		} else if *olderThan > 0 {
+			before = time.Now().Add(-*olderThan)
+		}
+		freed, err := cache.Prune(before)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s freed\n", humanize.Bytes(uint64(freed)))
+	case "verify":
+		bad, err := cache.Verify(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, e := range bad {
+			fmt.Printf("%s: missing or corrupted, removed from the cache\n", e.Name())
+		}
+		if len(bad) > 0 {
+			os.Exit(1)
+		}
+		fmt.Println("all archives ok")
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"eswiac.me/filingsdb"
+)
+
+// companyFactsCmd loads companyfacts.zip, or a CIK##########.json of it, into
+// a filings database, created if needed
+func companyFactsCmd(args []string) {
+	fs := flag.NewFlagSet("companyfacts", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to load the facts into, created if needed")
+	forms := fs.String("forms", "", "comma separated list of forms to load, all of them if empty")
+	ciks := fs.String("cik", "", "comma separated list of company CIKs to load, all of them if empty")
+	tags := fs.String("tags", "", "comma separated list of tags to load, all of them if empty")
+	excludeTags := fs.String("exclude-tags", "", "comma separated list of tags not to load")
+	fs.Parse(args)
+	if *file == "" {
+		log.Fatal("missing --db, the filings database to load the facts into")
+	}
+	if fs.NArg() != 1 {
+		log.Fatal("missing the companyfacts.zip or CIK##########.json file to load")
+	}
+	db, err := filingsdb.Open(*file,
+		filingsdb.WithLogger(log.New(os.Stdout, "", 0)),
+		filingsdb.WithFilter(filingsdb.IngestFilter{
+			Forms:       splitList(*forms),
+			Ciks:        splitList(*ciks),
+			Tags:        splitList(*tags),
+			ExcludeTags: splitList(*excludeTags),
+		}))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := interruptible()
+	defer cancel()
+	if err := db.IngestCompanyFacts(ctx, fs.Arg(0)); err != nil {
+		log.Fatal(err)
+	}
+}
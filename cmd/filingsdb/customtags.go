@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"eswiac.me/filingsdb/customtags"
+	"eswiac.me/filingsdb/models"
+)
+
+func customTagsCmd(args []string) {
+	fs := flag.NewFlagSet("customtags", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to read from")
+	companies := fs.String("cik", "", "comma separated list of company CIKs or tickers, all of them if empty")
+	forms := fs.String("forms", "", "comma separated list of forms of the submissions, all of them if empty")
+	suggest := fs.Bool("suggest", false, "suggest a standard tag for every custom tag and store them in the tag_mappings table")
+	mappings := fs.Bool("mappings", false, "list the mappings stored in the tag_mappings table")
+	status := fs.String("status", "", "only list the mappings with this status: suggested, accepted or rejected")
+	accept := fs.String("accept", "", "accept the mapping of a custom tag, <adsh>:<tag>, or map it to another standard tag, <adsh>:<tag>=<standard tag>")
+	reject := fs.String("reject", "", "reject the mapping of a custom tag, <adsh>:<tag>")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	db := openExistingDB(*file)
+	if *accept != "" || *reject != "" {
+		review := func(mapping string, status string) {
+			mapped := ""
+			if i := strings.Index(mapping, "="); i >= 0 {
+				mapping, mapped = mapping[:i], mapping[i+1:]
+			}
+			parts := strings.SplitN(mapping, ":", 2)
+			if len(parts) != 2 {
+				log.Fatalf("invalid mapping `%s`, expected <adsh>:<tag>", mapping)
+			}
+			if err := db.ReviewTagMapping(ctx, parts[0], parts[1], status, mapped); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if *accept != "" {
+			review(*accept, models.MappingAccepted)
+		}
+		if *reject != "" {
+			review(*reject, models.MappingRejected)
+		}
+		return
+	}
+
+	q := customtags.Query{Forms: splitList(*forms)}
+	for _, company := range splitList(*companies) {
+		cik, err := db.ResolveCIK(ctx, company)
+		if err != nil {
+			log.Fatal(err)
+		}
+		q.Ciks = append(q.Ciks, cik)
+	}
+	if *suggest {
+		if err := db.SuggestTagMappings(ctx, q); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *suggest || *mappings {
+		found, err := db.TagMappings(ctx, q, *status)
+		if err != nil {
+			log.Fatal(err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "adsh\ttag\tstmt\tmapped\tscore\tcalc\tpres\tlabel\tstatus")
+		for _, m := range found {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%.3f\t%.3f\t%.3f\t%.3f\t%s\n", m.Adsh, m.Tag, m.Stmt, str(m.Mapped),
+				m.Score, m.CalcScore, m.PresScore, m.LabelScore, m.Status)
+		}
+		w.Flush()
+		return
+	}
+
+	usage, err := db.CustomTagUsage(ctx, q)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "cik\tname\tfilings\tfacts\tcustom facts\tshare\tcustom tags")
+	for _, u := range usage {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%.1f%%\t%d\n", u.Cik, u.Name, u.Filings, u.Facts, u.CustomFacts, 100*u.Share(), u.CustomTags)
+	}
+	w.Flush()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+
+	"eswiac.me/filingsdb"
+)
+
+// openExistingDB opens a filings database built beforehand by `filingsdb <year>`
+func openExistingDB(file string) *filingsdb.DB {
+	if file == "" {
+		log.Fatal("missing --db, the filings database to read from (e.g. filings_2019.db)")
+	}
+	db, err := filingsdb.OpenExisting(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return db
+}
+
+// interruptible returns a context canceled on ^C, to stop cleanly
+func interruptible() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		cancel()
+	}()
+	return ctx, cancel
+}
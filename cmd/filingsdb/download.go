@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"eswiac.me/filingsdb"
+	"github.com/briandowns/spinner"
+	"github.com/dustin/go-humanize"
+)
+
+func (wc *WriteCounter) Write(p []byte) (int, error) {
+	n := len(p)
+	wc.Total += uint64(n)
+	wc.PrintProgress()
+	return n, nil
+}
+
+type WriteCounter struct {
+	Total uint64
+}
+
+// PrintProgress prints the progress of a file write
+func (wc WriteCounter) PrintProgress() {
+	// Clear the line by using a character return to go back to the start and remove
+	// the remaining characters by filling it with spaces
+	fmt.Printf("\r%s", strings.Repeat(" ", 50))
+
+	// Return again and print current status of download
+	// We use the humanize package to print the bytes in a meaningful way (e.g. 10 MB)
+	fmt.Printf("\rDownloading... %s complete", humanize.Bytes(wc.Total))
+}
+
+// progressLogger prints the messages of the library on their own line,
+// ending the progress line of the download in progress if any
+type progressLogger struct {
+	counter *WriteCounter
+}
+
+func (l progressLogger) Printf(format string, v ...interface{}) {
+	if l.counter.Total > 0 {
+		fmt.Println()
+		l.counter.Total = 0
+	}
+	fmt.Printf(format+"\n", v...)
+}
+
+type Downloader struct {
+	db       *filingsdb.DB
+	source   filingsdb.ArchiveSource
+	archives []filingsdb.Archive
+	year     string
+}
+
+func dbName(year string) string {
+	return fmt.Sprintf("filings_%v.db", year)
+}
+
+// parseSource returns the archive source of spec: sec.gov if empty or "sec",
+// an S3 bucket for s3://bucket/prefix, a manifest for a .json file or URL,
+// or a local directory
+func parseSource(spec string, client *filingsdb.Client, cache *filingsdb.Cache) filingsdb.ArchiveSource {
+	switch {
+	case spec == "" || spec == "sec":
+		return &filingsdb.SECSource{Client: client, Cache: cache}
+	case strings.HasPrefix(spec, "s3://"):
+		bucket := strings.TrimPrefix(spec, "s3://")
+		prefix := ""
+		if i := strings.Index(bucket, "/"); i >= 0 {
+			bucket, prefix = bucket[:i], bucket[i+1:]
+		}
+		src := filingsdb.NewS3Source(bucket, prefix)
+		src.Client = client
+		return src
+	case strings.HasSuffix(spec, ".json"):
+		return &filingsdb.ManifestSource{Location: spec, Client: client, Cache: cache}
+	}
+	return &filingsdb.DirSource{Dir: spec}
+}
+
+func New(year string, client *filingsdb.Client, source filingsdb.ArchiveSource, opts ...filingsdb.Option) *Downloader {
+	yearInt, err := strconv.Atoi(year)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if yearInt < 2009 || yearInt > 2100 {
+		log.Fatalf("Filings are not available before 2009 and after 2100")
+	}
+	archives, err := source.ListArchives(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	yearArchives := []filingsdb.Archive{} // 4 qtr in a year
+	for _, archive := range archives {
+		if strings.HasPrefix(archive.Name, year) {
+			yearArchives = append(yearArchives, archive)
+		}
+	}
+	if len(yearArchives) == 0 {
+		log.Fatalf("Couldn't find any filings filed in %v", year)
+	}
+
+	_, err = os.Stat(dbName(year))
+	if !os.IsNotExist(err) {
+		log.Fatalf("filings database already exists. This script does not perform differential updates. Please rename or move %v in order to rebuild the filings database.", dbName(year))
+	}
+	counter := &WriteCounter{}
+	client.Logger = progressLogger{counter}
+	opts = append(opts, filingsdb.WithClient(client), filingsdb.WithLogger(progressLogger{counter}), filingsdb.WithProgress(counter))
+	db, err := filingsdb.Open(dbName(year), opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return &Downloader{source: source, archives: yearArchives, db: db, year: year}
+}
+
+func (d Downloader) Start() {
+	ctx, cancel := interruptible()
+	defer cancel()
+
+	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
+	s.Start()
+	fmt.Println("Processing started, please be patient. This may take a while!")
+	// the archives may come from elsewhere than sec.gov, e.g. offline
+	if err := d.db.IngestTickers(ctx); err != nil {
+		log.Printf("Couldn't load the tickers: %v", err)
+	}
+	if err := d.db.LoadSicCodes(ctx); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, archive := range d.archives {
+		if err := d.db.IngestFrom(ctx, d.source, archive); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	s.Stop()
+	fmt.Printf("Processing complete. You can now open your %v filings database with `sqlite3 %v`", d.year, dbName((d.year)))
+	fmt.Println()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"eswiac.me/filingsdb/export"
+)
+
+// exportCmd writes the facts of a filing in a standard format
+func exportCmd(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to read from")
+	adsh := fs.String("adsh", "", "accession number of the filing")
+	format := fs.String("format", "xbrl-json", "output format: xbrl-json")
+	out := fs.String("out", "", "output file, stdout if empty")
+	fs.Parse(args)
+	if *adsh == "" {
+		log.Fatal("missing --adsh, the accession number of the filing to export")
+	}
+
+	db := openExistingDB(*file)
+	filing, err := db.Filing(context.Background(), *adsh)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		w, err = os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer w.Close()
+	}
+	if err := export.Filing(w, filing, *format); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"eswiac.me/filingsdb"
+	"eswiac.me/filingsdb/export"
+	"eswiac.me/filingsdb/query"
+)
+
+// factFlags are the flags shared by the commands querying facts of a company
+type factFlags struct {
+	db      *string
+	company *string
+	asOf    *string
+}
+
+func newFactFlags(fs *flag.FlagSet) factFlags {
+	return factFlags{
+		db:      fs.String("db", "", "filings database to read from"),
+		company: fs.String("cik", "", "company CIK or ticker"),
+		asOf:    fs.String("as-of", "", "only use filings accepted by this date (5:30pm EST cutoff) or timestamp"),
+	}
+}
+
+func (ff factFlags) open() (*filingsdb.DB, string, query.Filter) {
+	db := openExistingDB(*ff.db)
+	cik, err := db.ResolveCIK(context.Background(), *ff.company)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return db, cik, parseFilter(*ff.asOf)
+}
+
+func parseFilter(asOf string) query.Filter {
+	filter := query.Filter{}
+	if asOf != "" {
+		t, err := query.ParseAsOf(asOf)
+		if err != nil {
+			log.Fatal(err)
+		}
+		filter.AsOf = t
+	}
+	return filter
+}
+
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func printFacts(facts []query.Fact) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "tag\tddate\tqtrs\tuom\tvalue\tadsh\tform\taccepted")
+	for _, f := range facts {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n", f.Tag, f.Ddate, f.Qtrs, f.Uom, f.Value, f.Adsh, f.Form, f.Accepted)
+	}
+	w.Flush()
+}
+
+func fundamentalsCmd(args []string) {
+	fs := flag.NewFlagSet("fundamentals", flag.ExitOnError)
+	ff := newFactFlags(fs)
+	tags := fs.String("tags", "", "comma separated list of tags, all of them if empty")
+	fs.Parse(args)
+
+	db, cik, filter := ff.open()
+	facts, err := db.Fundamentals(context.Background(), query.FactQuery{Filter: filter, Cik: cik, Tags: splitList(*tags)})
+	if err != nil {
+		log.Fatal(err)
+	}
+	printFacts(facts)
+}
+
+func seriesCmd(args []string) {
+	fs := flag.NewFlagSet("series", flag.ExitOnError)
+	ff := newFactFlags(fs)
+	tag := fs.String("tag", "", "tag to read the values of")
+	fs.Parse(args)
+	if *tag == "" {
+		log.Fatal("missing --tag")
+	}
+
+	db, cik, filter := ff.open()
+	facts, err := db.Series(context.Background(), query.FactQuery{Filter: filter, Cik: cik}, *tag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	printFacts(facts)
+}
+
+func timeseriesCmd(args []string) {
+	fs := flag.NewFlagSet("timeseries", flag.ExitOnError)
+	ff := newFactFlags(fs)
+	tags := fs.String("tags", "", "comma separated list of tags, all of them if empty")
+	uom := fs.String("uom", "", "unit of measure, e.g. USD or shares, any if empty")
+	dim := fs.String("dim", "", "dimension segments (e.g. ProductOrService=Advertising;) or dimh, none if empty")
+	allPriorities := fs.Bool("all-priorities", false, "include facts with iprx > 1")
+	format := fs.String("format", "csv", "output format: csv, json or parquet")
+	out := fs.String("out", "", "output file, stdout if empty")
+	fs.Parse(args)
+
+	db, cik, filter := ff.open()
+	dimh, err := db.ResolveDimh(context.Background(), *dim)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ts, err := db.TimeSeries(context.Background(), query.FactQuery{
+		Filter:        filter,
+		Cik:           cik,
+		Tags:          splitList(*tags),
+		Uom:           *uom,
+		Dimh:          dimh,
+		AllPriorities: *allPriorities,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		w, err = os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer w.Close()
+	}
+	if err := export.TimeSeries(w, ts, *format); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func statementCmd(args []string) {
+	fs := flag.NewFlagSet("statement", flag.ExitOnError)
+	ff := newFactFlags(fs)
+	stmt := fs.String("stmt", "BS", "financial statement: BS, IS, CF, EQ or CI")
+	adsh := fs.String("adsh", "", "accession number of the filing, the most recent one if empty")
+	fs.Parse(args)
+
+	var st *query.Statement
+	var err error
+	if *adsh != "" {
+		db := openExistingDB(*ff.db)
+		st, err = db.FilingStatement(context.Background(), *adsh, strings.ToUpper(*stmt), parseFilter(*ff.asOf))
+	} else {
+		db, cik, filter := ff.open()
+		st, err = db.LatestStatement(context.Background(), query.StatementQuery{Filter: filter, Cik: cik, Stmt: strings.ToUpper(*stmt)})
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	printStatement(st)
+}
+
+func printStatement(st *query.Statement) {
+	fmt.Printf("%s %s %s (%s, period %s, accepted %s)\n", st.Sub.Name, st.Sub.Form, st.Stmt, st.Sub.Adsh, st.Sub.Period, st.Sub.Accepted)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "line\tlabel\ttag\tddate\tqtrs\tvalue\tfrom")
+	for _, line := range st.Lines {
+		if len(line.Facts) == 0 {
+			fmt.Fprintf(w, "%d\t%s\t%s\t\t\t\t\n", line.Line, line.Plabel, line.Tag)
+		}
+		for _, f := range line.Facts {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%s\t%s\n", line.Line, line.Plabel, line.Tag, f.Ddate, f.Qtrs, f.Value, f.Adsh)
+		}
+	}
+	w.Flush()
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"eswiac.me/filingsdb"
+)
+
+// command is a filingsdb subcommand reading an existing filings database
+type command struct {
+	usage string
+	run   func(args []string)
+}
+
+var commands = map[string]command{
+	"cache":        {"ls|prune|verify [--cache <dir>] [--older-than <duration>] [--all]", cacheCmd},
+	"companyfacts": {"--db <file> [--forms 10-K,10-Q] [--cik c1,c2] [--tags t1,t2] [--exclude-tags t1,t2] <companyfacts.zip|CIK##########.json>", companyFactsCmd},
+	"customtags":   {"--db <file> [--cik c1,c2] [--forms 10-K,10-Q] [--suggest | --mappings [--status suggested|accepted|rejected] | --accept <adsh>:<tag>[=<standard tag>] | --reject <adsh>:<tag>]", customTagsCmd},
+	"export":       {"--db <file> --adsh <adsh> [--format xbrl-json] [--out <file>]", exportCmd},
+	"fundamentals": {"--db <file> --cik <cik|ticker> [--tags t1,t2] [--as-of <date>]", fundamentalsCmd},
+	"peers":        {"--db <file> --cik <cik|ticker> [--metrics m1,m2] [--fy <year>] [--fp FY|Q1|Q2|Q3] [--major-group] [--list] [--as-of <date>]", peersCmd},
+	"ratios":       {"--db <file> (--cik <cik|ticker> | --adsh <adsh> | --materialize) [--forms 10-K,10-Q]", ratiosCmd},
+	"series":       {"--db <file> --cik <cik|ticker> --tag <tag> [--as-of <date>]", seriesCmd},
+	"serve":        {"--db <file> [--addr :8080] [--grpc-addr :9090]", serveCmd},
+	"render":       {"--db <file> (--adsh <adsh> | --cik <cik|ticker>) --out <dir>", renderCmd},
+	"reports":      {"--db <file> --adsh <adsh> [<report, e.g. R4>]", reportsCmd},
+	"screen":       {"--db <file> [--columns c1,c2] [--format table|csv] [--as-of <date>] <expression>", screenCmd},
+	"submissions":  {"--db <file> [--cik c1,c2] <submissions.zip|CIK##########.json>", submissionsCmd},
+	"statement":    {"--db <file> --cik <cik|ticker> --stmt <BS|IS|CF|EQ|CI> [--adsh <adsh>] [--as-of <date>]", statementCmd},
+	"tags":         {"--db <file> [--custom true|false] [--version <version>] [--sort usage|tag] [--limit <n>] [--doc] [search text]", tagsCmd},
+	"timeseries":   {"--db <file> --cik <cik|ticker> [--tags t1,t2] [--uom USD] [--dim <segments>] [--format csv|json|parquet] [--out <file>] [--as-of <date>]", timeseriesCmd},
+	"tui":          {"--db <file>", tuiCmd},
+	"xbrl":         {"--db <file> --adsh <adsh> [--accepted <time>] [--txt keep|skip|no-blocks|compress] <instance.xml|document.htm>", xbrlCmd},
+}
+
+func commandNames() []string {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func usage() {
+	fmt.Println("Usage: filingsdb [--forms 10-K,10-Q] [--cik c1,c2] [--sic s1,s2] [--tags t1,t2] [--exclude-tags t1,t2] [--primary-only] [--txt keep|skip|no-blocks|compress] [--user-agent \"<name> <email>\"] [--cache <dir>] [--source sec|<dir>|<manifest.json>|s3://<bucket>/<prefix>] [--dataset notes|statements] <year>")
+	for _, name := range commandNames() {
+		fmt.Printf("       filingsdb %s %s\n", name, commands[name].usage)
+	}
+	os.Exit(-1)
+}
+
+func main() {
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	if len(os.Args) < 2 {
+		usage()
+	}
+	if cmd, ok := commands[os.Args[1]]; ok {
+		cmd.run(os.Args[2:])
+		return
+	}
+	fs := flag.NewFlagSet("filingsdb", flag.ExitOnError)
+	forms := fs.String("forms", "", "comma separated list of forms to load, all of them if empty")
+	ciks := fs.String("cik", "", "comma separated list of company CIKs to load, all of them if empty")
+	sics := fs.String("sic", "", "comma separated list of SIC codes to load, all of them if empty")
+	tags := fs.String("tags", "", "comma separated list of tags to load, all of them if empty")
+	excludeTags := fs.String("exclude-tags", "", "comma separated list of tags not to load")
+	primaryOnly := fs.Bool("primary-only", false, "only load the facts of primary reports (iprx = 1)")
+	txt := fs.String("txt", "keep", "text facts policy: keep, skip, no-blocks (skip text blocks) or compress")
+	userAgent := fs.String("user-agent", os.Getenv("FILINGSDB_USER_AGENT"), "User-Agent declared to sec.gov, a name and a contact email (defaults to $FILINGSDB_USER_AGENT)")
+	cacheDir := cacheDirFlag(fs, "directory the archives are cached in, none if empty")
+	source := fs.String("source", "sec", "where to get the archives from: sec (sec.gov), a local directory, a JSON manifest file or URL, or s3://<bucket>/<prefix>")
+	dataset := fs.String("dataset", "", "data sets downloaded from sec.gov, with --source sec: notes (Financial Statement and Notes, the default) or statements (Financial Statement, smaller and older)")
+	fs.Usage = usage
+	fs.Parse(os.Args[1:])
+	if fs.NArg() != 1 {
+		usage()
+	}
+	policy, err := filingsdb.ParseTextPolicy(*txt)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := filingsdb.NewClient(*userAgent)
+	if *userAgent == "" {
+		client.UserAgent = filingsdb.DefaultClient.UserAgent
+		log.Println("no --user-agent given, sec.gov may throttle or block the requests")
+	}
+	opts := []filingsdb.Option{
+		filingsdb.WithTextPolicy(policy),
+		filingsdb.WithFilter(filingsdb.IngestFilter{
+			Forms:       splitList(*forms),
+			Ciks:        splitList(*ciks),
+			Sics:        splitList(*sics),
+			Tags:        splitList(*tags),
+			ExcludeTags: splitList(*excludeTags),
+			PrimaryOnly: *primaryOnly,
+		}),
+	}
+	var cache *filingsdb.Cache
+	if *cacheDir != "" {
+		if cache, err = filingsdb.OpenCache(*cacheDir, client); err != nil {
+			log.Fatal(err)
+		}
+	}
+	src := parseSource(*source, client, cache)
+	if *dataset != "" {
+		sec, ok := src.(*filingsdb.SECSource)
+		if !ok {
+			log.Fatalf("--dataset only applies to --source sec, the variant of other archives is recognized from their files")
+		}
+		sec.Dataset = *dataset
+	}
+	downloader := New(fs.Arg(0), client, src, opts...)
+	downloader.Start()
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"eswiac.me/filingsdb/peers"
+)
+
+func peersCmd(args []string) {
+	fs := flag.NewFlagSet("peers", flag.ExitOnError)
+	ff := newFactFlags(fs)
+	metrics := fs.String("metrics", "", "comma separated list of tags, concepts or ratios to compare, defaults to "+strings.Join(peers.DefaultMetrics, ","))
+	fy := fs.String("fy", "", "fiscal year to compare, defaults to the last annual report of the company")
+	fp := fs.String("fp", "FY", "fiscal period to compare: FY, Q1, Q2 or Q3")
+	majorGroup := fs.Bool("major-group", false, "compare with the SIC major group (first two digits) instead of the SIC code")
+	list := fs.Bool("list", false, "also list the values of every peer")
+	fs.Parse(args)
+
+	db, cik, filter := ff.open()
+	c, err := db.Peers(context.Background(), peers.Query{
+		Filter:     filter,
+		Cik:        cik,
+		MajorGroup: *majorGroup,
+		Fy:         *fy,
+		Fp:         *fp,
+		Metrics:    splitList(*metrics),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%s (CIK %s) %s %s\n", c.Company.Sub.Name, c.Company.Sub.Cik, c.Fy, c.Fp)
+	fmt.Printf("SIC %s %s, %d companies\n\n", c.Industry, c.Description, len(c.Peers))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "metric\tvalue\trank\tn\tmin\tq1\tmedian\tq3\tmax\t")
+	for _, s := range c.Stats {
+		value, rank := "", ""
+		if s.Value != nil {
+			value, rank = formatStat(*s.Value), fmt.Sprintf("%d/%d", s.Rank, s.Count)
+		}
+		if s.Count == 0 {
+			fmt.Fprintf(w, "%s\t%s\t%s\t0\t\t\t\t\t\t\n", s.Metric, value, rank)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t\n", s.Metric, value, rank, s.Count,
+			formatStat(s.Min), formatStat(s.Q1), formatStat(s.Median), formatStat(s.Q3), formatStat(s.Max))
+	}
+	w.Flush()
+
+	if *list {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "cik\tname\tsic\tadsh\t"+strings.Join(c.Metrics, "\t"))
+		for _, row := range c.Peers {
+			record := []string{row.Sub.Cik, row.Sub.Name, row.Sub.Sic, row.Sub.Adsh}
+			for _, v := range row.Values {
+				record = append(record, formatValue(v))
+			}
+			fmt.Fprintln(w, strings.Join(record, "\t"))
+		}
+		w.Flush()
+	}
+}
+
+// formatStat rounds interpolated values to 4 decimals
+func formatStat(v float64) string {
+	return formatValue(math.Round(v*1e4) / 1e4)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"eswiac.me/filingsdb/ratios"
+)
+
+func ratiosCmd(args []string) {
+	fs := flag.NewFlagSet("ratios", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to read from")
+	company := fs.String("cik", "", "company CIK or ticker")
+	adsh := fs.String("adsh", "", "accession number of a single filing")
+	forms := fs.String("forms", "10-K,10-Q", "comma separated list of forms to compute ratios for")
+	materialize := fs.Bool("materialize", false, "compute ratios for every filing and store them in the ratios table")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	db := openExistingDB(*file)
+	if *materialize {
+		if err := db.MaterializeRatios(ctx, splitList(*forms)); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var results []ratios.Result
+	var err error
+	if *adsh != "" {
+		results, err = db.SubmissionRatios(ctx, *adsh)
+	} else {
+		var cik string
+		cik, err = db.ResolveCIK(ctx, *company)
+		if err == nil {
+			results, err = db.Ratios(ctx, cik, splitList(*forms))
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "adsh\tddate\tqtrs\tratio\tvalue\tmissing")
+	for _, r := range results {
+		value := ""
+		if r.Value != nil {
+			value = r.Value.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", r.Adsh, r.Ddate, r.Qtrs, r.Name, value, strings.Join(r.Missing, ","))
+	}
+	w.Flush()
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+)
+
+// renderCmd writes a static HTML site of a filing or a company
+func renderCmd(args []string) {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to read from")
+	adsh := fs.String("adsh", "", "accession number of the filing")
+	company := fs.String("cik", "", "company CIK or ticker, for its page alone")
+	out := fs.String("out", "", "directory to write the pages to")
+	fs.Parse(args)
+	if *out == "" {
+		log.Fatal("missing --out, the directory to write the pages to")
+	}
+	if (*adsh == "") == (*company == "") {
+		log.Fatal("expected either --adsh, the accession number of the filing, or --cik, the company")
+	}
+
+	ctx := context.Background()
+	db := openExistingDB(*file)
+	if *adsh != "" {
+		if err := db.RenderFiling(ctx, *adsh, *out); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	cik, err := db.ResolveCIK(ctx, *company)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := db.RenderCompany(ctx, cik, *out); err != nil {
+		log.Fatal(err)
+	}
+}
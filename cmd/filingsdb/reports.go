@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"eswiac.me/filingsdb/query"
+)
+
+func reportsCmd(args []string) {
+	fs := flag.NewFlagSet("reports", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to read from")
+	adsh := fs.String("adsh", "", "accession number of the filing")
+	fs.Parse(args)
+	if *adsh == "" {
+		log.Fatal("missing --adsh, the accession number of the filing")
+	}
+	if fs.NArg() > 1 {
+		log.Fatal("expected a single report, e.g. R4")
+	}
+
+	db := openExistingDB(*file)
+	if fs.NArg() == 1 {
+		r, err := db.Report(context.Background(), *adsh, fs.Arg(0))
+		if err != nil {
+			log.Fatal(err)
+		}
+		printReport(r)
+		return
+	}
+	tree, err := db.Reports(context.Background(), *adsh)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s %s (%s, period %s)\n", tree.Sub.Name, tree.Sub.Form, tree.Sub.Adsh, tree.Sub.Period)
+	var print func(node *query.ReportNode, depth int)
+	print = func(node *query.ReportNode, depth int) {
+		parent := ""
+		if node.Parent != "" {
+			parent = "  (" + node.Parent + ")"
+		}
+		fmt.Printf("%sR%-4s %s%s\n", strings.Repeat("  ", depth), node.Report, node.Shortname, parent)
+		for _, child := range node.Children {
+			print(child, depth+1)
+		}
+	}
+	for _, c := range tree.Categories {
+		fmt.Printf("\n%s\n", c.Name)
+		for _, node := range c.Reports {
+			print(node, 1)
+		}
+	}
+}
+
+func printReport(r *query.Report) {
+	fmt.Printf("%s %s (%s, period %s)\n", r.Sub.Name, r.Sub.Form, r.Sub.Adsh, r.Sub.Period)
+	fmt.Printf("R%s %s\n%s\n\n", r.Ren.Report, r.Ren.Shortname, r.Ren.Longname)
+
+	// notes have their text blocks only
+	if len(r.Columns) > 0 {
+		printReportValues(r)
+	}
+	for _, line := range r.Lines {
+		for _, t := range line.Texts {
+			fmt.Printf("\n%s\n\n%s\n", line.Plabel, *t.Value)
+		}
+	}
+}
+
+func printReportValues(r *query.Report) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	periods, segments, dimensional := []string{""}, []string{""}, false
+	for _, c := range r.Columns {
+		periods = append(periods, c.Period())
+		segments = append(segments, strings.TrimSuffix(c.Segments, ";"))
+		dimensional = dimensional || c.Segments != ""
+	}
+	fmt.Fprintln(w, strings.Join(periods, "\t"))
+	if dimensional {
+		fmt.Fprintln(w, strings.Join(segments, "\t"))
+	}
+	for _, line := range r.Lines {
+		cells := []string{line.Plabel}
+		for _, v := range line.Values {
+			switch {
+			case v == nil || v.Value == nil:
+				cells = append(cells, "")
+			case line.Negating:
+				cells = append(cells, v.Value.Neg().String())
+			default:
+				cells = append(cells, v.Value.String())
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	w.Flush()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"eswiac.me/filingsdb/screen"
+)
+
+func screenCmd(args []string) {
+	fs := flag.NewFlagSet("screen", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to read from")
+	asOf := fs.String("as-of", "", "only use filings accepted by this date (5:30pm EST cutoff) or timestamp")
+	columns := fs.String("columns", "", "comma separated list of columns to output, defaults to the company and the metrics of the expression")
+	format := fs.String("format", "table", "output format: table or csv")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		log.Fatal("missing screen expression, e.g. \"sic in (2834,2836) and Revenue_ttm > 1e8 and form = '10-K'\"")
+	}
+
+	db := openExistingDB(*file)
+	result, err := db.Screen(context.Background(), screen.Query{
+		Filter:     parseFilter(*asOf),
+		Expression: strings.Join(fs.Args(), " "),
+		Columns:    splitList(*columns),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	records := [][]string{result.Columns}
+	for _, row := range result.Rows {
+		record := []string{}
+		for _, v := range row.Values {
+			record = append(record, formatValue(v))
+		}
+		records = append(records, record)
+	}
+	switch *format {
+	case "csv":
+		w := csv.NewWriter(os.Stdout)
+		w.WriteAll(records)
+		if err := w.Error(); err != nil {
+			log.Fatal(err)
+		}
+	case "table":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, record := range records {
+			fmt.Fprintln(w, strings.Join(record, "\t"))
+		}
+		w.Flush()
+	default:
+		log.Fatalf("unknown format `%s`, expected table or csv", *format)
+	}
+}
+
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
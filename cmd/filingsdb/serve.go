@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"net/http"
+
+	"eswiac.me/filingsdb/api"
+	"eswiac.me/filingsdb/graph"
+	"eswiac.me/filingsdb/rpc"
+	"google.golang.org/grpc"
+)
+
+func serveCmd(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to serve")
+	addr := fs.String("addr", ":8080", "address to serve the REST and GraphQL APIs on")
+	grpcAddr := fs.String("grpc-addr", "", "address to serve the gRPC API on, disabled if empty")
+	fs.Parse(args)
+
+	db := openExistingDB(*file).Gorm()
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g := grpc.NewServer()
+		rpc.NewServer(db).Register(g)
+		log.Printf("serving %s over gRPC on %s", *file, *grpcAddr)
+		go func() {
+			log.Fatal(g.Serve(lis))
+		}()
+	}
+
+	gql, err := graph.NewHandler(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", api.NewServer(db))
+	mux.Handle("/graphql", gql)
+	log.Printf("serving %s on %s", *file, *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
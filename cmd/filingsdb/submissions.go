@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"eswiac.me/filingsdb"
+)
+
+// submissionsCmd loads submissions.zip, or a CIK##########.json of it, into
+// a filings database, created if needed
+func submissionsCmd(args []string) {
+	fs := flag.NewFlagSet("submissions", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to load the companies into, created if needed")
+	ciks := fs.String("cik", "", "comma separated list of company CIKs to load, all of them if empty")
+	fs.Parse(args)
+	if *file == "" {
+		log.Fatal("missing --db, the filings database to load the companies into")
+	}
+	if fs.NArg() != 1 {
+		log.Fatal("missing the submissions.zip or CIK##########.json file to load")
+	}
+	db, err := filingsdb.Open(*file,
+		filingsdb.WithLogger(log.New(os.Stdout, "", 0)),
+		filingsdb.WithFilter(filingsdb.IngestFilter{Ciks: splitList(*ciks)}))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := interruptible()
+	defer cancel()
+	if err := db.IngestSubmissions(ctx, fs.Arg(0)); err != nil {
+		log.Fatal(err)
+	}
+}
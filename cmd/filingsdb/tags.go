@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"eswiac.me/filingsdb/query"
+)
+
+// tagsCmd searches the tags of a filings database, with their usage
+func tagsCmd(args []string) {
+	fs := flag.NewFlagSet("tags", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to read from")
+	custom := fs.String("custom", "", "true for custom tags only, false for standard tags only, both if empty")
+	version := fs.String("version", "", "taxonomy version (e.g. us-gaap/2019), taxonomy (e.g. us-gaap) or adsh of custom tags, any if empty")
+	sort := fs.String("sort", "usage", "order of the tags: usage (the most used first) or tag")
+	limit := fs.Int("limit", 50, "maximum number of tags to list, all of them if 0")
+	doc := fs.Bool("doc", false, "print the documentation of the tags")
+	fs.Parse(args)
+
+	q := query.TagQuery{
+		Text:    strings.Join(fs.Args(), " "),
+		Version: *version,
+		Sort:    *sort,
+		Limit:   *limit,
+	}
+	switch *custom {
+	case "":
+	case "true", "false":
+		isCustom := *custom == "true"
+		q.Custom = &isCustom
+	default:
+		log.Fatalf("invalid --custom `%s`, expected true or false", *custom)
+	}
+
+	db := openExistingDB(*file)
+	result, err := db.Tags(context.Background(), q)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "tag\tversion\tcustom\tdatatype\tiord\tcrdr\tfilings\tcompanies\tlabel")
+	for _, t := range result.Tags {
+		fmt.Fprintf(w, "%s\t%s\t%v\t%s\t%s\t%s\t%d\t%d\t%s\n", t.Tag, t.Version, t.Custom,
+			str(t.Datatype), str(t.Iord), str(t.Crdr), t.Filings, t.Companies, str(t.Tlabel))
+		if *doc && t.Doc != nil {
+			fmt.Fprintf(w, "\t%s\n", *t.Doc)
+		}
+	}
+	w.Flush()
+	if len(result.Tags) < result.Total {
+		fmt.Printf("%d of %d tags\n", len(result.Tags), result.Total)
+	}
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"eswiac.me/filingsdb/tui"
+)
+
+// tuiCmd browses the filings database in the terminal
+func tuiCmd(args []string) {
+	fs := flag.NewFlagSet("tui", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to read from")
+	fs.Parse(args)
+
+	db := openExistingDB(*file)
+	if err := tui.Run(db.Gorm(), os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
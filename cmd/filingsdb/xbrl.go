@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"eswiac.me/filingsdb"
+	"eswiac.me/filingsdb/query"
+)
+
+// xbrlCmd loads a filing from its XBRL or inline XBRL instance into a
+// filings database, created if needed
+func xbrlCmd(args []string) {
+	fs := flag.NewFlagSet("xbrl", flag.ExitOnError)
+	file := fs.String("db", "", "filings database to load the filing into, created if needed")
+	adsh := fs.String("adsh", "", "accession number of the filing, e.g. 0001326801-19-000009")
+	accepted := fs.String("accepted", "", "acceptance time of the filing, YYYY-MM-DD HH:MM:SS in Eastern time like EDGAR, now if empty")
+	txt := fs.String("txt", "keep", "text facts policy: keep, skip, no-blocks (skip text blocks) or compress")
+	fs.Parse(args)
+	if *file == "" {
+		log.Fatal("missing --db, the filings database to load the filing into")
+	}
+	if *adsh == "" {
+		log.Fatal("missing --adsh, the accession number of the filing")
+	}
+	if fs.NArg() != 1 {
+		log.Fatal("missing the instance (.xml) or inline XBRL document (.htm) to load")
+	}
+	policy, err := filingsdb.ParseTextPolicy(*txt)
+	if err != nil {
+		log.Fatal(err)
+	}
+	acceptedAt := time.Now().In(query.Eastern())
+	if *accepted != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", *accepted, query.Eastern())
+		if err != nil {
+			log.Fatal(err)
+		}
+		acceptedAt = t
+	}
+	db, err := filingsdb.Open(*file,
+		filingsdb.WithLogger(log.New(os.Stdout, "", 0)),
+		filingsdb.WithTextPolicy(policy))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := interruptible()
+	defer cancel()
+	if err := db.IngestXBRL(ctx, fs.Arg(0), *adsh, acceptedAt); err != nil {
+		log.Fatal(err)
+	}
+}
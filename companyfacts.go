@@ -0,0 +1,287 @@
+package filingsdb
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"gorm.io/gorm"
+)
+
+// IngestCompanyFacts loads the facts of companyfacts.zip, the bulk download
+// of the XBRL financial data of every company, or of a single
+// CIK##########.json of it. It's a full history of the facts of the face
+// financials of the companies, without dimensions.
+//
+// Facts go to data_nums and frames to data_frames, the filings reporting
+// them to data_subs (with the dataset "companyfacts") unless already loaded
+// from the data sets, whose facts are kept: load the archives first. Loading
+// the facts of a company again replaces those loaded before. The filter of
+// the DB applies, except for SIC codes which companyfacts.zip doesn't have.
+func (db *DB) IngestCompanyFacts(ctx context.Context, file string) error {
+	if strings.HasSuffix(file, ".json") {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return db.ingestCompanyFacts(ctx, path.Base(file), f)
+	}
+
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return fmt.Errorf("%v: %w", file, err)
+	}
+	defer zr.Close()
+	db.logger.Printf("Contents of %s:", path.Base(file))
+	for _, f := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !strings.HasSuffix(f.Name, ".json") {
+			continue
+		}
+		// skip the companies filtered out without reading them, CIK0000320193.json
+		cik := strings.TrimLeft(strings.TrimPrefix(strings.TrimSuffix(f.Name, ".json"), "CIK"), "0")
+		if db.filter != nil && !in(db.filter.ciks, cik) {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = db.ingestCompanyFacts(ctx, f.Name, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ingestCompanyFacts loads a CIK##########.json read from r
+func (db *DB) ingestCompanyFacts(ctx context.Context, name string, r io.Reader) error {
+	company := models.CompanyFacts{}
+	if err := json.NewDecoder(r).Decode(&company); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	err := db.gorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return db.loadCompanyFacts(tx, company)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// loadCompanyFacts replaces the facts of company loaded before
+func (db *DB) loadCompanyFacts(tx *gorm.DB, company models.CompanyFacts) error {
+	cik := fmt.Sprint(company.Cik)
+
+	// the submissions of the data sets come first
+	subs := []models.DataSUB{}
+	if err := tx.Where("cik = ?", cik).Find(&subs).Error; err != nil {
+		return err
+	}
+	loaded, replaced := map[string]bool{}, []string{}
+	for _, sub := range subs {
+		if sub.Dataset == models.DatasetCompanyFacts {
+			replaced = append(replaced, sub.Adsh)
+		} else {
+			loaded[sub.Adsh] = true
+		}
+	}
+	for i := 0; i < len(replaced); i += BATCH_SIZE {
+		adshs := replaced[i:]
+		if len(adshs) > BATCH_SIZE {
+			adshs = adshs[:BATCH_SIZE]
+		}
+		if err := tx.Where("adsh IN ?", adshs).Delete(&models.DataNUM{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("adsh IN ?", adshs).Delete(&models.DataSUB{}).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("cik = ?", cik).Delete(&models.DataFRAME{}).Error; err != nil {
+		return err
+	}
+
+	// the filings of the facts, with the latest date they report as
+	// balance sheet date
+	facts := companyFacts(company)
+	newSubs := map[string]*models.DataSUB{}
+	adshs := []string{}
+	for _, f := range facts {
+		if loaded[f.fact.Accn] {
+			continue
+		}
+		sub, ok := newSubs[f.fact.Accn]
+		if !ok {
+			s := f.fact.Sub(company)
+			sub = &s
+			newSubs[f.fact.Accn] = sub
+			adshs = append(adshs, f.fact.Accn)
+		}
+		if f.num.Ddate > sub.Period {
+			sub.Period = f.num.Ddate
+		}
+	}
+	if db.filter != nil {
+		db.filter.reset()
+	}
+	rows := &batch{tx: tx}
+	kept := map[string]bool{}
+	for adsh := range loaded {
+		kept[adsh] = true
+	}
+	for _, adsh := range adshs {
+		if db.filter != nil && !db.filter.keep(*newSubs[adsh]) {
+			continue
+		}
+		kept[adsh] = true
+		if err := rows.add(*newSubs[adsh]); err != nil {
+			return err
+		}
+	}
+	if err := rows.flush(); err != nil {
+		return err
+	}
+
+	existingTags, err := tagVersions(tx, company)
+	if err != nil {
+		return err
+	}
+	nums, frames, tags := &batch{tx: tx}, &batch{tx: tx}, &batch{tx: tx}
+	for _, f := range facts {
+		if !kept[f.num.Adsh] || (db.filter != nil && !db.filter.tag(f.num.Tag)) {
+			continue
+		}
+		// frames refer to the facts of the data sets too
+		if f.fact.Frame != "" {
+			frame := models.DataFRAME{
+				Frame:   f.fact.Frame,
+				Tag:     f.num.Tag,
+				Version: f.num.Version,
+				Uom:     f.num.Uom,
+				Cik:     cik,
+				Adsh:    f.num.Adsh,
+				Ddate:   f.num.Ddate,
+				Qtrs:    f.num.Qtrs,
+				Value:   f.num.Value,
+			}
+			if err := frames.add(frame); err != nil {
+				return err
+			}
+		}
+		if loaded[f.num.Adsh] || (db.filter != nil && !db.filter.keep(f.num)) {
+			continue
+		}
+		if err := nums.add(f.num); err != nil {
+			return err
+		}
+		key := f.num.Version + "/" + f.num.Tag
+		if !existingTags[key] {
+			existingTags[key] = true
+			if err := tags.add(conceptTag(f.num.Version, f.num.Tag, company)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, b := range []*batch{nums, frames, tags} {
+		if err := b.flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// companyFact is a fact of companyfacts.zip along with its num.tsv version
+type companyFact struct {
+	fact models.CompanyFact
+	num  models.DataNUM
+}
+
+// companyFacts returns the facts of company in a stable order, the same fact
+// reported several times by a filing (in different contexts) getting
+// increasing priorities (iprx)
+func companyFacts(company models.CompanyFacts) []companyFact {
+	facts := []companyFact{}
+	seen := map[string]int{}
+	for _, taxonomy := range taxonomies(company) {
+		concepts := company.Facts[taxonomy]
+		tags := []string{}
+		for tag := range concepts {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		for _, tag := range tags {
+			units := []string{}
+			for uom := range concepts[tag].Units {
+				units = append(units, uom)
+			}
+			sort.Strings(units)
+			for _, uom := range units {
+				for _, f := range concepts[tag].Units[uom] {
+					num := f.Num(taxonomy, tag, uom)
+					key := strings.Join([]string{num.Adsh, num.Tag, num.Uom, num.Ddate, fmt.Sprint(num.Qtrs)}, "\t")
+					seen[key]++
+					num.Iprx = seen[key]
+					facts = append(facts, companyFact{fact: f, num: num})
+				}
+			}
+		}
+	}
+	return facts
+}
+
+// taxonomies returns the taxonomies of the facts of company, sorted
+func taxonomies(company models.CompanyFacts) []string {
+	keys := []string{}
+	for k := range company.Facts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// tagVersions returns the taxonomy/tag of data_tags among those of company
+func tagVersions(tx *gorm.DB, company models.CompanyFacts) (map[string]bool, error) {
+	existing := []models.DataTAG{}
+	if err := tx.Select("tag, version").Where("version IN ?", taxonomies(company)).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	versions := map[string]bool{}
+	for _, t := range existing {
+		versions[t.Version+"/"+t.Tag] = true
+	}
+	return versions, nil
+}
+
+// conceptTag returns the definition of a tag of company, versioned by its
+// taxonomy
+func conceptTag(taxonomy string, tag string, company models.CompanyFacts) models.DataTAG {
+	concept := company.Facts[taxonomy][tag]
+	iord := "I"
+	for _, facts := range concept.Units {
+		for _, f := range facts {
+			if f.Start != "" {
+				iord = "D"
+			}
+		}
+	}
+	return models.DataTAG{
+		Tag:     tag,
+		Version: taxonomy,
+		Iord:    &iord,
+		Tlabel:  concept.Label,
+		Doc:     concept.Description,
+	}
+}
@@ -0,0 +1,197 @@
+package customtags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"gorm.io/gorm"
+)
+
+// Query selects the submissions whose custom tags are analyzed
+type Query struct {
+	// Companies, all of them if empty
+	Ciks []string
+
+	// Forms of the submissions, all of them if empty
+	Forms []string
+}
+
+// where returns the conditions of q on data_subs and their arguments
+func (q Query) where() (string, []interface{}) {
+	conditions, args := []string{"1 = 1"}, []interface{}{}
+	if len(q.Ciks) > 0 {
+		conditions = append(conditions, "data_subs.cik IN ?")
+		args = append(args, q.Ciks)
+	}
+	if len(q.Forms) > 0 {
+		conditions = append(conditions, "data_subs.form IN ?")
+		args = append(args, q.Forms)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// Usage is the use a company makes of custom tags in its submissions
+type Usage struct {
+	Cik  string
+	Name string
+
+	// Count of the submissions with facts
+	Filings int
+
+	// Count of the numeric and text facts, and of those of custom tags
+	Facts       int
+	CustomFacts int
+
+	// Count of the distinct names of the custom tags of the facts
+	CustomTags int
+}
+
+// Share returns the share of the facts of custom tags, from 0 to 1
+func (u Usage) Share() float64 {
+	if u.Facts == 0 {
+		return 0
+	}
+	return float64(u.CustomFacts) / float64(u.Facts)
+}
+
+// CompanyUsage returns the use of custom tags by each company of the
+// submissions of q, the companies with the largest share of custom facts
+// first
+func CompanyUsage(db *gorm.DB, q Query) ([]Usage, error) {
+	where, args := q.where()
+	usage := []Usage{}
+	err := db.Raw(`SELECT data_subs.cik, MAX(data_subs.name) AS name, COUNT(DISTINCT data_subs.adsh) AS filings, COUNT(*) AS facts,
+			SUM(CASE WHEN facts.version = facts.adsh THEN 1 ELSE 0 END) AS custom_facts,
+			COUNT(DISTINCT CASE WHEN facts.version = facts.adsh THEN facts.tag END) AS custom_tags
+		FROM (SELECT adsh, tag, version FROM data_nums UNION ALL SELECT adsh, tag, version FROM data_txts) AS facts
+		JOIN data_subs ON data_subs.adsh = facts.adsh
+		WHERE `+where+`
+		GROUP BY data_subs.cik`, args...).Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(usage, func(i, j int) bool {
+		if usage[i].Share() != usage[j].Share() {
+			return usage[i].Share() > usage[j].Share()
+		}
+		return usage[i].Cik < usage[j].Cik
+	})
+	return usage, nil
+}
+
+// Materialize suggests a standard tag for every custom tag of the
+// submissions of q and stores them in the tag_mappings table. The mappings
+// reviewed (accepted or rejected) are kept, the others replaced.
+func Materialize(db *gorm.DB, q Query, batchSize int) error {
+	if err := db.AutoMigrate(&models.TagMapping{}); err != nil {
+		return err
+	}
+	mappings, err := Suggest(db, q)
+	if err != nil {
+		return err
+	}
+	adshs := []string{}
+	for _, m := range mappings {
+		if len(adshs) == 0 || adshs[len(adshs)-1] != m.Adsh {
+			adshs = append(adshs, m.Adsh)
+		}
+	}
+	// the suggestions replaced are never left deleted or half written
+	return db.Transaction(func(tx *gorm.DB) error {
+		reviewed := map[string]bool{}
+		err := inChunks(adshs, batchSize, func(chunk []string) error {
+			found := []models.TagMapping{}
+			if err := tx.Where("adsh IN ? AND status <> ?", chunk, models.MappingSuggested).Find(&found).Error; err != nil {
+				return err
+			}
+			for _, m := range found {
+				reviewed[m.Adsh+"\t"+m.Tag] = true
+			}
+			return tx.Where("adsh IN ? AND status = ?", chunk, models.MappingSuggested).Delete(&models.TagMapping{}).Error
+		})
+		if err != nil {
+			return err
+		}
+		rows := []models.TagMapping{}
+		for _, m := range mappings {
+			if reviewed[m.Adsh+"\t"+m.Tag] {
+				continue
+			}
+			rows = append(rows, m)
+			if len(rows) >= batchSize {
+				if err := tx.Create(&rows).Error; err != nil {
+					return err
+				}
+				rows = []models.TagMapping{}
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+// Mappings returns the stored mappings of the custom tags of the
+// submissions of q with the given status, any if empty, by submission and tag
+func Mappings(db *gorm.DB, q Query, status string) ([]models.TagMapping, error) {
+	if !db.Migrator().HasTable(&models.TagMapping{}) {
+		return nil, fmt.Errorf("no tag mappings, suggest them first")
+	}
+	where, args := q.where()
+	tx := db.Where("adsh IN (SELECT adsh FROM data_subs WHERE "+where+")", args...)
+	if status != "" {
+		tx = tx.Where("status = ?", status)
+	}
+	mappings := []models.TagMapping{}
+	return mappings, tx.Order("cik, adsh, tag").Find(&mappings).Error
+}
+
+// Review sets the status of the mapping of the custom tag of the submission
+// adsh. mapped, if not empty, replaces the standard tag suggested.
+func Review(db *gorm.DB, adsh string, tag string, status string, mapped string) error {
+	switch status {
+	case models.MappingSuggested, models.MappingAccepted, models.MappingRejected:
+	default:
+		return fmt.Errorf("unknown status `%s`, expected %s, %s or %s", status, models.MappingSuggested, models.MappingAccepted, models.MappingRejected)
+	}
+	if !db.Migrator().HasTable(&models.TagMapping{}) {
+		return fmt.Errorf("no tag mappings, suggest them first")
+	}
+	updates := map[string]interface{}{"status": status}
+	if mapped != "" {
+		versions := []string{}
+		err := db.Model(&models.DataTAG{}).Where("tag = ? AND custom = ?", mapped, false).Order("version DESC").Limit(1).Pluck("version", &versions).Error
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return fmt.Errorf("unknown standard tag %s", mapped)
+		}
+		updates["mapped"], updates["mapped_version"] = mapped, versions[0]
+	}
+	tx := db.Model(&models.TagMapping{}).Where("adsh = ? AND tag = ?", adsh, tag).Updates(updates)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("no mapping of %s in %s", tag, adsh)
+	}
+	return nil
+}
+
+// inChunks calls fn with the values, size at a time
+func inChunks(values []string, size int, fn func([]string) error) error {
+	for i := 0; i < len(values); i += size {
+		end := i + size
+		if end > len(values) {
+			end = len(values)
+		}
+		if err := fn(values[i:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
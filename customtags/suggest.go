@@ -0,0 +1,466 @@
+package customtags
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"eswiac.me/filingsdb/models"
+	"gorm.io/gorm"
+)
+
+// Weights of the calculation, presentation and label scores of a suggestion
+const (
+	calcWeight  = 0.4
+	presWeight  = 0.25
+	labelWeight = 0.35
+)
+
+// minScore is the score below which no standard tag is suggested
+const minScore = 0.25
+
+// labelCandidates is the count of standard tags of the most similar labels
+// scored for a custom tag, besides those its calculations and presentation
+// point to
+const labelCandidates = 10
+
+// chunkSize is the number of values of the IN clauses of the lookups
+const chunkSize = 500
+
+// customTag is a custom tag of a submission, with the standard tags around
+// it in the submission
+type customTag struct {
+	models.DataTAG
+	cik   string
+	stmt  string
+	words []string
+
+	// Standard tags of the calculation arcs to and from the custom tag
+	parents  []string
+	children []string
+
+	// Standard tags presented on the lines next to the custom tag
+	neighbours []string
+}
+
+// Suggest returns the standard tag closest to each custom tag of the
+// submissions of q, by submission and tag. The standard tags considered are
+// those of the database that other submissions calculate along with the
+// standard parents and children of the custom tag, or present next to its
+// neighbours, and those of the most similar labels. The standard tags of the
+// submission itself are left out, a custom tag standing for something else,
+// and so are those of another data type or period type. Each is scored on,
+// and the scores weighted:
+//   - its calculations: the share of the parents and children of the custom
+//     tag that are also parents and children of the standard tag elsewhere,
+//   - its presentation: how often it is presented on the statement of the
+//     custom tag, and next to the lines the custom tag is next to,
+//   - its label: the words it shares with the label of the custom tag.
+func Suggest(db *gorm.DB, q Query) ([]models.TagMapping, error) {
+	where, args := q.where()
+	subs := []models.DataSUB{}
+	if err := db.Model(&models.DataSUB{}).Select("adsh, cik").Where(where, args...).Order("adsh").Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	ciks, adshs := map[string]string{}, []string{}
+	for _, sub := range subs {
+		ciks[sub.Adsh] = sub.Cik
+		adshs = append(adshs, sub.Adsh)
+	}
+
+	standard := []models.DataTAG{}
+	err := db.Model(&models.DataTAG{}).Select("tag, version, custom, abstract, datatype, iord, crdr, tlabel").
+		Where("custom = ? AND abstract = ?", false, false).Order("tag, version DESC").Find(&standard).Error
+	if err != nil {
+		return nil, err
+	}
+	labels := newLabelIndex(standard)
+
+	customs := []*customTag{}
+	used := map[string]map[string]bool{}
+	err = inChunks(adshs, chunkSize, func(chunk []string) error {
+		found, err := loadCustomTags(db, chunk, used)
+		for _, c := range found {
+			c.cik = ciks[c.Version]
+		}
+		customs = append(customs, found...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the standard tags calculated and presented along with those around
+	// the custom tags, in all the submissions
+	parents, children, neighbours := []string{}, []string{}, []string{}
+	for _, c := range customs {
+		parents = append(parents, c.parents...)
+		children = append(children, c.children...)
+		neighbours = append(neighbours, c.neighbours...)
+	}
+	childrenOf, err := pairs(db, `SELECT ptag AS a, ctag AS b, COUNT(DISTINCT adsh) AS count FROM data_cals
+		WHERE ptag IN ? AND cversion <> adsh GROUP BY ptag, ctag`, parents)
+	if err != nil {
+		return nil, err
+	}
+	parentsOf, err := pairs(db, `SELECT ctag AS a, ptag AS b, COUNT(DISTINCT adsh) AS count FROM data_cals
+		WHERE ctag IN ? AND pversion <> adsh GROUP BY ctag, ptag`, children)
+	if err != nil {
+		return nil, err
+	}
+	nextTo, err := pairs(db, `SELECT x.tag AS a, y.tag AS b, COUNT(DISTINCT y.adsh) AS count FROM data_pres AS x
+		JOIN data_pres AS y ON y.adsh = x.adsh AND y.report = x.report AND (y.line = x.line - 1 OR y.line = x.line + 1)
+		WHERE x.tag IN ? AND y.version <> y.adsh GROUP BY x.tag, y.tag`, neighbours)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]map[string]bool, len(customs))
+	names := []string{}
+	for i, c := range customs {
+		candidates[i] = map[string]bool{}
+		add := func(related map[string]map[string]int, tags []string) {
+			for _, t := range tags {
+				for name := range related[t] {
+					candidates[i][name] = true
+				}
+			}
+		}
+		add(childrenOf, c.parents)
+		add(parentsOf, c.children)
+		add(nextTo, c.neighbours)
+		for _, name := range labels.similar(c, labelCandidates, used[c.Version]) {
+			candidates[i][name] = true
+		}
+		for name := range candidates[i] {
+			names = append(names, name)
+		}
+	}
+	stmts, err := pairs(db, `SELECT tag AS a, stmt AS b, COUNT(*) AS count FROM data_pres
+		WHERE tag IN ? AND version <> adsh GROUP BY tag, stmt`, names)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := []models.TagMapping{}
+	for i, c := range customs {
+		m := models.TagMapping{Adsh: c.Version, Cik: c.cik, Tag: c.Tag, Tlabel: c.Tlabel, Stmt: c.stmt, Status: models.MappingSuggested}
+		for name := range candidates[i] {
+			s, ok := labels.tags[name]
+			if !ok || used[c.Version][name] || !compatible(c.DataTAG, s.DataTAG) {
+				continue
+			}
+			calc, pres, label := -1.0, -1.0, dice(c.words, s.words)
+			if len(c.parents)+len(c.children) > 0 {
+				calc = (share(childrenOf, c.parents, name)*float64(len(c.parents)) +
+					share(parentsOf, c.children, name)*float64(len(c.children))) / float64(len(c.parents)+len(c.children))
+			}
+			if c.stmt != "" {
+				pres = statementShare(stmts[name], c.stmt)
+				if len(c.neighbours) > 0 {
+					pres = (pres + share(nextTo, c.neighbours, name)) / 2
+				}
+			}
+			score := weighted(calc, pres, label)
+			if score < minScore || score < m.Score || score == m.Score && m.Mapped != nil && *m.Mapped < name {
+				continue
+			}
+			mapped, version := name, s.Version
+			m.Mapped, m.MappedVersion = &mapped, &version
+			m.Score, m.CalcScore, m.PresScore, m.LabelScore = round(score), round(math.Max(calc, 0)), round(math.Max(pres, 0)), round(label)
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings, nil
+}
+
+// loadCustomTags returns the custom tags of the submissions adshs, by
+// submission and tag, with the standard tags around them. It adds the
+// standard tags of the calculations and presentation of each submission to
+// used.
+func loadCustomTags(db *gorm.DB, adshs []string, used map[string]map[string]bool) ([]*customTag, error) {
+	tags := []models.DataTAG{}
+	if err := db.Where("version IN ? AND custom = ? AND abstract = ?", adshs, true, false).Order("version, tag").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	customs, byTag := []*customTag{}, map[string]*customTag{}
+	for _, t := range tags {
+		c := &customTag{DataTAG: t}
+		customs = append(customs, c)
+		byTag[t.Version+"\t"+t.Tag] = c
+	}
+	for _, adsh := range adshs {
+		used[adsh] = map[string]bool{}
+	}
+
+	cals := []models.DataCAL{}
+	if err := db.Where("adsh IN ?", adshs).Find(&cals).Error; err != nil {
+		return nil, err
+	}
+	for _, cal := range cals {
+		if cal.Pversion != cal.Adsh {
+			used[cal.Adsh][cal.Ptag] = true
+		}
+		if cal.Cversion != cal.Adsh {
+			used[cal.Adsh][cal.Ctag] = true
+		}
+		if c, ok := byTag[cal.Adsh+"\t"+cal.Ctag]; ok && cal.Cversion == cal.Adsh && cal.Pversion != cal.Adsh {
+			c.parents = append(c.parents, cal.Ptag)
+		}
+		if c, ok := byTag[cal.Adsh+"\t"+cal.Ptag]; ok && cal.Pversion == cal.Adsh && cal.Cversion != cal.Adsh {
+			c.children = append(c.children, cal.Ctag)
+		}
+	}
+
+	pres := []models.DataPRE{}
+	if err := db.Where("adsh IN ?", adshs).Order("adsh, report, line").Find(&pres).Error; err != nil {
+		return nil, err
+	}
+	for i, p := range pres {
+		if p.Version != p.Adsh {
+			used[p.Adsh][p.Tag] = true
+			continue
+		}
+		c, ok := byTag[p.Adsh+"\t"+p.Tag]
+		if !ok {
+			continue
+		}
+		if c.stmt == "" {
+			c.stmt = p.Stmt
+			if c.Tlabel == nil && p.Plabel != "" {
+				c.words = words(p.Plabel)
+			}
+		}
+		for _, j := range []int{i - 1, i + 1} {
+			if j < 0 || j >= len(pres) {
+				continue
+			}
+			n := pres[j]
+			if n.Adsh == p.Adsh && n.Report == p.Report && n.Version != n.Adsh {
+				c.neighbours = append(c.neighbours, n.Tag)
+			}
+		}
+	}
+	for _, c := range customs {
+		switch {
+		case c.Tlabel != nil:
+			c.words = words(*c.Tlabel)
+		case c.words == nil:
+			c.words = words(splitCamelCase(c.Tag))
+		}
+	}
+	return customs, nil
+}
+
+// pairs returns the counts of the rows of query, selecting a, b and count
+// where a is one of values, by a and b
+func pairs(db *gorm.DB, query string, values []string) (map[string]map[string]int, error) {
+	result := map[string]map[string]int{}
+	distinct, seen := []string{}, map[string]bool{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			distinct = append(distinct, v)
+		}
+	}
+	err := inChunks(distinct, chunkSize, func(chunk []string) error {
+		rows := []struct {
+			A     string
+			B     string
+			Count int
+		}{}
+		err := db.Raw(query, chunk).Scan(&rows).Error
+		for _, r := range rows {
+			if result[r.A] == nil {
+				result[r.A] = map[string]int{}
+			}
+			result[r.A][r.B] += r.Count
+		}
+		return err
+	})
+	return result, err
+}
+
+// share returns the share of tags related to name in related
+func share(related map[string]map[string]int, tags []string, name string) float64 {
+	if len(tags) == 0 {
+		return 0
+	}
+	count := 0
+	for _, t := range tags {
+		if related[t][name] > 0 {
+			count++
+		}
+	}
+	return float64(count) / float64(len(tags))
+}
+
+// statementShare returns the share of the presentations counted by stmts
+// that are on the statement stmt
+func statementShare(stmts map[string]int, stmt string) float64 {
+	total := 0
+	for _, count := range stmts {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(stmts[stmt]) / float64(total)
+}
+
+// weighted returns the weighted average of the scores, leaving out the
+// negative ones, the custom tag having no calculation or presentation
+func weighted(calc, pres, label float64) float64 {
+	sum, weights := label*labelWeight, labelWeight
+	if calc >= 0 {
+		sum, weights = sum+calc*calcWeight, weights+calcWeight
+	}
+	if pres >= 0 {
+		sum, weights = sum+pres*presWeight, weights+presWeight
+	}
+	return sum / weights
+}
+
+func round(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
+
+// compatible tells whether the facts of the custom tag c could be facts of
+// the standard tag s: of the same data type and period type
+func compatible(c models.DataTAG, s models.DataTAG) bool {
+	same := func(a, b *string) bool {
+		return a == nil || b == nil || *a == *b
+	}
+	return same(c.Datatype, s.Datatype) && same(c.Iord, s.Iord)
+}
+
+// standardTag is a standard tag and the words of its label
+type standardTag struct {
+	models.DataTAG
+	words []string
+}
+
+// labelIndex finds the standard tags whose labels share words with others
+type labelIndex struct {
+	// Standard tags by name, of their latest version
+	tags map[string]*standardTag
+
+	// Standard tags by word of their labels
+	postings map[string][]*standardTag
+}
+
+// newLabelIndex indexes tags, sorted by name then version, latest first
+func newLabelIndex(tags []models.DataTAG) *labelIndex {
+	index := &labelIndex{tags: map[string]*standardTag{}, postings: map[string][]*standardTag{}}
+	for _, t := range tags {
+		if _, ok := index.tags[t.Tag]; ok {
+			continue
+		}
+		s := &standardTag{DataTAG: t}
+		if t.Tlabel != nil {
+			s.words = words(*t.Tlabel)
+		} else {
+			s.words = words(splitCamelCase(t.Tag))
+		}
+		index.tags[t.Tag] = s
+		for _, w := range s.words {
+			index.postings[w] = append(index.postings[w], s)
+		}
+	}
+	return index
+}
+
+// similar returns the names of the n standard tags compatible with c whose
+// labels are the most similar to its own, but those of excluded
+func (index *labelIndex) similar(c *customTag, n int, excluded map[string]bool) []string {
+	shared := map[*standardTag]int{}
+	for _, w := range c.words {
+		for _, s := range index.postings[w] {
+			shared[s]++
+		}
+	}
+	type match struct {
+		name  string
+		score float64
+	}
+	matches := []match{}
+	for s, count := range shared {
+		if excluded[s.Tag] || !compatible(c.DataTAG, s.DataTAG) {
+			continue
+		}
+		matches = append(matches, match{s.Tag, 2 * float64(count) / float64(len(c.words)+len(s.words))})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].name < matches[j].name
+	})
+	names := []string{}
+	for i := 0; i < len(matches) && i < n; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// stopWords are left out of the words of the labels
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "at": true, "by": true, "for": true, "from": true,
+	"in": true, "of": true, "on": true, "or": true, "the": true, "to": true, "with": true,
+}
+
+// words returns the distinct words of a label, lower case and singular, but
+// the stop words
+func words(label string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	fields := strings.FieldsFunc(strings.ToLower(label), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range fields {
+		if stopWords[w] {
+			continue
+		}
+		if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = w[:len(w)-1]
+		}
+		if !seen[w] {
+			seen[w] = true
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// dice returns the Dice coefficient of two sets of words
+func dice(a []string, b []string) float64 {
+	if len(a)+len(b) == 0 {
+		return 0
+	}
+	in := map[string]bool{}
+	for _, w := range a {
+		in[w] = true
+	}
+	count := 0
+	for _, w := range b {
+		if in[w] {
+			count++
+		}
+	}
+	return 2 * float64(count) / float64(len(a)+len(b))
+}
+
+// splitCamelCase returns the words of a tag name, e.g. Revenue From Sales of
+// RevenueFromSales
+func splitCamelCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
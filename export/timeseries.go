@@ -0,0 +1,146 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"eswiac.me/filingsdb/query"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// TimeSeries writes ts to w in the given format: csv, json or parquet
+func TimeSeries(w io.Writer, ts *query.TimeSeries, format string) error {
+	switch format {
+	case "csv":
+		return TimeSeriesCSV(w, ts)
+	case "json":
+		return TimeSeriesJSON(w, ts)
+	case "parquet":
+		return TimeSeriesParquet(w, ts)
+	}
+	return fmt.Errorf("unknown format `%s`, expected csv, json or parquet", format)
+}
+
+// TimeSeriesCSV writes ts as CSV, with a ddate and qtrs column followed by
+// one column per tag. Missing values are left empty.
+func TimeSeriesCSV(w io.Writer, ts *query.TimeSeries) error {
+	cw := csv.NewWriter(w)
+	header := []string{"ddate", "qtrs"}
+	for _, col := range ts.Columns {
+		header = append(header, col.Name)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, row := range ts.Rows {
+		record := []string{row.Ddate, strconv.Itoa(row.Qtrs)}
+		for _, v := range row.Values {
+			if v == nil {
+				record = append(record, "")
+			} else {
+				record = append(record, v.String())
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// TimeSeriesJSON writes ts as an array of objects, one per period, with a
+// ddate and qtrs member followed by one member per tag, in the order of the
+// columns. Missing values are null.
+func TimeSeriesJSON(w io.Writer, ts *query.TimeSeries) error {
+	rows := []timeSeriesJSONRow{}
+	for _, row := range ts.Rows {
+		rows = append(rows, timeSeriesJSONRow{columns: ts.Columns, row: row})
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(rows)
+}
+
+// timeSeriesJSONRow is a row of TimeSeriesJSON, whose members keep the
+// order of the columns that a map would lose
+type timeSeriesJSONRow struct {
+	columns []query.Column
+	row     query.TimeSeriesRow
+}
+
+func (r timeSeriesJSONRow) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	member := func(name string, value interface{}) error {
+		if buf.Len() == 0 {
+			buf.WriteByte('{')
+		} else {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(b)
+		return nil
+	}
+	if err := member("ddate", r.row.Ddate); err != nil {
+		return nil, err
+	}
+	if err := member("qtrs", r.row.Qtrs); err != nil {
+		return nil, err
+	}
+	for i, v := range r.row.Values {
+		var value interface{}
+		if v != nil {
+			// a number rather than the quoted string of decimal
+			value = json.Number(v.String())
+		}
+		if err := member(r.columns[i].Name, value); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// TimeSeriesParquet writes ts as a parquet file with a ddate (UTF8) and qtrs
+// (INT32) column followed by one optional DOUBLE column per tag
+func TimeSeriesParquet(w io.Writer, ts *query.TimeSeries) error {
+	md := []string{
+		"name=ddate, type=UTF8, encoding=PLAIN_DICTIONARY",
+		"name=qtrs, type=INT32",
+	}
+	for _, col := range ts.Columns {
+		md = append(md, fmt.Sprintf("name=%s, type=DOUBLE, repetitiontype=OPTIONAL", col.Name))
+	}
+	pw, err := writer.NewCSVWriterFromWriter(md, w, 1)
+	if err != nil {
+		return err
+	}
+	for _, row := range ts.Rows {
+		record := []interface{}{row.Ddate, int32(row.Qtrs)}
+		for _, v := range row.Values {
+			if v == nil {
+				record = append(record, nil)
+			} else {
+				f, _ := v.Float64()
+				record = append(record, f)
+			}
+		}
+		if err := pw.Write(record); err != nil {
+			return err
+		}
+	}
+	return pw.WriteStop()
+}
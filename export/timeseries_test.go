@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"eswiac.me/filingsdb/query"
+	"github.com/shopspring/decimal"
+)
+
+func TestTimeSeriesJSON(t *testing.T) {
+	revenues, payable := decimal.RequireFromString("55838000000"), decimal.RequireFromString("0.5")
+	ts := &query.TimeSeries{
+		Cik: "1326801",
+		Columns: []query.Column{
+			{Name: "Revenues", Tag: "Revenues", Uom: "USD"},
+			{Name: "AccountsPayable", Tag: "AccountsPayable", Uom: "USD"},
+		},
+		Rows: []query.TimeSeriesRow{
+			{Ddate: "20171231", Qtrs: 4, Values: []*decimal.Decimal{nil, &payable}},
+			{Ddate: "20181231", Qtrs: 4, Values: []*decimal.Decimal{&revenues, nil}},
+		},
+	}
+	buf := &bytes.Buffer{}
+	if err := TimeSeriesJSON(buf, ts); err != nil {
+		t.Fatal(err)
+	}
+	expected := `[
+  {
+    "ddate": "20171231",
+    "qtrs": 4,
+    "Revenues": null,
+    "AccountsPayable": 0.5
+  },
+  {
+    "ddate": "20181231",
+    "qtrs": 4,
+    "Revenues": 55838000000,
+    "AccountsPayable": null
+  }
+]
+`
+	if buf.String() != expected {
+		t.Errorf("wrote\n%s\nexpected\n%s", buf, expected)
+	}
+
+	buf.Reset()
+	if err := TimeSeriesJSON(buf, &query.TimeSeries{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("wrote %q for no rows", buf)
+	}
+}
@@ -0,0 +1,280 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+)
+
+// Filing writes the facts of f to w in the given format: xbrl-json
+func Filing(w io.Writer, f *query.Filing, format string) error {
+	switch format {
+	case "xbrl-json":
+		return XBRLJSON(w, f)
+	}
+	return fmt.Errorf("unknown format `%s`, expected xbrl-json", format)
+}
+
+// xbrlJSON is an xBRL-JSON report of the XBRL Open Information Model
+type xbrlJSON struct {
+	DocumentInfo struct {
+		DocumentType string            `json:"documentType"`
+		Namespaces   map[string]string `json:"namespaces"`
+		Taxonomy     []string          `json:"taxonomy"`
+	} `json:"documentInfo"`
+	Facts map[string]xbrlJSONFact `json:"facts"`
+}
+
+type xbrlJSONFact struct {
+	Value      *string           `json:"value"`
+	Decimals   *int              `json:"decimals,omitempty"`
+	Dimensions map[string]string `json:"dimensions"`
+}
+
+// taxonomyNamespaces are the namespaces and schemas of the standard
+// taxonomies, by name, %s being the year of their version
+var taxonomyNamespaces = map[string][2]string{
+	"us-gaap": {"http://fasb.org/us-gaap/%s-01-31", "https://xbrl.fasb.org/us-gaap/%[1]s/elts/us-gaap-%[1]s-01-31.xsd"},
+	"srt":     {"http://fasb.org/srt/%s-01-31", "https://xbrl.fasb.org/srt/%[1]s/elts/srt-%[1]s-01-31.xsd"},
+	"dei":     {"http://xbrl.sec.gov/dei/%s-01-31", "https://xbrl.sec.gov/dei/%[1]s/dei-%[1]s-01-31.xsd"},
+	"invest":  {"http://xbrl.sec.gov/invest/%s-01-31", "https://xbrl.sec.gov/invest/%[1]s/invest-%[1]s-01-31.xsd"},
+	"country": {"http://xbrl.sec.gov/country/%s-01-31", "https://xbrl.sec.gov/country/%[1]s/country-%[1]s-01-31.xsd"},
+	"ecd":     {"http://xbrl.sec.gov/ecd/%s", "https://xbrl.sec.gov/ecd/%[1]s/ecd-%[1]s.xsd"},
+}
+
+// unitNamespaces are the namespaces of the measures of the units
+const (
+	nsISO4217 = "http://www.xbrl.org/2003/iso4217"
+	nsXBRLI   = "http://www.xbrl.org/2003/instance"
+	nsUTR     = "http://www.xbrl.org/2009/utr"
+)
+
+// adshPattern matches the accession numbers versioning the custom tags
+var adshPattern = regexp.MustCompile(`^\d{10}-\d{2}-\d{6}$`)
+
+// ncName matches the names that may be explicit members, typed dimension
+// values being anything else
+var ncName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// XBRLJSON writes the numeric and text facts of f as an xBRL-JSON report
+// (https://www.xbrl.org/Specification/xbrl-json/REC-2021-10-13/xbrl-json-REC-2021-10-13.html).
+//
+// The data sets round the periods to month ends and quarters and shorten
+// the names of the dimensions, so are the periods of the report: a duration
+// of qtrs quarters ending at ddate. Axes and members get back their names
+// from the tags of f when found there; the namespaces of the standard
+// taxonomies are the ones of their versions (of the year of the filing for
+// the facts of companyfacts.zip, versioned by taxonomy only), the custom
+// tags of the filing being in a namespace of its EDGAR folder.
+func XBRLJSON(w io.Writer, f *query.Filing) error {
+	report := xbrlJSON{Facts: map[string]xbrlJSONFact{}}
+	report.DocumentInfo.DocumentType = "https://xbrl.org/2021/xbrl-json"
+	namespaces := map[string]string{
+		"xbrli":   nsXBRLI,
+		"iso4217": nsISO4217,
+		"cik":     "http://www.sec.gov/CIK",
+	}
+	report.DocumentInfo.Namespaces = namespaces
+	taxonomies := map[string]bool{}
+
+	folder := fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/%s/%s", f.Sub.Cik, strings.Replace(f.Sub.Adsh, "-", "", -1))
+	custom := customPrefix(f.Sub)
+
+	// qname returns the QName of a tag of version, declaring its namespace.
+	// Custom tags are versioned by an adsh, standard ones by a taxonomy
+	// and its year, or by the taxonomy only for companyfacts.zip, whose
+	// year is then taken to be that of the filing.
+	qname := func(tag string, version string) string {
+		if version == "" || adshPattern.MatchString(version) {
+			namespaces[custom] = folder
+			return custom + ":" + tag
+		}
+		name, year := version, filingYear(f.Sub)
+		if parts := strings.SplitN(version, "/", 2); len(parts) == 2 {
+			name, year = parts[0], parts[1]
+		}
+		if ns, ok := taxonomyNamespaces[name]; ok {
+			namespaces[name] = fmt.Sprintf(ns[0], year)
+			taxonomies[fmt.Sprintf(ns[1], year)] = true
+		} else {
+			namespaces[name] = fmt.Sprintf("http://xbrl.sec.gov/%s/%s", name, year)
+		}
+		return name + ":" + tag
+	}
+	// tagQName returns the QName of the first of names found in the tags
+	// of the filing, the first name in its main standard taxonomy otherwise
+	standard := mainTaxonomy(f)
+	tagQName := func(names ...string) string {
+		for _, name := range names {
+			if t, ok := f.Tags[name]; ok {
+				return qname(t.Tag, t.Version)
+			}
+		}
+		return qname(names[0], standard)
+	}
+	unit := func(uom string) string {
+		measures := func(s string) string {
+			qnames := []string{}
+			for _, m := range strings.Split(s, "*") {
+				switch {
+				case m == "shares" || m == "pure":
+					qnames = append(qnames, "xbrli:"+m)
+				case len(m) == 3 && strings.ToUpper(m) == m:
+					qnames = append(qnames, "iso4217:"+m)
+				default:
+					namespaces["utr"] = nsUTR
+					qnames = append(qnames, "utr:"+m)
+				}
+			}
+			return strings.Join(qnames, "*")
+		}
+		parts := strings.SplitN(uom, "/", 2)
+		if len(parts) == 2 {
+			return measures(parts[0]) + "/" + measures(parts[1])
+		}
+		return measures(uom)
+	}
+	entity := fmt.Sprintf("cik:%010s", f.Sub.Cik)
+	dimensions := func(concept string, ddate string, qtrs int, dimh string) (map[string]string, error) {
+		period, err := oimPeriod(ddate, qtrs)
+		if err != nil {
+			return nil, err
+		}
+		d := map[string]string{"concept": concept, "entity": entity, "period": period}
+		dim, ok := f.Dims[dimh]
+		if !ok {
+			return d, nil
+		}
+		for _, pair := range strings.Split(dim.Segments, ";") {
+			i := strings.Index(pair, "=")
+			if i < 0 {
+				continue
+			}
+			axis, member := pair[:i], pair[i+1:]
+			axisQName := tagQName(axis+"Axis", "Statement"+axis+"Axis")
+			if !ncName.MatchString(member) {
+				d[axisQName] = member // typed dimension
+				continue
+			}
+			d[axisQName] = tagQName(member+"Member", "Statement"+member+"Member", member+"Domain", member)
+		}
+		return d, nil
+	}
+
+	count := len(f.Nums) + len(f.Txts)
+	id := func(i int) string {
+		return fmt.Sprintf("f%0*d", len(fmt.Sprint(count)), i+1)
+	}
+	for i, n := range f.Nums {
+		d, err := dimensions(qname(n.Tag, n.Version), n.Ddate, n.Qtrs, n.Dimh)
+		if err != nil {
+			return fmt.Errorf("%s: %w", n.Tag, err)
+		}
+		d["unit"] = unit(n.Uom)
+		fact := xbrlJSONFact{Dimensions: d}
+		if n.Value != nil {
+			value := n.Value.String()
+			fact.Value = &value
+		}
+		if n.Dcml != models.DcmlINF && n.Dcml != models.DcmlUnknown {
+			decimals := n.Dcml
+			fact.Decimals = &decimals
+		}
+		report.Facts[id(i)] = fact
+	}
+	for i, t := range f.Txts {
+		d, err := dimensions(qname(t.Tag, t.Version), t.Ddate, t.Qtrs, t.Dimh)
+		if err != nil {
+			return fmt.Errorf("%s: %w", t.Tag, err)
+		}
+		if t.Lang != "" {
+			d["language"] = strings.ToLower(t.Lang)
+		}
+		report.Facts[id(len(f.Nums)+i)] = xbrlJSONFact{Value: t.Value, Dimensions: d}
+	}
+
+	if _, ok := namespaces[custom]; ok && f.Sub.Instance != "" {
+		base := f.Sub.Instance[:len(f.Sub.Instance)-len(extension(f.Sub.Instance))]
+		base = strings.TrimSuffix(base, "_htm")
+		taxonomies[folder+"/"+base+".xsd"] = true
+	}
+	report.DocumentInfo.Taxonomy = []string{}
+	for t := range taxonomies {
+		report.DocumentInfo.Taxonomy = append(report.DocumentInfo.Taxonomy, t)
+	}
+	sort.Strings(report.DocumentInfo.Taxonomy)
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(report)
+}
+
+// mainTaxonomy returns the standard version the most facts of f are of,
+// e.g. us-gaap/2018 (us-gaap for companyfacts.zip), empty if none
+func mainTaxonomy(f *query.Filing) string {
+	counts := map[string]int{}
+	for _, n := range f.Nums {
+		if !adshPattern.MatchString(n.Version) {
+			counts[n.Version]++
+		}
+	}
+	main := ""
+	for version, count := range counts {
+		if count > counts[main] || count == counts[main] && version < main {
+			main = version
+		}
+	}
+	return main
+}
+
+// filingYear returns the year of the period of sub, the version of the
+// standard taxonomies its facts are assumed to be of when unknown
+func filingYear(sub models.DataSUB) string {
+	for _, date := range []string{sub.Period, sub.Filed} {
+		if len(date) >= 4 {
+			return date[:4]
+		}
+	}
+	return sub.Fy
+}
+
+// customPrefix returns the prefix of the custom tags of sub, the one of the
+// file name of its instance (fb of fb-20181231.xml), or ext
+func customPrefix(sub models.DataSUB) string {
+	prefix := strings.SplitN(sub.Instance, "-", 2)[0]
+	if prefix == "" || prefix == sub.Instance || !ncName.MatchString(prefix) {
+		return "ext"
+	}
+	return strings.ToLower(prefix)
+}
+
+// extension returns the extension of a file name, .xml of fb-20181231.xml
+func extension(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i:]
+	}
+	return ""
+}
+
+// oimPeriod returns the period of a fact of the data sets as an OIM period:
+// the start of the day after ddate for an instant, the interval from the
+// start of the quarters to it for a duration
+func oimPeriod(ddate string, qtrs int) (string, error) {
+	end, err := time.Parse("20060102", ddate)
+	if err != nil {
+		return "", err
+	}
+	end = end.AddDate(0, 0, 1)
+	if qtrs == 0 {
+		return end.Format("2006-01-02T15:04:05"), nil
+	}
+	start := end.AddDate(0, -3*qtrs, 0)
+	return start.Format("2006-01-02T15:04:05") + "/" + end.Format("2006-01-02T15:04:05"), nil
+}
@@ -0,0 +1,151 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eswiac.me/filingsdb"
+	"eswiac.me/filingsdb/export"
+	"eswiac.me/filingsdb/sectest"
+)
+
+// appleFacts is a CIK##########.json of companyfacts.zip
+const appleFacts = `{
+  "cik": 320193,
+  "entityName": "Apple Inc.",
+  "facts": {
+    "dei": {
+      "EntityCommonStockSharesOutstanding": {
+        "label": "Entity Common Stock, Shares Outstanding",
+        "units": {"shares": [{"end": "2018-10-19", "val": 4745398000, "accn": "0000320193-18-000145", "fy": 2018, "fp": "FY", "form": "10-K", "filed": "2018-11-05"}]}
+      }
+    },
+    "us-gaap": {
+      "Revenues": {
+        "label": "Revenues",
+        "units": {"USD": [{"start": "2017-10-01", "end": "2018-09-29", "val": 265595000000, "accn": "0000320193-18-000145", "fy": 2018, "fp": "FY", "form": "10-K", "filed": "2018-11-05", "frame": "CY2018"}]}
+      }
+    }
+  }
+}`
+
+// xbrlJSONReport is the part of an xBRL-JSON report the tests check
+type xbrlJSONReport struct {
+	DocumentInfo struct {
+		Namespaces map[string]string
+		Taxonomy   []string
+	}
+	Facts map[string]struct {
+		Value      *string
+		Decimals   *int
+		Dimensions map[string]string
+	}
+}
+
+// exportFiling loads the sample archive and appleFacts, and exports adsh
+func exportFiling(t *testing.T, adsh string) xbrlJSONReport {
+	dir, err := ioutil.TempDir("", "filingsdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := filingsdb.Open(filepath.Join(dir, "filings.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	archive, facts := filepath.Join(dir, "2019q1_notes.zip"), filepath.Join(dir, "CIK0000320193.json")
+	if err := ioutil.WriteFile(archive, sectest.SampleArchive(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(facts, []byte(appleFacts), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ExtractFromZip(ctx, archive); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.IngestCompanyFacts(ctx, facts); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := db.Filing(ctx, adsh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := export.XBRLJSON(buf, f); err != nil {
+		t.Fatal(err)
+	}
+	report := xbrlJSONReport{}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+// concepts returns the decimals of the facts of report by concept, -1000
+// for none
+func concepts(report xbrlJSONReport) map[string]int {
+	c := map[string]int{}
+	for _, fact := range report.Facts {
+		c[fact.Dimensions["concept"]] = -1000
+		if fact.Decimals != nil {
+			c[fact.Dimensions["concept"]] = *fact.Decimals
+		}
+	}
+	return c
+}
+
+func TestXBRLJSONDataSets(t *testing.T) {
+	report := exportFiling(t, "0001326801-19-000009")
+	c := concepts(report)
+	for _, concept := range []string{"us-gaap:Revenues", "us-gaap:Assets", "x:AdvertisingRevenue", "us-gaap:NatureOfOperations", "dei:DocumentType"} {
+		if _, ok := c[concept]; !ok {
+			t.Errorf("no fact of %s in %v", concept, c)
+		}
+	}
+	if c["us-gaap:Revenues"] != -6 {
+		t.Errorf("decimals %d of us-gaap:Revenues, expected -6", c["us-gaap:Revenues"])
+	}
+	ns := report.DocumentInfo.Namespaces
+	if ns["us-gaap"] != "http://fasb.org/us-gaap/2018-01-31" || ns["x"] != "https://www.sec.gov/Archives/edgar/data/1326801/000132680119000009" {
+		t.Errorf("namespaces %v", ns)
+	}
+	segmented := 0
+	for _, fact := range report.Facts {
+		if fact.Dimensions["us-gaap:StatementBusinessSegmentsAxis"] != "" || len(fact.Dimensions) > 4 {
+			segmented++
+		}
+	}
+	if segmented != 1 {
+		t.Errorf("%d facts with dimensions, expected 1", segmented)
+	}
+}
+
+func TestXBRLJSONCompanyFacts(t *testing.T) {
+	report := exportFiling(t, "0000320193-18-000145")
+	c := concepts(report)
+	expected := map[string]int{"us-gaap:Revenues": -1000, "dei:EntityCommonStockSharesOutstanding": -1000}
+	if len(c) != len(expected) {
+		t.Errorf("concepts %v, expected %v", c, expected)
+	}
+	for concept, decimals := range expected {
+		if d, ok := c[concept]; !ok || d != decimals {
+			t.Errorf("concepts %v, expected %v", c, expected)
+		}
+	}
+	ns := report.DocumentInfo.Namespaces
+	if ns["us-gaap"] != "http://fasb.org/us-gaap/2018-01-31" || ns["dei"] != "http://xbrl.sec.gov/dei/2018-01-31" {
+		t.Errorf("namespaces %v", ns)
+	}
+	if len(report.DocumentInfo.Taxonomy) != 2 || report.DocumentInfo.Taxonomy[0] != "https://xbrl.fasb.org/us-gaap/2018/elts/us-gaap-2018-01-31.xsd" {
+		t.Errorf("taxonomies %v", report.DocumentInfo.Taxonomy)
+	}
+}
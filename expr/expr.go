@@ -0,0 +1,379 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Env resolves the identifiers of an expression. Values are nil (unknown),
+// float64, string or bool.
+type Env interface {
+	Lookup(name string) (interface{}, error)
+}
+
+// MapEnv is an Env backed by a map, unknown identifiers being nil
+type MapEnv map[string]interface{}
+
+// Lookup returns the value of name
+func (m MapEnv) Lookup(name string) (interface{}, error) {
+	return m[name], nil
+}
+
+// Expr is a node of a parsed expression
+type Expr interface {
+	// Eval evaluates the expression. Comparisons involving an unknown (nil)
+	// value are unknown, following SQL three-valued logic.
+	Eval(env Env) (interface{}, error)
+	String() string
+}
+
+// Literal is a number, string or null constant
+type Literal struct {
+	Value interface{}
+}
+
+// Ident is a reference to a value resolved by the Env
+type Ident struct {
+	Name string
+}
+
+// Arith is an arithmetic operation: +, -, * or /
+type Arith struct {
+	Op          string
+	Left, Right Expr
+}
+
+// Compare is a comparison: =, !=, <, <=, > or >=
+type Compare struct {
+	Op          string
+	Left, Right Expr
+}
+
+// In tests whether a value is one of a list
+type In struct {
+	Expr   Expr
+	List   []Expr
+	Negate bool
+}
+
+// IsNull tests whether a value is unknown
+type IsNull struct {
+	Expr   Expr
+	Negate bool
+}
+
+// Logical is a conjunction or disjunction: and, or
+type Logical struct {
+	Op          string
+	Left, Right Expr
+}
+
+// Not negates a condition
+type Not struct {
+	Expr Expr
+}
+
+func (e *Literal) Eval(env Env) (interface{}, error) {
+	return e.Value, nil
+}
+
+func (e *Ident) Eval(env Env) (interface{}, error) {
+	return env.Lookup(e.Name)
+}
+
+func (e *Arith) Eval(env Env) (interface{}, error) {
+	l, r, err := evalBoth(env, e.Left, e.Right)
+	if err != nil || l == nil || r == nil {
+		return nil, err
+	}
+	a, ok := toNumber(l)
+	if !ok {
+		return nil, fmt.Errorf("`%s` is not a number in %s", l, e)
+	}
+	b, ok := toNumber(r)
+	if !ok {
+		return nil, fmt.Errorf("`%s` is not a number in %s", r, e)
+	}
+	switch e.Op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	}
+	if b == 0 {
+		return nil, nil
+	}
+	return a / b, nil
+}
+
+func (e *Compare) Eval(env Env) (interface{}, error) {
+	l, r, err := evalBoth(env, e.Left, e.Right)
+	if err != nil || l == nil || r == nil {
+		return nil, err
+	}
+	c, err := compare(l, r)
+	if err != nil {
+		return nil, err
+	}
+	switch e.Op {
+	case "=":
+		return c == 0, nil
+	case "!=":
+		return c != 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	}
+	return c >= 0, nil
+}
+
+func (e *In) Eval(env Env) (interface{}, error) {
+	v, err := e.Expr.Eval(env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	unknown := false
+	for _, item := range e.List {
+		iv, err := item.Eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if iv == nil {
+			unknown = true
+			continue
+		}
+		c, err := compare(v, iv)
+		if err != nil {
+			return nil, err
+		}
+		if c == 0 {
+			return !e.Negate, nil
+		}
+	}
+	if unknown {
+		return nil, nil
+	}
+	return e.Negate, nil
+}
+
+func (e *IsNull) Eval(env Env) (interface{}, error) {
+	v, err := e.Expr.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+	return (v == nil) != e.Negate, nil
+}
+
+func (e *Logical) Eval(env Env) (interface{}, error) {
+	l, err := e.Left.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+	lb, lok := l.(bool)
+	// short circuit
+	if lok && ((e.Op == "and" && !lb) || (e.Op == "or" && lb)) {
+		return lb, nil
+	}
+	r, err := e.Right.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+	rb, rok := r.(bool)
+	if rok && ((e.Op == "and" && !rb) || (e.Op == "or" && rb)) {
+		return rb, nil
+	}
+	if !lok || !rok {
+		return nil, nil
+	}
+	return rb, nil
+}
+
+func (e *Not) Eval(env Env) (interface{}, error) {
+	v, err := e.Expr.Eval(env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a condition", e.Expr)
+	}
+	return !b, nil
+}
+
+// Match evaluates a condition, unknown results being false
+func Match(e Expr, env Env) (bool, error) {
+	v, err := e.Eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, _ := v.(bool)
+	return b, nil
+}
+
+func evalBoth(env Env, left, right Expr) (interface{}, interface{}, error) {
+	l, err := left.Eval(env)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := right.Eval(env)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
+
+func toNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	case bool:
+		if n {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// compare orders two values, numerically if both can be read as numbers (so
+// that `sic = 2834` matches the text "2834"), otherwise as strings
+func compare(a, b interface{}) (int, error) {
+	x, aok := toNumber(a)
+	y, bok := toNumber(b)
+	if aok && bok {
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	s, sok := a.(string)
+	t, tok := b.(string)
+	if !sok || !tok {
+		return 0, fmt.Errorf("cannot compare `%v` with `%v`", a, b)
+	}
+	return strings.Compare(s, t), nil
+}
+
+func (e *Literal) String() string {
+	switch v := e.Value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "'" + strings.Replace(v, "'", "''", -1) + "'"
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return fmt.Sprint(e.Value)
+}
+
+func (e *Ident) String() string {
+	return e.Name
+}
+
+func (e *Arith) String() string {
+	return "(" + e.Left.String() + " " + e.Op + " " + e.Right.String() + ")"
+}
+
+func (e *Compare) String() string {
+	return e.Left.String() + " " + e.Op + " " + e.Right.String()
+}
+
+func (e *In) String() string {
+	items := []string{}
+	for _, item := range e.List {
+		items = append(items, item.String())
+	}
+	op := " in ("
+	if e.Negate {
+		op = " not in ("
+	}
+	return e.Expr.String() + op + strings.Join(items, ", ") + ")"
+}
+
+func (e *IsNull) String() string {
+	if e.Negate {
+		return e.Expr.String() + " is not null"
+	}
+	return e.Expr.String() + " is null"
+}
+
+func (e *Logical) String() string {
+	return "(" + e.Left.String() + " " + e.Op + " " + e.Right.String() + ")"
+}
+
+func (e *Not) String() string {
+	return "not " + e.Expr.String()
+}
+
+// Idents returns the identifiers referenced by e, in order of appearance
+func Idents(e Expr) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	Walk(e, func(n Expr) {
+		if id, ok := n.(*Ident); ok && !seen[id.Name] {
+			seen[id.Name] = true
+			names = append(names, id.Name)
+		}
+	})
+	return names
+}
+
+// Walk calls fn for e and each of its descendants, depth first
+func Walk(e Expr, fn func(Expr)) {
+	fn(e)
+	switch n := e.(type) {
+	case *Arith:
+		Walk(n.Left, fn)
+		Walk(n.Right, fn)
+	case *Compare:
+		Walk(n.Left, fn)
+		Walk(n.Right, fn)
+	case *Logical:
+		Walk(n.Left, fn)
+		Walk(n.Right, fn)
+	case *In:
+		Walk(n.Expr, fn)
+		for _, item := range n.List {
+			Walk(item, fn)
+		}
+	case *IsNull:
+		Walk(n.Expr, fn)
+	case *Not:
+		Walk(n.Expr, fn)
+	}
+}
+
+// Conjuncts splits e on its top level `and`s
+func Conjuncts(e Expr) []Expr {
+	if l, ok := e.(*Logical); ok && l.Op == "and" {
+		return append(Conjuncts(l.Left), Conjuncts(l.Right)...)
+	}
+	return []Expr{e}
+}
+
+// And joins conditions with `and`, nil if there are none
+func And(exprs []Expr) Expr {
+	var e Expr
+	for _, c := range exprs {
+		if e == nil {
+			e = c
+		} else {
+			e = &Logical{Op: "and", Left: e, Right: c}
+		}
+	}
+	return e
+}
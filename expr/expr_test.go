@@ -0,0 +1,229 @@
+package expr
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src, err string
+	}{
+		{"", "at 0: unexpected end of expression"},
+		{"sic >", "at 5: unexpected end of expression"},
+		{"sic = 2834 )", "at 11: unexpected `)`"},
+		{"(sic = 2834", "at 11: expected `)`"},
+		{"sic not 2834", "at 8: expected `in` after `not`"},
+		{"sic is 2834", "at 7: expected `null`"},
+		{"sic in 2834", "at 7: expected `(` after `in`"},
+		{"sic in (2834 2836)", "at 13: expected `,` or `)` in list"},
+		{"sic = and", "at 6: unexpected `and`"},
+		{"form = '10-K", "unterminated string at 7"},
+		{"Revenue > 1.2.3", "invalid number `1.2.3` at 10"},
+		{"sic # 2834", "unexpected character `#` at 4"},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.src)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Parse(%q): %v, expected %s", test.src, err, test.err)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	env := MapEnv{"sic": "2834", "form": "10-K", "Revenue": 2e8, "NetIncome": -5e6, "Zero": 0.0}
+	tests := []struct {
+		src      string
+		expected interface{}
+	}{
+		{"sic = 2834", true},
+		{"sic == '2834'", true},
+		{"sic <> 2834", false},
+		{"form = '10-K' and Revenue > 1e8", true},
+		{"form < '10-Q'", true},
+		{"Revenue / 1e6 + 1", 201.0},
+		{"-NetIncome", 5e6},
+		{"NetIncome / Revenue < -0.02", true},
+		{"Revenue / Zero", nil},
+		{"Revenue / Zero > 0", nil},
+		{"Missing", nil},
+		{"Missing + 1", nil},
+		{"Missing > 0", nil},
+		{"not Missing > 0", nil},
+		{"Missing is null", true},
+		{"sic is not null", true},
+		{"Missing > 0 and sic = 1", false},
+		{"Missing > 0 and sic = 2834", nil},
+		{"Missing > 0 or sic = 2834", true},
+		{"Missing > 0 or sic = 1", nil},
+		{"sic in (2834, 2836)", true},
+		{"sic not in (2834, 2836)", false},
+		{"sic in (1, null)", nil},
+		{"sic not in (1, null)", nil},
+		{"sic in (2834, null)", true},
+		{"Missing in (2834)", nil},
+		{"sic = null", nil},
+	}
+	for _, test := range tests {
+		e, err := Parse(test.src)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", test.src, err)
+			continue
+		}
+		v, err := e.Eval(env)
+		if err != nil || v != test.expected {
+			t.Errorf("%s = %v (%v), expected %v", test.src, v, err, test.expected)
+		}
+	}
+
+	for _, src := range []string{"form = 10", "form + 1", "not Revenue"} {
+		e, err := Parse(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, err := e.Eval(env); err == nil {
+			t.Errorf("%s = %v, expected an error", src, v)
+		}
+	}
+}
+
+// testColumn maps the identifiers of a test to columns of the table subs
+func testColumn(name string) (string, bool) {
+	switch name {
+	case "sic", "form", "fy", "name", "revenue":
+		return "subs." + name, true
+	}
+	return "", false
+}
+
+func TestToSQL(t *testing.T) {
+	tests := []struct {
+		src  string
+		sql  string
+		args []interface{}
+	}{
+		{"form = '10-K'", "subs.form = ?", []interface{}{"10-K"}},
+		{"form != '10-K'", "subs.form <> ?", []interface{}{"10-K"}},
+		{"sic = 2834", "CAST(subs.sic AS REAL) = CAST(? AS REAL)", []interface{}{2834.0}},
+		{"revenue / 1e6 > 1", "CAST((CAST(subs.revenue AS REAL) / CAST(? AS REAL)) AS REAL) > CAST(? AS REAL)", []interface{}{1e6, 1.0}},
+		{"-revenue < 0", "CAST((CAST(? AS REAL) - CAST(subs.revenue AS REAL)) AS REAL) < CAST(? AS REAL)", []interface{}{0.0, 0.0}},
+		{"sic in (2834, '2836')", "CAST(subs.sic AS REAL) IN (?, ?)", []interface{}{2834.0, "2836"}},
+		{"form not in ('10-K', null)", "subs.form NOT IN (?, NULL)", []interface{}{"10-K"}},
+		{"fy is null or not (form is not null)", "(subs.fy IS NULL OR NOT (subs.form IS NOT NULL))", nil},
+		{"sic = 2834 and name = 'Acme'", "(CAST(subs.sic AS REAL) = CAST(? AS REAL) AND subs.name = ?)", []interface{}{2834.0, "Acme"}},
+	}
+	for _, test := range tests {
+		e, err := Parse(test.src)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", test.src, err)
+			continue
+		}
+		sql, args, ok := ToSQL(e, testColumn)
+		if !ok || sql != test.sql || !reflect.DeepEqual(args, test.args) {
+			t.Errorf("ToSQL(%s) = %s %v %v, expected %s %v", test.src, sql, args, ok, test.sql, test.args)
+		}
+	}
+
+	e, err := Parse("sic = 2834 and Revenue_ttm > 1e8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := ToSQL(e, testColumn); ok {
+		t.Errorf("ToSQL of an identifier with no column")
+	}
+}
+
+// TestToSQLEval checks that the conditions compiled by ToSQL select the rows
+// Match does, text columns and NULLs included
+func TestToSQLEval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "expr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "expr.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	rows := []MapEnv{
+		{"sic": "2834", "form": "10-K", "fy": "2018", "name": "Acme", "revenue": 100.0},
+		{"sic": "2836", "form": "10-Q", "fy": "2019", "name": "Beta", "revenue": 0.0},
+		{"sic": nil, "form": "10-K", "fy": nil, "name": "Gamma", "revenue": nil},
+		{"sic": "7372", "form": "10-K/A", "fy": "2017", "name": "delta", "revenue": -5.0},
+	}
+	if err := db.Exec("CREATE TABLE subs (id INTEGER, sic TEXT, form TEXT, fy TEXT, name TEXT, revenue REAL)").Error; err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range rows {
+		err := db.Exec("INSERT INTO subs VALUES (?, ?, ?, ?, ?, ?)", i, r["sic"], r["form"], r["fy"], r["name"], r["revenue"]).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, src := range []string{
+		"sic = 2834",
+		"sic != 2834",
+		"sic >= 2836",
+		"fy > 2017.5",
+		"sic in (2834, 2836)",
+		"sic not in (2834, 2836)",
+		"sic in (2834, null)",
+		"sic in (2834, '2836')",
+		"sic not in (2834, null)",
+		"form = '10-K'",
+		"form > '10-K'",
+		"name < 'b'",
+		"sic is null",
+		"fy is not null and form != '10-Q'",
+		"not (sic = 2834)",
+		"sic = 2834 or fy is null",
+		"sic = 2834 and revenue > 0",
+		"revenue / 0 > 0",
+		"revenue / 0 is null",
+		"revenue / fy > 0.04",
+		"revenue * 2 >= 200 or -revenue > 0",
+		"revenue in (0, 100)",
+		"not (revenue > 0 or fy < 2018)",
+	} {
+		e, err := Parse(src)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", src, err)
+			continue
+		}
+		expected := []int{}
+		for i, r := range rows {
+			ok, err := Match(e, r)
+			if err != nil {
+				t.Fatalf("%s: %v", src, err)
+			}
+			if ok {
+				expected = append(expected, i)
+			}
+		}
+		where, args, ok := ToSQL(e, testColumn)
+		if !ok {
+			t.Fatalf("%s: no SQL", src)
+		}
+		selected := []int{}
+		if err := db.Table("subs").Where(where, args...).Order("id").Pluck("id", &selected).Error; err != nil {
+			t.Fatalf("%s: %v", src, err)
+		}
+		if fmt.Sprint(selected) != fmt.Sprint(expected) {
+			t.Errorf("%s: SQL selects %v, Match %v", src, selected, expected)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+// keywords are matched case insensitively and can't be used as identifiers
+var keywords = map[string]bool{
+	"and":  true,
+	"or":   true,
+	"not":  true,
+	"in":   true,
+	"is":   true,
+	"null": true,
+}
+
+func (t token) is(keyword string) bool {
+	return t.kind == tokIdent && strings.EqualFold(t.text, keyword)
+}
+
+func lex(src string) ([]token, error) {
+	tokens := []token{}
+	i := 0
+	for i < len(src) {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ",", pos: i})
+			i++
+		case c == '\'' || c == '"':
+			end := i + 1
+			var sb strings.Builder
+			for {
+				if end >= len(src) {
+					return nil, fmt.Errorf("unterminated string at %d", i)
+				}
+				if rune(src[end]) == c {
+					// a doubled quote is an escaped quote
+					if end+1 < len(src) && rune(src[end+1]) == c {
+						sb.WriteByte(src[end])
+						end += 2
+						continue
+					}
+					break
+				}
+				sb.WriteByte(src[end])
+				end++
+			}
+			tokens = append(tokens, token{kind: tokString, text: sb.String(), pos: i})
+			i = end + 1
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1]))):
+			end := i
+			for end < len(src) && (unicode.IsDigit(rune(src[end])) || src[end] == '.') {
+				end++
+			}
+			// exponent, e.g. 1e8 or 2.5E-3
+			if end < len(src) && (src[end] == 'e' || src[end] == 'E') {
+				exp := end + 1
+				if exp < len(src) && (src[exp] == '+' || src[exp] == '-') {
+					exp++
+				}
+				if exp < len(src) && unicode.IsDigit(rune(src[exp])) {
+					end = exp
+					for end < len(src) && unicode.IsDigit(rune(src[end])) {
+						end++
+					}
+				}
+			}
+			num, err := strconv.ParseFloat(src[i:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number `%s` at %d", src[i:end], i)
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[i:end], num: num, pos: i})
+			i = end
+		case c == '_' || unicode.IsLetter(c):
+			end := i
+			for end < len(src) && (src[end] == '_' || unicode.IsLetter(rune(src[end])) || unicode.IsDigit(rune(src[end]))) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[i:end], pos: i})
+			i = end
+		default:
+			op := ""
+			for _, candidate := range []string{"<=", ">=", "<>", "!=", "==", "=", "<", ">", "+", "-", "*", "/"} {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character `%c` at %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
@@ -0,0 +1,248 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parse parses a filter expression such as
+//
+//	sic in (2834, 2836) and Revenue_ttm > 1e8 and NetIncome_ttm < 0 and form = '10-K'
+//
+// Expressions combine comparisons (=, !=, <>, <, <=, >, >=), `in` lists and
+// `is [not] null` tests of identifiers, numbers and quoted strings with and,
+// or, not and parentheses. Arithmetic (+, -, *, /) is allowed on both sides of
+// a comparison. Keywords are case insensitive.
+func Parse(src string) (Expr, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, p.errorf("unexpected `%s`", p.peek().text)
+	}
+	return e, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("at %d: %s", p.peek().pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) or() (Expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		p.next()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "or", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (Expr, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		p.next()
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: "and", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) not() (Expr, error) {
+	if p.peek().is("not") {
+		p.next()
+		e, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: e}, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (Expr, error) {
+	left, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	switch {
+	case t.kind == tokOp && isComparison(t.text):
+		p.next()
+		right, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		op := t.text
+		if op == "==" {
+			op = "="
+		}
+		if op == "<>" {
+			op = "!="
+		}
+		return &Compare{Op: op, Left: left, Right: right}, nil
+	case t.is("in"):
+		p.next()
+		return p.in(left, false)
+	case t.is("not"):
+		p.next()
+		if !p.peek().is("in") {
+			return nil, p.errorf("expected `in` after `not`")
+		}
+		p.next()
+		return p.in(left, true)
+	case t.is("is"):
+		p.next()
+		negate := false
+		if p.peek().is("not") {
+			p.next()
+			negate = true
+		}
+		if !p.peek().is("null") {
+			return nil, p.errorf("expected `null`")
+		}
+		p.next()
+		return &IsNull{Expr: left, Negate: negate}, nil
+	}
+	return left, nil
+}
+
+func isComparison(op string) bool {
+	switch op {
+	case "=", "==", "!=", "<>", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func (p *parser) in(left Expr, negate bool) (Expr, error) {
+	if p.peek().kind != tokLParen {
+		return nil, p.errorf("expected `(` after `in`")
+	}
+	p.next()
+	list := []Expr{}
+	for {
+		e, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+		t := p.peek()
+		if t.kind != tokRParen && t.kind != tokComma {
+			return nil, p.errorf("expected `,` or `)` in list")
+		}
+		p.next()
+		if t.kind == tokRParen {
+			break
+		}
+	}
+	return &In{Expr: left, List: list, Negate: negate}, nil
+}
+
+func (p *parser) sum() (Expr, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == tokOp && (t.text == "+" || t.text == "-"); t = p.peek() {
+		p.next()
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = &Arith{Op: t.text, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) term() (Expr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.kind == tokOp && (t.text == "*" || t.text == "/"); t = p.peek() {
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Arith{Op: t.text, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (Expr, error) {
+	if t := p.peek(); t.kind == tokOp && t.text == "-" {
+		p.next()
+		e, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Arith{Op: "-", Left: &Literal{Value: 0.0}, Right: e}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &Literal{Value: t.num}, nil
+	case tokString:
+		return &Literal{Value: t.text}, nil
+	case tokIdent:
+		if t.is("null") {
+			return &Literal{Value: nil}, nil
+		}
+		if keywords[strings.ToLower(t.text)] {
+			return nil, fmt.Errorf("at %d: unexpected `%s`", t.pos, t.text)
+		}
+		return &Ident{Name: t.text}, nil
+	case tokLParen:
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokRParen {
+			return nil, p.errorf("expected `)`")
+		}
+		return e, nil
+	case tokEOF:
+		return nil, fmt.Errorf("at %d: unexpected end of expression", t.pos)
+	}
+	return nil, fmt.Errorf("at %d: unexpected `%s`", t.pos, t.text)
+}
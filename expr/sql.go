@@ -0,0 +1,95 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToSQL compiles e into a parameterized SQL condition. column maps each
+// identifier to a SQL column expression; ok is false if an identifier has no
+// column, in which case e must be evaluated with Eval instead.
+func ToSQL(e Expr, column func(name string) (string, bool)) (sql string, args []interface{}, ok bool) {
+	c := &sqlCompiler{column: column}
+	sql = c.compile(e)
+	return sql, c.args, c.ok()
+}
+
+type sqlCompiler struct {
+	column  func(name string) (string, bool)
+	args    []interface{}
+	missing bool
+}
+
+func (c *sqlCompiler) ok() bool {
+	return !c.missing
+}
+
+func (c *sqlCompiler) compile(e Expr) string {
+	switch n := e.(type) {
+	case *Literal:
+		if n.Value == nil {
+			return "NULL"
+		}
+		c.args = append(c.args, n.Value)
+		return "?"
+	case *Ident:
+		col, ok := c.column(n.Name)
+		if !ok {
+			c.missing = true
+		}
+		return col
+	case *Arith:
+		// sqlite stores most columns as text, make sure they're computed as numbers
+		return fmt.Sprintf("(CAST(%s AS REAL) %s CAST(%s AS REAL))", c.compile(n.Left), n.Op, c.compile(n.Right))
+	case *Compare:
+		op := n.Op
+		if op == "!=" {
+			op = "<>"
+		}
+		left, right := c.compile(n.Left), c.compile(n.Right)
+		if isNumeric(n.Left) || isNumeric(n.Right) {
+			left, right = "CAST("+left+" AS REAL)", "CAST("+right+" AS REAL)"
+		}
+		return left + " " + op + " " + right
+	case *In:
+		left := c.compile(n.Expr)
+		items := []string{}
+		numeric := false
+		for _, item := range n.List {
+			numeric = numeric || isNumeric(item)
+			items = append(items, c.compile(item))
+		}
+		if numeric {
+			left = "CAST(" + left + " AS REAL)"
+		}
+		op := " IN ("
+		if n.Negate {
+			op = " NOT IN ("
+		}
+		return left + op + strings.Join(items, ", ") + ")"
+	case *IsNull:
+		if n.Negate {
+			return c.compile(n.Expr) + " IS NOT NULL"
+		}
+		return c.compile(n.Expr) + " IS NULL"
+	case *Logical:
+		return "(" + c.compile(n.Left) + " " + strings.ToUpper(n.Op) + " " + c.compile(n.Right) + ")"
+	case *Not:
+		return "NOT (" + c.compile(n.Expr) + ")"
+	}
+	c.missing = true
+	return ""
+}
+
+// isNumeric reports whether e is a number literal or an arithmetic operation,
+// so that a comparison with a text column is done numerically
+func isNumeric(e Expr) bool {
+	switch n := e.(type) {
+	case *Literal:
+		_, ok := n.Value.(float64)
+		return ok
+	case *Arith:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+// Package filingsdb downloads the Financial Statement and Notes data sets
+// from sec.gov into a sqlite database and queries them.
+//
+//	db, err := filingsdb.Open("filings_2019.db")
+//	archives, err := filingsdb.ListArchives(ctx)
+//	err = db.Ingest(ctx, archives[0].URL)
+//	facts, err := db.Fundamentals(ctx, query.FactQuery{Cik: "1326801"})
+package filingsdb
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"eswiac.me/filingsdb/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// BATCH_SIZE is the count of rows inserted at once
+const BATCH_SIZE = 500
+
+// Logger receives the progress messages of the library. *log.Logger is a Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// DB is a filings database
+type DB struct {
+	gorm     *gorm.DB
+	logger   Logger
+	progress io.Writer
+	filter   *rowFilter
+	client   *Client
+	cache    *Cache
+
+	textPolicy TextPolicy
+}
+
+// Option configures a DB
+type Option func(*DB)
+
+// WithLogger sets the logger of the progress messages, discarded by default
+func WithLogger(l Logger) Option {
+	return func(db *DB) {
+		db.logger = l
+	}
+}
+
+// WithClient sets the client of sec.gov, DefaultClient by default
+func WithClient(c *Client) Option {
+	return func(db *DB) {
+		db.client = c
+	}
+}
+
+// WithProgress tees the archives downloaded by Ingest into w, e.g. to
+// display a byte counter
+func WithProgress(w io.Writer) Option {
+	return func(db *DB) {
+		db.progress = w
+	}
+}
+
+// Open opens the sqlite database dsn, creating it and its tables if needed
+func Open(dsn string, opts ...Option) (*DB, error) {
+	db, err := open(dsn, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Migrate(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// OpenExisting opens the sqlite database dsn built beforehand, to read it:
+// unlike Open, it doesn't create the database nor alter its tables
+func OpenExisting(dsn string, opts ...Option) (*DB, error) {
+	file := strings.TrimPrefix(strings.SplitN(dsn, "?", 2)[0], "file:")
+	if _, err := os.Stat(file); err != nil {
+		return nil, err
+	}
+	return open(dsn, opts)
+}
+
+func open(dsn string, opts []Option) (*DB, error) {
+	db := &DB{logger: log.New(ioutil.Discard, "", 0), client: DefaultClient}
+	for _, opt := range opts {
+		opt(db)
+	}
+	if !strings.Contains(dsn, "?") {
+		dsn += "?_journal_mode=WAL"
+	}
+	newLogger := logger.New(
+		log.New(os.Stdout, "\r\n", log.LstdFlags), // io writer
+		logger.Config{
+			SlowThreshold: time.Minute,   // Slow SQL threshold
+			LogLevel:      logger.Silent, // Log level
+			Colorful:      true,          // Disable color
+		},
+	)
+	g, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: newLogger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	db.gorm = g
+	return db, nil
+}
+
+// Migrate creates the tables of the data sets. The submissions loaded before
+// the dataset column existed, all from notes archives, are marked as such.
+func (db *DB) Migrate() error {
+	err := db.gorm.AutoMigrate(
+		&models.DataSUB{},
+		&models.DataTAG{},
+		&models.DataDIM{},
+		&models.DataNUM{},
+		&models.DataTXT{},
+		&models.DataTXTValue{},
+		&models.DataPRE{},
+		&models.DataREN{},
+		&models.DataCAL{},
+		&models.DataFRAME{},
+		&models.Company{},
+		&models.CompanyTicker{},
+		&models.CompanyFormerName{},
+		&models.CompanyFiling{},
+		&models.DataTicker{},
+		&models.DataSIC{},
+	)
+	if err != nil {
+		return err
+	}
+	return db.gorm.Model(&models.DataSUB{}).
+		Where("dataset IS NULL OR dataset = ''").
+		Update("dataset", models.DatasetNotes).Error
+}
+
+// Gorm returns the underlying gorm handle, for queries the library doesn't cover
+func (db *DB) Gorm() *gorm.DB {
+	return db.gorm
+}
+
+// Close closes the database
+func (db *DB) Close() error {
+	sqlDB, err := db.gorm.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,45 @@
+package filingsdb_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eswiac.me/filingsdb"
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/sectest"
+)
+
+func TestMigrateDataset(t *testing.T) {
+	db, dir := openTemp(t)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "2019q1_notes.zip")
+	if err := ioutil.WriteFile(file, sectest.SampleArchive(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ExtractFromZip(context.Background(), file); err != nil {
+		t.Fatal(err)
+	}
+	// a database built before the dataset column
+	if err := db.Gorm().Exec("UPDATE data_subs SET dataset = NULL").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := filingsdb.Open(filepath.Join(dir, "filings.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var count int64
+	if err := db.Gorm().Model(&models.DataSUB{}).Where("dataset = ?", models.DatasetNotes).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if expected := fixtureRows(sectest.SampleFiles, "sub.tsv"); count != expected {
+		t.Errorf("%d submissions marked notes, expected %d", count, expected)
+	}
+}
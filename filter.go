@@ -0,0 +1,137 @@
+package filingsdb
+
+import (
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+)
+
+// IngestFilter restricts the rows loaded from the data sets. Empty lists
+// don't filter anything.
+type IngestFilter struct {
+	/**
+	Submission filters, applied to sub.tsv. The rows of the other
+	files are only loaded if their submission (adsh) passes, custom
+	tags of tag.tsv being loaded if the submission defining them does.
+	*/
+	Forms []string
+	Ciks  []string
+	Sics  []string
+
+	/**
+	Tag allow and deny lists, applied to tag.tsv, num.tsv, txt.tsv,
+	pre.tsv and to both tags of the cal.tsv arcs
+	*/
+	Tags        []string
+	ExcludeTags []string
+
+	// Only load the facts of primary reports (iprx = 1) of num.tsv and txt.tsv
+	PrimaryOnly bool
+}
+
+// WithFilter only loads the rows of the data sets passing f
+func WithFilter(f IngestFilter) Option {
+	return func(db *DB) {
+		db.filter = newRowFilter(f)
+	}
+}
+
+// rowFilter decides which records of an archive to load
+type rowFilter struct {
+	forms       map[string]bool
+	ciks        map[string]bool
+	sics        map[string]bool
+	tags        map[string]bool
+	excludeTags map[string]bool
+	primaryOnly bool
+
+	// submissions of the archive that passed, nil until sub.tsv is read
+	// or if there's no submission filter
+	adshs map[string]bool
+}
+
+func newRowFilter(f IngestFilter) *rowFilter {
+	ciks := []string{}
+	for _, cik := range f.Ciks {
+		ciks = append(ciks, strings.TrimLeft(cik, "0"))
+	}
+	return &rowFilter{
+		forms:       set(f.Forms),
+		ciks:        set(ciks),
+		sics:        set(f.Sics),
+		tags:        set(f.Tags),
+		excludeTags: set(f.ExcludeTags),
+		primaryOnly: f.PrimaryOnly,
+	}
+}
+
+func set(items []string) map[string]bool {
+	if len(items) == 0 {
+		return nil
+	}
+	s := map[string]bool{}
+	for _, item := range items {
+		s[item] = true
+	}
+	return s
+}
+
+// filtersSubmissions reports whether the filter selects submissions
+func (f *rowFilter) filtersSubmissions() bool {
+	return f.forms != nil || f.ciks != nil || f.sics != nil
+}
+
+// reset forgets the submissions of the previous archive
+func (f *rowFilter) reset() {
+	f.adshs = nil
+	if f.filtersSubmissions() {
+		f.adshs = map[string]bool{}
+	}
+}
+
+// keep reports whether record passes the filter. Submissions must be
+// checked first, see reset.
+func (f *rowFilter) keep(record interface{}) bool {
+	switch r := record.(type) {
+	case models.DataSUB:
+		// the submissions of companyfacts.zip have no SIC code to filter on
+		sic := in(f.sics, r.Sic) || r.Dataset == models.DatasetCompanyFacts
+		if !in(f.forms, r.Form) || !in(f.ciks, r.Cik) || !sic {
+			return false
+		}
+		if f.adshs != nil {
+			f.adshs[r.Adsh] = true
+		}
+		return true
+	case models.DataTAG:
+		// custom tags are versioned by the adsh of the submission defining them
+		if r.Custom && !f.submission(r.Version) {
+			return false
+		}
+		return f.tag(r.Tag)
+	case models.DataNUM:
+		return f.submission(r.Adsh) && f.tag(r.Tag) && (!f.primaryOnly || r.Iprx == 1)
+	case models.DataTXT:
+		return f.submission(r.Adsh) && f.tag(r.Tag) && (!f.primaryOnly || r.Iprx == 1)
+	case models.DataPRE:
+		return f.submission(r.Adsh) && f.tag(r.Tag)
+	case models.DataCAL:
+		return f.submission(r.Adsh) && f.tag(r.Ptag) && f.tag(r.Ctag)
+	case models.DataREN:
+		return f.submission(r.Adsh)
+	}
+	return true
+}
+
+func (f *rowFilter) submission(adsh string) bool {
+	return f.adshs == nil || f.adshs[adsh]
+}
+
+func (f *rowFilter) tag(tag string) bool {
+	return in(f.tags, tag) && !f.excludeTags[tag]
+}
+
+// in reports whether value is in s, a nil set containing everything
+func in(s map[string]bool, value string) bool {
+	return s == nil || s[value]
+}
@@ -0,0 +1,28 @@
+package filingsdb
+
+import (
+	"testing"
+
+	"eswiac.me/filingsdb/models"
+)
+
+func TestFilterSics(t *testing.T) {
+	f := newRowFilter(IngestFilter{Sics: []string{"2834"}})
+	f.reset()
+	subs := []struct {
+		sub  models.DataSUB
+		keep bool
+	}{
+		{models.DataSUB{Adsh: "0000078003-19-000015", Sic: "2834", Dataset: models.DatasetNotes}, true},
+		{models.DataSUB{Adsh: "0001326801-19-000009", Sic: "7370", Dataset: models.DatasetNotes}, false},
+		{models.DataSUB{Adsh: "0001682852-19-000012", Dataset: models.DatasetCompanyFacts}, true},
+	}
+	for _, s := range subs {
+		if keep := f.keep(s.sub); keep != s.keep {
+			t.Errorf("keep(%s) = %v", s.sub.Adsh, keep)
+		}
+		if keep := f.keep(models.DataNUM{Adsh: s.sub.Adsh}); keep != s.keep {
+			t.Errorf("keep of a fact of %s = %v", s.sub.Adsh, keep)
+		}
+	}
+}
@@ -6,8 +6,15 @@ require (
 	github.com/antchfx/htmlquery v1.2.3
 	github.com/briandowns/spinner v1.11.1
 	github.com/dustin/go-humanize v1.0.0
+	github.com/golang/protobuf v1.4.1
+	github.com/graphql-go/graphql v0.8.1
+	github.com/klauspost/compress v1.10.5
 	github.com/mattn/go-sqlite3 v1.14.2 // indirect
 	github.com/shopspring/decimal v1.2.0
+	github.com/xitongsys/parquet-go v1.5.4
+	golang.org/x/sys v0.0.0-20200323222414-85ca7c5b95cd
+	google.golang.org/grpc v1.33.2
+	google.golang.org/protobuf v1.25.0
 	gorm.io/driver/sqlite v1.1.1
 	gorm.io/gorm v1.20.0
 )
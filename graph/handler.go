@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+)
+
+// request is a GraphQL request as posted by clients
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves GraphQL queries over db, POSTed as JSON or passed in the
+// query parameter of a GET request
+type Handler struct {
+	db     *gorm.DB
+	schema graphql.Schema
+}
+
+// NewHandler returns a GraphQL handler over db
+func NewHandler(db *gorm.DB) (*Handler, error) {
+	schema, err := NewSchema()
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{db: db, schema: schema}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := request{}
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if v := r.URL.Query().Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				http.Error(w, "invalid variables: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        withLoaders(r.Context(), h.db),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// loader batches the lookups of a field across the objects of a query: the
+// resolvers register their key and return a thunk, and the first thunk
+// called fetches every key registered so far with a single query
+type loader struct {
+	fetch   func(keys []string) (map[string]interface{}, error)
+	mu      sync.Mutex
+	pending []string
+	results map[string]interface{}
+	err     error
+}
+
+func (l *loader) load(key string) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 && l.err == nil {
+			keys := unique(l.pending)
+			results, err := l.fetch(keys)
+			l.pending = nil
+			l.err = err
+			for _, k := range keys {
+				l.results[k] = results[k]
+			}
+		}
+		if l.err != nil {
+			return nil, l.err
+		}
+		return l.results[key], nil
+	}
+}
+
+func unique(keys []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// loaders are the loaders of a request, one per field and arguments
+type loaders struct {
+	db     *gorm.DB
+	mu     sync.Mutex
+	byName map[string]*loader
+}
+
+type loadersKey struct{}
+
+// withLoaders returns a context carrying fresh loaders over db
+func withLoaders(ctx context.Context, db *gorm.DB) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{db: db, byName: map[string]*loader{}})
+}
+
+func loadersOf(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// get returns the loader of field called with args, creating it with fetch
+// on first use
+func (ls *loaders) get(field string, args map[string]interface{}, fetch fetchFunc) *loader {
+	name := field
+	if len(args) > 0 {
+		// map keys are marshalled sorted
+		b, _ := json.Marshal(args)
+		name += string(b)
+	}
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	l, ok := ls.byName[name]
+	if !ok {
+		l = &loader{
+			fetch:   func(keys []string) (map[string]interface{}, error) { return fetch(ls.db, args, keys) },
+			results: map[string]interface{}{},
+		}
+		ls.byName[name] = l
+	}
+	return l
+}
+
+// groupBy loads into rows (a pointer to a slice of models) the rows of tx
+// whose column is one of keys, and groups them by the field of the model
+// mapped to that column. Every key gets a slice, empty if no row matches.
+func groupBy(tx *gorm.DB, rows interface{}, column string, field string, keys []string) (map[string]interface{}, error) {
+	if err := tx.Where(column+" IN ?", keys).Find(rows).Error; err != nil {
+		return nil, err
+	}
+	return group(rows, field, keys), nil
+}
+
+// group groups the rows found by the value of their field, one group per key
+func group(rows interface{}, field string, keys []string) map[string]interface{} {
+	v := reflect.ValueOf(rows).Elem()
+	groups := map[string]reflect.Value{}
+	for _, k := range keys {
+		groups[k] = reflect.MakeSlice(v.Type(), 0, 0)
+	}
+	for i := 0; i < v.Len(); i++ {
+		k := v.Index(i).FieldByName(field).String()
+		groups[k] = reflect.Append(groups[k], v.Index(i))
+	}
+	results := map[string]interface{}{}
+	for k, g := range groups {
+		results[k] = g.Interface()
+	}
+	return results
+}
+
+// first keeps the first row of each group
+func first(groups map[string]interface{}) map[string]interface{} {
+	results := map[string]interface{}{}
+	for k, g := range groups {
+		v := reflect.ValueOf(g)
+		if v.Len() > 0 {
+			results[k] = v.Index(0).Interface()
+		}
+	}
+	return results
+}
@@ -0,0 +1,319 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"eswiac.me/filingsdb/sic"
+	"github.com/graphql-go/graphql"
+	"gorm.io/gorm"
+)
+
+// Company is a registrant as of its most recent submission
+type Company struct {
+	Cik            string
+	Name           string
+	Sic            string
+	SicDescription string
+	Tickers        []string
+}
+
+// NewSchema returns the GraphQL schema of the filings database. Its object
+// types mirror the models, linked by:
+//
+//	Submission.company, nums, txts, pres, rens, cals
+//	Company.filings
+//	Num.submission, definition, dimension
+//	Txt.submission, definition, dimension
+//	Pre.definition
+//	Cal.parent, child
+//
+// Links are resolved in batches: the nums of every submission of a query are
+// read with one query, and so on.
+func NewSchema() (graphql.Schema, error) {
+	company := objectOf("Company", "A registrant as of its most recent submission", Company{})
+	sub := objectOf("Submission", "A submission, i.e. a filing (sub.tsv)", models.DataSUB{})
+	num := objectOf("Num", "A numeric fact (num.tsv)", models.DataNUM{})
+	txt := objectOf("Txt", "A non numeric fact (txt.tsv)", models.DataTXT{})
+	tag := objectOf("Tag", "The definition of a tag (tag.tsv)", models.DataTAG{})
+	dim := objectOf("Dim", "The dimensions of facts (dim.tsv)", models.DataDIM{})
+	pre := objectOf("Pre", "A line of a statement presentation (pre.tsv)", models.DataPRE{})
+	ren := objectOf("Ren", "A report of the rendered filing (ren.tsv)", models.DataREN{})
+	cal := objectOf("Cal", "A calculation arc (cal.tsv)", models.DataCAL{})
+
+	filterArgs := graphql.FieldConfigArgument{
+		"tag":   {Type: graphql.NewList(graphql.String)},
+		"ddate": {Type: graphql.String},
+		"qtrs":  {Type: graphql.Int},
+		"uom":   {Type: graphql.String},
+		"dimh":  {Type: graphql.String},
+	}
+	filingsArgs := graphql.FieldConfigArgument{
+		"form":   {Type: graphql.NewList(graphql.String)},
+		"fy":     {Type: graphql.String},
+		"fp":     {Type: graphql.String},
+		"limit":  {Type: graphql.Int, DefaultValue: 100},
+		"offset": {Type: graphql.Int, DefaultValue: 0},
+	}
+
+	company.AddFieldConfig("filings", &graphql.Field{
+		Type:        graphql.NewList(graphql.NewNonNull(sub)),
+		Description: "Submissions of the company, most recent first",
+		Args:        filingsArgs,
+		Resolve:     batch("filings", func(p graphql.ResolveParams) string { return p.Source.(Company).Cik }, fetchFilings),
+	})
+
+	bySub := func(p graphql.ResolveParams) string { return p.Source.(models.DataSUB).Adsh }
+	sub.AddFieldConfig("company", &graphql.Field{
+		Type:    company,
+		Resolve: batch("company", func(p graphql.ResolveParams) string { return p.Source.(models.DataSUB).Cik }, fetchCompanies),
+	})
+	sub.AddFieldConfig("nums", &graphql.Field{
+		Type:    graphql.NewList(graphql.NewNonNull(num)),
+		Args:    filterArgs,
+		Resolve: batch("nums", bySub, hasMany(&[]models.DataNUM{}, "Adsh")),
+	})
+	sub.AddFieldConfig("txts", &graphql.Field{
+		Type:    graphql.NewList(graphql.NewNonNull(txt)),
+		Args:    filterArgs,
+		Resolve: batch("txts", bySub, hasMany(&[]models.DataTXT{}, "Adsh")),
+	})
+	sub.AddFieldConfig("pres", &graphql.Field{
+		Type:    graphql.NewList(graphql.NewNonNull(pre)),
+		Args:    graphql.FieldConfigArgument{"stmt": {Type: graphql.String}, "report": {Type: graphql.Int}},
+		Resolve: batch("pres", bySub, hasMany(&[]models.DataPRE{}, "Adsh")),
+	})
+	sub.AddFieldConfig("rens", &graphql.Field{
+		Type:    graphql.NewList(graphql.NewNonNull(ren)),
+		Resolve: batch("rens", bySub, hasMany(&[]models.DataREN{}, "Adsh")),
+	})
+	sub.AddFieldConfig("cals", &graphql.Field{
+		Type:    graphql.NewList(graphql.NewNonNull(cal)),
+		Resolve: batch("cals", bySub, hasMany(&[]models.DataCAL{}, "Adsh")),
+	})
+
+	num.AddFieldConfig("submission", &graphql.Field{
+		Type:    sub,
+		Resolve: batch("submission", func(p graphql.ResolveParams) string { return p.Source.(models.DataNUM).Adsh }, fetchSubmissions),
+	})
+	num.AddFieldConfig("definition", &graphql.Field{
+		Type: tag,
+		Resolve: batch("definition", func(p graphql.ResolveParams) string {
+			n := p.Source.(models.DataNUM)
+			return tagKey(n.Tag, n.Version)
+		}, fetchTags),
+	})
+	num.AddFieldConfig("dimension", &graphql.Field{
+		Type:    dim,
+		Resolve: batch("dimension", func(p graphql.ResolveParams) string { return p.Source.(models.DataNUM).Dimh }, fetchDims),
+	})
+	txt.AddFieldConfig("submission", &graphql.Field{
+		Type:    sub,
+		Resolve: batch("submission", func(p graphql.ResolveParams) string { return p.Source.(models.DataTXT).Adsh }, fetchSubmissions),
+	})
+	txt.AddFieldConfig("definition", &graphql.Field{
+		Type: tag,
+		Resolve: batch("definition", func(p graphql.ResolveParams) string {
+			t := p.Source.(models.DataTXT)
+			return tagKey(t.Tag, t.Version)
+		}, fetchTags),
+	})
+	txt.AddFieldConfig("dimension", &graphql.Field{
+		Type:    dim,
+		Resolve: batch("dimension", func(p graphql.ResolveParams) string { return p.Source.(models.DataTXT).Dimh }, fetchDims),
+	})
+	pre.AddFieldConfig("definition", &graphql.Field{
+		Type: tag,
+		Resolve: batch("definition", func(p graphql.ResolveParams) string {
+			pr := p.Source.(models.DataPRE)
+			return tagKey(pr.Tag, pr.Version)
+		}, fetchTags),
+	})
+	cal.AddFieldConfig("parent", &graphql.Field{
+		Type: tag,
+		Resolve: batch("definition", func(p graphql.ResolveParams) string {
+			c := p.Source.(models.DataCAL)
+			return tagKey(c.Ptag, c.Pversion)
+		}, fetchTags),
+	})
+	cal.AddFieldConfig("child", &graphql.Field{
+		Type: tag,
+		Resolve: batch("definition", func(p graphql.ResolveParams) string {
+			c := p.Source.(models.DataCAL)
+			return tagKey(c.Ctag, c.Cversion)
+		}, fetchTags),
+	})
+
+	root := graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: graphql.Fields{
+		"company": {
+			Type:        company,
+			Description: "A company by CIK or ticker",
+			Args:        graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				ls := loadersOf(p.Context)
+				cik, err := query.ResolveCIK(ls.db, p.Args["id"].(string))
+				if err != nil {
+					return nil, err
+				}
+				return ls.get("company", nil, fetchCompanies).load(cik), nil
+			},
+		},
+		"submission": {
+			Type: sub,
+			Args: graphql.FieldConfigArgument{"adsh": {Type: graphql.NewNonNull(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return loadersOf(p.Context).get("submission", nil, fetchSubmissions).load(p.Args["adsh"].(string)), nil
+			},
+		},
+		"tag": {
+			Type:        graphql.NewList(graphql.NewNonNull(tag)),
+			Description: "The definitions of a tag, one per taxonomy version or custom extension",
+			Args:        graphql.FieldConfigArgument{"name": {Type: graphql.NewNonNull(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				tags := []models.DataTAG{}
+				err := loadersOf(p.Context).db.Where("tag = ?", p.Args["name"]).Order("version").Find(&tags).Error
+				return tags, err
+			},
+		},
+		"dimension": {
+			Type: dim,
+			Args: graphql.FieldConfigArgument{"dimh": {Type: graphql.NewNonNull(graphql.String)}},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return loadersOf(p.Context).get("dimension", nil, fetchDims).load(p.Args["dimh"].(string)), nil
+			},
+		},
+	}})
+	return graphql.NewSchema(graphql.SchemaConfig{Query: root})
+}
+
+// fetchFunc fetches the values of a batched field for keys, given the
+// arguments of the field
+type fetchFunc func(db *gorm.DB, args map[string]interface{}, keys []string) (map[string]interface{}, error)
+
+// batch resolves a field through the loader of the request for the field and
+// its arguments, key returning the key of the source object
+func batch(field string, key func(p graphql.ResolveParams) string, fetch fetchFunc) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return loadersOf(p.Context).get(field, p.Args, fetch).load(key(p)), nil
+	}
+}
+
+// hasMany fetches the rows of a table (rows pointing to a slice of its model)
+// belonging to the keys, field being the model field holding the key. The
+// arguments of the field filter the rows on the columns they're named after.
+func hasMany(rows interface{}, field string) fetchFunc {
+	return func(db *gorm.DB, args map[string]interface{}, keys []string) (map[string]interface{}, error) {
+		// a new slice per call, loaders running concurrently
+		dst := reflect.New(reflect.TypeOf(rows).Elem()).Interface()
+		tx := db.Model(dst)
+		for column, v := range args {
+			if _, ok := v.([]interface{}); ok {
+				if list := stringList(v); len(list) > 0 {
+					tx = tx.Where(column+" IN ?", list)
+				}
+			} else {
+				tx = tx.Where(column+" = ?", v)
+			}
+		}
+		if err := tx.Where(fieldName(field)+" IN ?", keys).Find(dst).Error; err != nil {
+			return nil, err
+		}
+		if txts, ok := dst.(*[]models.DataTXT); ok {
+			if err := query.TextValues(db, *txts); err != nil {
+				return nil, err
+			}
+		}
+		return group(dst, field, keys), nil
+	}
+}
+
+func fetchSubmissions(db *gorm.DB, args map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	groups, err := groupBy(db, &[]models.DataSUB{}, "adsh", "Adsh", keys)
+	if err != nil {
+		return nil, err
+	}
+	return first(groups), nil
+}
+
+func fetchFilings(db *gorm.DB, args map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	tx := db.Order("accepted DESC")
+	if forms := stringList(args["form"]); len(forms) > 0 {
+		tx = tx.Where("form IN ?", forms)
+	}
+	for _, column := range []string{"fy", "fp"} {
+		if v, ok := args[column]; ok {
+			tx = tx.Where(column+" = ?", v)
+		}
+	}
+	groups, err := groupBy(tx, &[]models.DataSUB{}, "cik", "Cik", keys)
+	if err != nil {
+		return nil, err
+	}
+	offset, _ := args["offset"].(int)
+	limit, _ := args["limit"].(int)
+	for cik, g := range groups {
+		subs := g.([]models.DataSUB)
+		start, end := offset, len(subs)
+		if start > end {
+			start = end
+		}
+		if limit > 0 && start+limit < end {
+			end = start + limit
+		}
+		groups[cik] = subs[start:end]
+	}
+	return groups, nil
+}
+
+func fetchCompanies(db *gorm.DB, args map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	groups, err := groupBy(db.Order("accepted DESC"), &[]models.DataSUB{}, "cik", "Cik", keys)
+	if err != nil {
+		return nil, err
+	}
+	tickers := []models.DataTicker{}
+	if err := db.Where("cik IN ?", keys).Order("ticker").Find(&tickers).Error; err != nil {
+		return nil, err
+	}
+	companies := map[string]interface{}{}
+	for cik, sub := range first(groups) {
+		s := sub.(models.DataSUB)
+		c := Company{Cik: cik, Name: s.Name, Sic: s.Sic, SicDescription: sic.Description(s.Sic), Tickers: []string{}}
+		for _, t := range tickers {
+			if t.CikString == cik {
+				c.Tickers = append(c.Tickers, t.Ticker)
+			}
+		}
+		companies[cik] = c
+	}
+	return companies, nil
+}
+
+func tagKey(tag string, version string) string {
+	return tag + "\n" + version
+}
+
+func fetchTags(db *gorm.DB, args map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	names := []string{}
+	for _, k := range keys {
+		names = append(names, strings.SplitN(k, "\n", 2)[0])
+	}
+	tags := []models.DataTAG{}
+	if err := db.Where("tag IN ?", unique(names)).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	results := map[string]interface{}{}
+	for _, t := range tags {
+		results[tagKey(t.Tag, t.Version)] = t
+	}
+	return results, nil
+}
+
+func fetchDims(db *gorm.DB, args map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	groups, err := groupBy(db, &[]models.DataDIM{}, "dimh", "Dimh", keys)
+	if err != nil {
+		return nil, err
+	}
+	return first(groups), nil
+}
@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shopspring/decimal"
+)
+
+var decimalType = reflect.TypeOf(decimal.Decimal{})
+
+// objectOf returns a GraphQL object type with a field per column of model,
+// named after the struct field with a lower case first letter. Decimals are
+// exposed as strings to keep their precision.
+func objectOf(name string, description string, model interface{}) *graphql.Object {
+	fields := graphql.Fields{}
+	t := reflect.TypeOf(model)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("gorm") == "-" {
+			continue
+		}
+		fields[fieldName(f.Name)] = &graphql.Field{Type: outputType(f.Type), Resolve: resolveStructField(i)}
+	}
+	return graphql.NewObject(graphql.ObjectConfig{Name: name, Description: description, Fields: fields})
+}
+
+func fieldName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// outputType maps a Go type to a GraphQL type, pointers being nullable
+func outputType(t reflect.Type) graphql.Output {
+	if t.Kind() == reflect.Ptr {
+		return nullableType(t.Elem())
+	}
+	return graphql.NewNonNull(nullableType(t))
+}
+
+func nullableType(t reflect.Type) graphql.Output {
+	if t == decimalType {
+		return graphql.String
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return graphql.Int
+	case reflect.Float32, reflect.Float64:
+		return graphql.Float
+	case reflect.Slice:
+		return graphql.NewList(outputType(t.Elem()))
+	}
+	return graphql.String
+}
+
+// resolveStructField resolves to the i-th field of the source struct
+func resolveStructField(i int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v := reflect.ValueOf(p.Source)
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		f := v.Field(i)
+		if f.Kind() == reflect.Ptr {
+			if f.IsNil() {
+				return nil, nil
+			}
+			f = f.Elem()
+		}
+		if d, ok := f.Interface().(decimal.Decimal); ok {
+			return d.String(), nil
+		}
+		return f.Interface(), nil
+	}
+}
+
+// stringList reads a [String] argument
+func stringList(v interface{}) []string {
+	list := []string{}
+	items, _ := v.([]interface{})
+	for _, item := range items {
+		if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
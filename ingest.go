@@ -0,0 +1,185 @@
+package filingsdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/sic"
+	"gorm.io/gorm"
+)
+
+// Ingest loads a data set archive into the database. archive is either the
+// path to a downloaded zip or its URL, e.g. one listed by ListArchives,
+// downloaded through the cache if the DB has one.
+func (db *DB) Ingest(ctx context.Context, archive string) error {
+	if !isURL(archive) {
+		return db.ExtractFromZip(ctx, archive)
+	}
+	src := &SECSource{Client: db.client, Cache: db.cache}
+	return db.IngestFrom(ctx, src, Archive{Name: path.Base(archive), URL: archive})
+}
+
+// ExtractFromZip loads the files of the data set archive zipfile into their tables
+func (db *DB) ExtractFromZip(ctx context.Context, zipfile string) error {
+	archive, err := models.OpenArchive(zipfile)
+	if err != nil {
+		return fmt.Errorf("%v: %w", zipfile, err)
+	}
+	defer archive.Close()
+
+	// the submissions decide which rows of the other files to keep
+	files := archive.Files()
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i] == "sub.tsv" && files[j] != "sub.tsv"
+	})
+	if db.filter != nil {
+		db.filter.reset()
+	}
+	dataset := archive.Dataset()
+	if dataset == models.DatasetStatements {
+		db.logger.Printf("Financial Statement data set: no dimensions, text facts, calculations nor reports")
+	}
+
+	tx := db.gorm.WithContext(ctx)
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if file == "txt.tsv" && db.textPolicy == TextSkip {
+			continue
+		}
+		db.logger.Printf("Contents of %s:", file)
+		rows, values := &batch{tx: tx}, &batch{tx: tx}
+		err := archive.Each(file, func(record interface{}) error {
+			if db.filter != nil && !db.filter.keep(record) {
+				return nil
+			}
+			if txt, ok := record.(models.DataTXT); ok {
+				txt, value, keep := db.text(txt)
+				if !keep {
+					return nil
+				}
+				if value != nil {
+					if err := values.add(*value); err != nil {
+						return err
+					}
+				}
+				record = txt
+			}
+			if sub, ok := record.(models.DataSUB); ok {
+				sub.Dataset = dataset
+				record = sub
+			}
+			return rows.add(record)
+		})
+		if err == nil {
+			err = rows.flush()
+		}
+		if err == nil {
+			err = values.flush()
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// batch accumulates records of one model and creates them BATCH_SIZE at a time
+type batch struct {
+	tx   *gorm.DB
+	rows reflect.Value
+}
+
+func (b *batch) add(record interface{}) error {
+	if !b.rows.IsValid() {
+		b.rows = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(record)), 0, BATCH_SIZE)
+	}
+	b.rows = reflect.Append(b.rows, reflect.ValueOf(record))
+	if b.rows.Len() >= BATCH_SIZE {
+		return b.flush()
+	}
+	return nil
+}
+
+// flush creates the records accumulated so far
+func (b *batch) flush() error {
+	if !b.rows.IsValid() || b.rows.Len() == 0 {
+		return nil
+	}
+	rows := reflect.New(b.rows.Type())
+	rows.Elem().Set(b.rows)
+	if err := b.tx.Create(rows.Interface()).Error; err != nil {
+		return err
+	}
+	b.rows = reflect.MakeSlice(b.rows.Type(), 0, BATCH_SIZE)
+	return nil
+}
+
+// IngestTickers loads the tickers published by sec.gov
+func (db *DB) IngestTickers(ctx context.Context) error {
+	tx := db.gorm.WithContext(ctx)
+	tickersList := models.DataTickers{}
+	// Get the data
+	resp, err := db.client.Get(ctx, db.client.BaseURL+"/files/company_tickers.json", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(body, &tickersList)
+	if err != nil {
+		return err
+	}
+	tickers := []models.DataTicker{}
+	for _, ticker := range tickersList {
+		ticker.CikString = strconv.Itoa(ticker.Cik)
+		tickers = append(tickers, ticker)
+		if len(tickers) > BATCH_SIZE {
+			if err := tx.Create(&tickers).Error; err != nil {
+				return err
+			}
+			tickers = []models.DataTicker{}
+		}
+	}
+	// last batch
+	return tx.Create(&tickers).Error
+}
+
+// LoadSicCodes fills the SIC reference table from the bundled codes
+func (db *DB) LoadSicCodes(ctx context.Context) error {
+	tx := db.gorm.WithContext(ctx)
+	codes := []string{}
+	for code := range sic.Codes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	sics := []models.DataSIC{}
+	for _, code := range codes {
+		group := sic.MajorGroup(code)
+		sics = append(sics, models.DataSIC{
+			Sic:                   code,
+			Description:           sic.Codes[code],
+			MajorGroup:            group,
+			MajorGroupDescription: sic.MajorGroups[group],
+		})
+		if len(sics) >= BATCH_SIZE {
+			if err := tx.Create(&sics).Error; err != nil {
+				return err
+			}
+			sics = []models.DataSIC{}
+		}
+	}
+	// last batch
+	return tx.Create(&sics).Error
+}
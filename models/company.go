@@ -0,0 +1,313 @@
+package models
+
+import (
+	"strings"
+)
+
+// Company is a registrant as EDGAR knows it today, loaded from
+// submissions.zip, unlike DataSUB which tells about it as of each filing
+type Company struct {
+	/**
+	Central Index Key (CIK), without leading zeros.
+	*/
+	Cik string `gorm:"index:idx_companies_cik"`
+
+	/**
+	Current name of the registrant.
+	*/
+	Name string
+
+	/**
+	Type of entity, e.g. operating, other or
+	investment.
+	*/
+	EntityType *string
+
+	/**
+	Standard Industrial Classification (SIC) code
+	and its description.
+	*/
+	Sic            *string `gorm:"index:idx_companies_sic"`
+	SicDescription *string
+
+	/**
+	Employee Identification Number.
+	*/
+	Ein *string
+
+	/**
+	Filer category, e.g. Large accelerated filer.
+	*/
+	Category *string
+
+	/**
+	Fiscal year end, MMDD.
+	*/
+	FiscalYearEnd *string
+
+	/**
+	State or country of incorporation, as a code
+	and its description.
+	*/
+	StateOfIncorporation            *string
+	StateOfIncorporationDescription *string
+
+	/**
+	Business address and phone number.
+	*/
+	BusinessStreet1        *string
+	BusinessStreet2        *string
+	BusinessCity           *string
+	BusinessStateOrCountry *string
+	BusinessZipCode        *string
+	Phone                  *string
+
+	/**
+	Web sites of the company and of its investor
+	relations.
+	*/
+	Website         *string
+	InvestorWebsite *string
+}
+
+// TableName of the companies
+func (Company) TableName() string {
+	return "companies"
+}
+
+// CompanyTicker is a ticker of a company and the exchange it's listed on
+type CompanyTicker struct {
+	Cik      string `gorm:"index:idx_company_tickers_cik"`
+	Ticker   string `gorm:"index:idx_company_tickers_ticker"`
+	Exchange *string
+}
+
+// CompanyFormerName is a name a company had before
+type CompanyFormerName struct {
+	Cik  string `gorm:"index:idx_company_former_names_cik"`
+	Name string
+
+	// Dates the name was used from and to, YYYY-MM-DD
+	From *string
+	To   *string
+}
+
+// CompanyFiling is an entry of the filing index of a company, XBRL or not:
+// every form filed on EDGAR since 2001
+type CompanyFiling struct {
+	Cik string `gorm:"index:idx_company_filings_cik"`
+
+	/**
+	Accession Number, the adsh of data_subs
+	for XBRL financial filings.
+	*/
+	Adsh string `gorm:"index:idx_company_filings_adsh"`
+
+	Form string `gorm:"index:idx_company_filings_form"`
+
+	/**
+	Date the form was filed and date of the period
+	it reports on, if any, YYYY-MM-DD.
+	*/
+	FilingDate string
+	ReportDate *string
+
+	/**
+	Acceptance date and time, e.g. 2019-01-30T16:05:44.000Z.
+	*/
+	AcceptanceDateTime string
+
+	/**
+	The securities act (33, 34...), file and film
+	numbers.
+	*/
+	Act        *string
+	FileNumber *string
+	FilmNumber *string
+
+	/**
+	Items of an 8-K, comma separated, e.g. 2.02,9.01.
+	*/
+	Items *string
+
+	/**
+	Size of the filing in bytes.
+	*/
+	Size int
+
+	/**
+	Whether the filing has XBRL financial data,
+	inline XBRL in particular.
+	*/
+	IsXBRL       bool
+	IsInlineXBRL bool
+
+	/**
+	The file name of the primary document and
+	its description.
+	*/
+	PrimaryDocument       *string
+	PrimaryDocDescription *string
+}
+
+// Submissions is a file of submissions.zip (CIK##########.json): the
+// metadata of a company and the index of its most recent filings, as
+// served by https://data.sec.gov/submissions/CIK##########.json
+type Submissions struct {
+	Cik                             string    `json:"cik"`
+	EntityType                      string    `json:"entityType"`
+	Sic                             string    `json:"sic"`
+	SicDescription                  string    `json:"sicDescription"`
+	Name                            string    `json:"name"`
+	Tickers                         []string  `json:"tickers"`
+	Exchanges                       []*string `json:"exchanges"`
+	Ein                             string    `json:"ein"`
+	Category                        string    `json:"category"`
+	FiscalYearEnd                   string    `json:"fiscalYearEnd"`
+	StateOfIncorporation            string    `json:"stateOfIncorporation"`
+	StateOfIncorporationDescription string    `json:"stateOfIncorporationDescription"`
+	Phone                           string    `json:"phone"`
+	Website                         string    `json:"website"`
+	InvestorWebsite                 string    `json:"investorWebsite"`
+	Addresses                       struct {
+		Business struct {
+			Street1        *string `json:"street1"`
+			Street2        *string `json:"street2"`
+			City           *string `json:"city"`
+			StateOrCountry *string `json:"stateOrCountry"`
+			ZipCode        *string `json:"zipCode"`
+		} `json:"business"`
+	} `json:"addresses"`
+	FormerNames []struct {
+		Name string `json:"name"`
+		From string `json:"from"`
+		To   string `json:"to"`
+	} `json:"formerNames"`
+	Filings struct {
+		Recent SubmissionsFilings `json:"recent"`
+
+		// The files of the older filings, e.g. CIK0000320193-submissions-001.json
+		Files []struct {
+			Name string `json:"name"`
+		} `json:"files"`
+	} `json:"filings"`
+}
+
+// SubmissionsFilings is a filing index of Submissions, by column, or of a
+// file of older filings of submissions.zip (CIK##########-submissions-001.json)
+type SubmissionsFilings struct {
+	AccessionNumber       []string `json:"accessionNumber"`
+	FilingDate            []string `json:"filingDate"`
+	ReportDate            []string `json:"reportDate"`
+	AcceptanceDateTime    []string `json:"acceptanceDateTime"`
+	Act                   []string `json:"act"`
+	Form                  []string `json:"form"`
+	FileNumber            []string `json:"fileNumber"`
+	FilmNumber            []string `json:"filmNumber"`
+	Items                 []string `json:"items"`
+	Size                  []int    `json:"size"`
+	IsXBRL                []int    `json:"isXBRL"`
+	IsInlineXBRL          []int    `json:"isInlineXBRL"`
+	PrimaryDocument       []string `json:"primaryDocument"`
+	PrimaryDocDescription []string `json:"primaryDocDescription"`
+}
+
+// Company returns the company of the submissions
+func (s Submissions) Company() Company {
+	b := s.Addresses.Business
+	return Company{
+		Cik:                             trimCik(s.Cik),
+		Name:                            s.Name,
+		EntityType:                      strOrNil(s.EntityType),
+		Sic:                             strOrNil(s.Sic),
+		SicDescription:                  strOrNil(s.SicDescription),
+		Ein:                             strOrNil(s.Ein),
+		Category:                        strOrNil(s.Category),
+		FiscalYearEnd:                   strOrNil(s.FiscalYearEnd),
+		StateOfIncorporation:            strOrNil(s.StateOfIncorporation),
+		StateOfIncorporationDescription: strOrNil(s.StateOfIncorporationDescription),
+		BusinessStreet1:                 b.Street1,
+		BusinessStreet2:                 b.Street2,
+		BusinessCity:                    b.City,
+		BusinessStateOrCountry:          b.StateOrCountry,
+		BusinessZipCode:                 b.ZipCode,
+		Phone:                           strOrNil(s.Phone),
+		Website:                         strOrNil(s.Website),
+		InvestorWebsite:                 strOrNil(s.InvestorWebsite),
+	}
+}
+
+// CompanyTickers returns the tickers of the submissions, with the exchange
+// listing them at the same index
+func (s Submissions) CompanyTickers() []CompanyTicker {
+	tickers := []CompanyTicker{}
+	for i, ticker := range s.Tickers {
+		t := CompanyTicker{Cik: trimCik(s.Cik), Ticker: ticker}
+		if i < len(s.Exchanges) {
+			t.Exchange = s.Exchanges[i]
+		}
+		tickers = append(tickers, t)
+	}
+	return tickers
+}
+
+// CompanyFormerNames returns the former names of the submissions
+func (s Submissions) CompanyFormerNames() []CompanyFormerName {
+	names := []CompanyFormerName{}
+	for _, n := range s.FormerNames {
+		names = append(names, CompanyFormerName{
+			Cik:  trimCik(s.Cik),
+			Name: n.Name,
+			From: strOrNil(date(n.From)),
+			To:   strOrNil(date(n.To)),
+		})
+	}
+	return names
+}
+
+// CompanyFilings returns the filings of the index of company cik
+func (f SubmissionsFilings) CompanyFilings(cik string) []CompanyFiling {
+	at := func(column []string, i int) string {
+		if i < len(column) {
+			return column[i]
+		}
+		return ""
+	}
+	filings := []CompanyFiling{}
+	for i, adsh := range f.AccessionNumber {
+		filing := CompanyFiling{
+			Cik:                   trimCik(cik),
+			Adsh:                  adsh,
+			Form:                  at(f.Form, i),
+			FilingDate:            at(f.FilingDate, i),
+			ReportDate:            strOrNil(at(f.ReportDate, i)),
+			AcceptanceDateTime:    at(f.AcceptanceDateTime, i),
+			Act:                   strOrNil(at(f.Act, i)),
+			FileNumber:            strOrNil(at(f.FileNumber, i)),
+			FilmNumber:            strOrNil(at(f.FilmNumber, i)),
+			Items:                 strOrNil(at(f.Items, i)),
+			PrimaryDocument:       strOrNil(at(f.PrimaryDocument, i)),
+			PrimaryDocDescription: strOrNil(at(f.PrimaryDocDescription, i)),
+		}
+		if i < len(f.Size) {
+			filing.Size = f.Size[i]
+		}
+		filing.IsXBRL = i < len(f.IsXBRL) && f.IsXBRL[i] == 1
+		filing.IsInlineXBRL = i < len(f.IsInlineXBRL) && f.IsInlineXBRL[i] == 1
+		filings = append(filings, filing)
+	}
+	return filings
+}
+
+func trimCik(cik string) string {
+	return strings.TrimLeft(cik, "0")
+}
+
+// date returns the date of a timestamp, e.g. 2007-01-04 for
+// 2007-01-04T05:00:00.000Z
+func date(timestamp string) string {
+	if i := strings.Index(timestamp, "T"); i >= 0 {
+		return timestamp[:i]
+	}
+	return timestamp
+}
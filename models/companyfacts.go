@@ -0,0 +1,146 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DatasetCompanyFacts is the DataSUB.Dataset of the submissions loaded from
+// companyfacts.zip, known only by the facts they reported
+const DatasetCompanyFacts = "companyfacts"
+
+// CompanyFacts is a file of companyfacts.zip (CIK##########.json): every
+// fact a company reported in the XBRL financial data of its filings, as
+// served by https://data.sec.gov/api/xbrl/companyfacts/CIK##########.json
+type CompanyFacts struct {
+	Cik        int    `json:"cik"`
+	EntityName string `json:"entityName"`
+
+	// Concepts by taxonomy (us-gaap, ifrs-full, dei...) and tag
+	Facts map[string]map[string]CompanyConcept `json:"facts"`
+}
+
+// CompanyConcept is a tag of CompanyFacts
+type CompanyConcept struct {
+	Label       *string `json:"label"`
+	Description *string `json:"description"`
+
+	// Facts by unit of measure (USD, shares, USD/shares...)
+	Units map[string][]CompanyFact `json:"units"`
+}
+
+// CompanyFact is a fact of CompanyConcept, reported by the filing accn
+type CompanyFact struct {
+	// Start date (YYYY-MM-DD) of a duration, empty for a point in time
+	Start string `json:"start"`
+	End   string `json:"end"`
+
+	Val  json.Number `json:"val"`
+	Accn string      `json:"accn"`
+
+	// Fiscal year and period focus, form and filing date of the filing
+	Fy    *int    `json:"fy"`
+	Fp    *string `json:"fp"`
+	Form  string  `json:"form"`
+	Filed string  `json:"filed"`
+
+	/**
+	The calendar period (CY2019, CY2019Q1, CY2019Q1I for an instant)
+	the SEC deems the fact the most representative of, across every
+	filing of the company: the frames API returns it. Empty otherwise.
+	*/
+	Frame string `json:"frame"`
+}
+
+// Num returns the fact as a fact of num.tsv, the end date being rounded to
+// the nearest month end and the duration to a count of quarters the way the
+// data sets do
+func (f CompanyFact) Num(taxonomy string, tag string, uom string) DataNUM {
+	num := DataNUM{
+		Adsh:    f.Accn,
+		Tag:     tag,
+		Version: taxonomy,
+		Uom:     uom,
+		Dimh:    DimHash(""),
+		Iprx:    1,
+		Dcml:    DcmlUnknown,
+	}
+	if v, err := decimal.NewFromString(f.Val.String()); err == nil {
+		num.Value = &v
+	}
+	end, err := time.Parse("2006-01-02", f.End)
+	if err != nil {
+		num.Ddate = strings.Replace(f.End, "-", "", -1)
+		return num
+	}
+	var startp *time.Time
+	if start, err := time.Parse("2006-01-02", f.Start); err == nil {
+		startp = &start
+	}
+	ddate, qtrs, durp, datp := RoundPeriod(startp, end)
+	num.Ddate, num.Qtrs, num.Durp, num.Datp = ddate, qtrs, &durp, &datp
+	return num
+}
+
+// RoundPeriod returns the period of a fact the way the data sets do: the
+// end date rounded to the nearest month end (YYYYMMDD) and the duration
+// from start, both dates included, to a count of quarters (0 for a point in
+// time, start being nil), with the differences of the fact to them (durp and
+// datp of num.tsv)
+func RoundPeriod(start *time.Time, end time.Time) (string, int, decimal.Decimal, decimal.Decimal) {
+	ddate, datp := roundToMonthEnd(end)
+	qtrs, durp := 0, decimal.Zero
+	if start != nil {
+		days := end.Sub(*start).Hours()/24 + 1
+		qtrs = int(math.Round(days / 91))
+		if qtrs > 0 {
+			durp = decimal.NewFromFloat((days - float64(qtrs)*91) / 91).Round(4)
+		}
+	}
+	return ddate.Format("20060102"), qtrs, durp, datp
+}
+
+// roundToMonthEnd returns the month end nearest to t, with the difference
+// between t and it as a fraction of the days of its month, e.g. -2/31 for
+// December 29th
+func roundToMonthEnd(t time.Time) (time.Time, decimal.Decimal) {
+	monthEnd := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	previous := time.Date(t.Year(), t.Month(), 0, 0, 0, 0, 0, time.UTC)
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	rounded := monthEnd
+	if t.Sub(previous) < monthEnd.Sub(t) {
+		rounded = previous
+	}
+	days := float64(rounded.Day())
+	return rounded, decimal.NewFromFloat(t.Sub(rounded).Hours() / 24 / days).Round(4)
+}
+
+// Sub returns the submission of the filing of the fact, as far as the facts
+// of companyfacts.zip tell: sic, addresses and the acceptance time are
+// unknown. The submission is considered accepted at 5:30pm the day it was
+// filed, the latest it can have been.
+func (f CompanyFact) Sub(company CompanyFacts) DataSUB {
+	filed := strings.Replace(f.Filed, "-", "", -1)
+	sub := DataSUB{
+		Adsh:     f.Accn,
+		Cik:      strconv.Itoa(company.Cik),
+		Name:     company.EntityName,
+		Form:     f.Form,
+		Filed:    filed,
+		Accepted: f.Filed + " 17:30:00.0",
+		Nciks:    1,
+		Dataset:  DatasetCompanyFacts,
+	}
+	if f.Fy != nil {
+		sub.Fy = strconv.Itoa(*f.Fy)
+	}
+	if f.Fp != nil {
+		sub.Fp = *f.Fp
+	}
+	return sub
+}
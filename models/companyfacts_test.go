@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundPeriod(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	tests := []struct {
+		start, end string
+		ddate      string
+		qtrs       int
+		durp, datp string
+	}{
+		{"", "2018-12-31", "20181231", 0, "0", "0"},
+		{"", "2018-12-29", "20181231", 0, "0", "-0.0645"},
+		{"", "2019-06-14", "20190531", 0, "0", "0.4516"},
+		{"", "2019-03-02", "20190228", 0, "0", "0.0714"},
+		{"2018-01-01", "2018-12-31", "20181231", 4, "0.011", "0"},
+		{"2017-12-31", "2018-12-29", "20181231", 4, "0", "-0.0645"},
+		{"2019-01-01", "2019-03-30", "20190331", 1, "-0.022", "-0.0323"},
+		{"2018-07-01", "2019-03-31", "20190331", 3, "0.011", "0"},
+	}
+	for _, test := range tests {
+		var start *time.Time
+		if test.start != "" {
+			s := date(test.start)
+			start = &s
+		}
+		ddate, qtrs, durp, datp := RoundPeriod(start, date(test.end))
+		if ddate != test.ddate || qtrs != test.qtrs || durp.String() != test.durp || datp.String() != test.datp {
+			t.Errorf("RoundPeriod(%s, %s) = %s %d %s %s, expected %s %d %s %s", test.start, test.end,
+				ddate, qtrs, durp, datp, test.ddate, test.qtrs, test.durp, test.datp)
+		}
+	}
+}
+
+func TestCompanyFactDcml(t *testing.T) {
+	fact := CompanyFact{Accn: "0001326801-19-000009", End: "2018-12-31", Start: "2018-01-01", Val: "55838000000"}
+	if num := fact.Num("us-gaap", "Revenues", "USD"); num.Dcml != DcmlUnknown {
+		t.Errorf("dcml %d, expected DcmlUnknown", num.Dcml)
+	}
+}
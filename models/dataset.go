@@ -0,0 +1,103 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The variants of the data sets published by the SEC, recorded in
+// DataSUB.Dataset
+const (
+	/**
+	Financial Statement and Notes data sets, e.g. 2019q1_notes.zip:
+	every file, facts of the notes and dimensional facts included.
+	*/
+	DatasetNotes = "notes"
+
+	/**
+	Financial Statement data sets, e.g. 2019q1.zip: sub.tsv, tag.tsv,
+	num.tsv and pre.tsv only, with the facts of the face financials.
+	Published since 2009q1, they are much smaller.
+	*/
+	DatasetStatements = "statements"
+
+	/**
+	Not a data set: a filing parsed from its XBRL or inline XBRL
+	instance and taxonomy extension, before the data sets publish it.
+	*/
+	DatasetXBRL = "xbrl"
+)
+
+// columns are the columns of the files of the notes data sets, in the order
+// their parsers read them. Files with other columns are mapped by name.
+var columns = map[string][]string{
+	"sub.tsv": {"adsh", "cik", "name", "sic", "countryba", "stprba", "cityba", "zipba", "bas1", "bas2", "baph", "countryma", "stprma", "cityma", "zipma", "mas1", "mas2", "countryinc", "stprinc", "ein", "former", "changed", "afs", "wksi", "fye", "form", "period", "fy", "fp", "filed", "accepted", "prevrpt", "detail", "instance", "nciks", "aciks", "pubfloatusd", "floatdate", "floataxis", "floatmems"},
+	"tag.tsv": {"tag", "version", "custom", "abstract", "datatype", "iord", "crdr", "tlabel", "doc"},
+	"dim.tsv": {"dimh", "segments", "segt"},
+	"num.tsv": {"adsh", "tag", "version", "ddate", "qtrs", "uom", "dimh", "iprx", "value", "footnote", "footlen", "dimn", "coreg", "durp", "datp", "dcml"},
+	"txt.tsv": {"adsh", "tag", "version", "ddate", "qtrs", "iprx", "lang", "dcml", "durp", "datp", "dimh", "dimn", "coreg", "escaped", "srclen", "txtlen", "footnote", "footlen", "context", "value"},
+	"ren.tsv": {"adsh", "report", "rfile", "menucat", "shortname", "longname", "roleuri", "parentroleuri", "parentreport", "ultparentrpt"},
+	"pre.tsv": {"adsh", "report", "line", "stmt", "inpth", "tag", "version", "prole", "plabel", "negating"},
+	"cal.tsv": {"adsh", "grp", "arc", "negative", "ptag", "pversion", "ctag", "cversion"},
+}
+
+// fillers complete the fields of a record missing from a file, given the
+// fields by column name. The statements data sets have a single fact per
+// key without dimensions, or the dimensions as segments text in the
+// recent ones: they get the dimh and priority of the notes data sets so
+// that the facts of both variants are queried alike.
+var fillers = map[string]func(fields map[string]string){
+	"num.tsv": func(fields map[string]string) {
+		if _, ok := fields["dimh"]; !ok {
+			fields["dimh"] = DimHash(fields["segments"])
+		}
+		if _, ok := fields["dimn"]; !ok {
+			fields["dimn"] = strconv.Itoa(strings.Count(fields["segments"], ";"))
+		}
+		if _, ok := fields["iprx"]; !ok {
+			fields["iprx"] = "1"
+		}
+	},
+}
+
+// remapper reorders the fields of a file with other columns than the notes
+// data sets into the order of its parser
+type remapper struct {
+	file   string
+	header []string
+}
+
+// newRemapper returns the remapper of a file with header, nil if its
+// columns are those of the notes data sets
+func newRemapper(file string, header []string) *remapper {
+	if strings.Join(header, "\t") == strings.Join(columns[file], "\t") {
+		return nil
+	}
+	return &remapper{file: file, header: header}
+}
+
+func (m *remapper) remap(tokens []string) []string {
+	fields := make(map[string]string, len(m.header))
+	for i, name := range m.header {
+		fields[name] = tokens[i]
+	}
+	if fill := fillers[m.file]; fill != nil {
+		fill(fields)
+	}
+	remapped := make([]string, len(columns[m.file]))
+	for i, name := range columns[m.file] {
+		remapped[i] = fields[name]
+	}
+	return remapped
+}
+
+// Dataset returns the variant of the data sets of the archive, the notes
+// ones having dimensions and text facts
+func (a *Archive) Dataset() string {
+	for _, f := range a.files {
+		if f.Name == "dim.tsv" || f.Name == "txt.tsv" {
+			return DatasetNotes
+		}
+	}
+	return DatasetStatements
+}
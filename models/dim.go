@@ -1,5 +1,20 @@
 package models
 
+import (
+	"crypto/md5"
+	"encoding/hex"
+)
+
+// DimHash returns the dimh of segments, the key of DataDIM: 0x00000000
+// without segments, or the hexadecimal MD5 of the segments text
+func DimHash(segments string) string {
+	if segments == "" {
+		return "0x00000000"
+	}
+	sum := md5.Sum([]byte(segments))
+	return "0x" + hex.EncodeToString(sum[:])
+}
+
 // DataTAG is a Tag
 func ParseDataDIM(tokens []string) DataDIM {
 	dim := DataDIM{}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// DataFRAME is a fact of a frame: the value of a tag the SEC deems the most
+// representative of a calendar period for a company, across its filings,
+// as returned by https://data.sec.gov/api/xbrl/frames/. Loaded from
+// companyfacts.zip, the value is also among the facts of data_nums.
+type DataFRAME struct {
+
+	/**
+	The calendar period: CY2019 for a year, CY2019Q1 for a
+	quarter and CY2019Q1I for a point in time.
+	*/
+	Frame string `gorm:"index:idx_frames_key"`
+
+	// The tag and its taxonomy (us-gaap, ifrs-full, dei...)
+	Tag     string `gorm:"index:idx_frames_key"`
+	Version string
+
+	Uom string `gorm:"index:idx_frames_key"`
+
+	Cik string `gorm:"index:idx_frames_cik"`
+
+	// The submission the value was reported by
+	Adsh string
+
+	// The end date and duration of the fact, as in data_nums
+	Ddate string
+	Qtrs  int
+
+	Value *decimal.Decimal `sql:"type:decimal(20,8);"`
+}
+
+// TableName of the frames, gorm reading RAM as an initialism in FRAME
+func (DataFRAME) TableName() string {
+	return "data_frames"
+}
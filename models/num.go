@@ -4,6 +4,16 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Dcml values that aren't a decimals attribute
+const (
+	// DcmlINF is the Dcml of the facts with decimals="INF"
+	DcmlINF = 32767
+
+	// DcmlUnknown is the Dcml of the facts whose decimals aren't known,
+	// those of companyfacts.zip
+	DcmlUnknown = -32768
+)
+
 // DataNUM is a Number
 func ParseDataNUM(tokens []string) DataNUM {
 	num := DataNUM{}
@@ -139,7 +149,9 @@ type DataNUM struct {
 
 	/**
 	The value of the fact "decimals" attribute,
-	with INF represented by 32767.
+	with INF represented by 32767 (DcmlINF). The
+	facts of companyfacts.zip, which doesn't tell it,
+	get DcmlUnknown (-32768), -1 meaning rounded to tens.
 	*/
 	Dcml int
 }
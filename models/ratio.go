@@ -0,0 +1,53 @@
+package models
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Ratio is a financial ratio computed from the facts of a submission
+type Ratio struct {
+	/**
+	Accession Number of the submission the ratio
+	was computed for.
+	*/
+	Adsh string `gorm:"index:idx_ratios_adsh"`
+
+	/**
+	Central Index Key of the registrant.
+	*/
+	Cik string `gorm:"index:idx_ratios_cik"`
+
+	/**
+	Name of the ratio, e.g. ROE or CurrentRatio.
+	*/
+	Name string `gorm:"index:idx_ratios_name"`
+
+	/**
+	The end date of the period the ratio applies
+	to, i.e. the period of the submission.
+	*/
+	Ddate string
+
+	/**
+	The count of quarters the duration inputs of
+	the ratio span. 0 if all inputs are point in time.
+	*/
+	Qtrs int
+
+	/**
+	The value of the ratio, NULL if an input is
+	missing or the denominator is zero.
+	*/
+	Value *decimal.Decimal `sql:"type:decimal(20,8);"`
+
+	/**
+	The inputs that could not be found, as a comma
+	separated list of concept@ddate, if any.
+	*/
+	Missing *string
+}
+
+// TableName of the materialized ratios
+func (Ratio) TableName() string {
+	return "ratios"
+}
@@ -0,0 +1,254 @@
+package models
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parsers map the files of a data set to the parser of their lines
+var parsers = map[string]func(tokens []string) interface{}{
+	"sub.tsv": func(tokens []string) interface{} { return ParseDataSUB(tokens) },
+	"tag.tsv": func(tokens []string) interface{} { return ParseDataTAG(tokens) },
+	"dim.tsv": func(tokens []string) interface{} { return ParseDataDIM(tokens) },
+	"num.tsv": func(tokens []string) interface{} { return ParseDataNUM(tokens) },
+	"txt.tsv": func(tokens []string) interface{} { return ParseDataTXT(tokens) },
+	"ren.tsv": func(tokens []string) interface{} { return ParseDataREN(tokens) },
+	"pre.tsv": func(tokens []string) interface{} { return ParseDataPRE(tokens) },
+	"cal.tsv": func(tokens []string) interface{} { return ParseDataCAL(tokens) },
+}
+
+// IsDataFile reports whether file (e.g. num.tsv) is a file of the data sets
+// the models can be read from
+func IsDataFile(file string) bool {
+	_, ok := parsers[file]
+	return ok
+}
+
+// TSVReader reads the records of a file of a data set one line at a time,
+// bufio.Scanner style:
+//
+//	rd, err := models.NewTSVReader("num.tsv", r)
+//	for rd.Next() {
+//		num := rd.Record().(models.DataNUM)
+//	}
+//	err = rd.Err()
+type TSVReader struct {
+	file   string
+	parse  func(tokens []string) interface{}
+	rd     *bufio.Reader
+	header []string
+	line   int
+	remap  *remapper
+	record interface{}
+	err    error
+	closer io.Closer
+}
+
+// NewTSVReader returns a reader of the records of the data set file (sub.tsv,
+// num.tsv, txt.tsv...) read from r. Columns are matched by name: files of
+// the statements data sets, with fewer columns, read into the same records,
+// the columns they lack being read as empty.
+func NewTSVReader(file string, r io.Reader) (*TSVReader, error) {
+	parse, ok := parsers[file]
+	if !ok {
+		return nil, fmt.Errorf("unknown data set file %s", file)
+	}
+	rd := &TSVReader{file: file, parse: parse, rd: bufio.NewReader(r)}
+	header, err := rd.readLine()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if header != "" {
+		rd.header = strings.Split(header, "\t")
+		rd.remap = newRemapper(file, rd.header)
+	}
+	return rd, nil
+}
+
+// File returns the name of the file read
+func (r *TSVReader) File() string {
+	return r.file
+}
+
+// Header returns the column names of the file
+func (r *TSVReader) Header() []string {
+	return r.header
+}
+
+// Next reads the next record, returning false at the end of the file or on error
+func (r *TSVReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	line, err := r.readLine()
+	for line == "" {
+		if err != nil {
+			if err != io.EOF {
+				r.err = err
+			}
+			return false
+		}
+		line, err = r.readLine()
+	}
+	tokens := strings.Split(line, "\t")
+	if len(tokens) != len(r.header) {
+		r.err = fmt.Errorf("%s line %d: expected %d fields, got %d", r.file, r.line, len(r.header), len(tokens))
+		return false
+	}
+	if r.remap != nil {
+		tokens = r.remap.remap(tokens)
+	}
+	r.record = r.parse(tokens)
+	return true
+}
+
+// Record returns the last record read: a DataSUB, DataNUM, DataTXT... value
+// depending on the file
+func (r *TSVReader) Record() interface{} {
+	return r.record
+}
+
+// Err returns the first error met, nil at the end of the file
+func (r *TSVReader) Err() error {
+	return r.err
+}
+
+// Close closes the file if the reader was opened from an Archive
+func (r *TSVReader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// readLine reads a line without its line terminator, the last line of the
+// file being returned along with io.EOF
+func (r *TSVReader) readLine() (string, error) {
+	line, err := r.rd.ReadString('\n')
+	r.line++
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// Archive is a data set zip, e.g. 2019q1_notes.zip
+type Archive struct {
+	closer io.Closer
+	files  []*zip.File
+}
+
+// OpenArchive opens the data set zip at path
+func OpenArchive(path string) (*Archive, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Archive{closer: zr, files: zr.File}, nil
+}
+
+// NewArchive reads a data set zip of size bytes from r
+func NewArchive(r io.ReaderAt, size int64) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return &Archive{files: zr.File}, nil
+}
+
+// Close closes the archive
+func (a *Archive) Close() error {
+	if a.closer == nil {
+		return nil
+	}
+	return a.closer.Close()
+}
+
+// Files returns the data set files of the archive, in archive order
+func (a *Archive) Files() []string {
+	files := []string{}
+	for _, f := range a.files {
+		if IsDataFile(f.Name) {
+			files = append(files, f.Name)
+		}
+	}
+	return files
+}
+
+// Open returns a reader of the data set file of the archive, e.g. num.tsv.
+// The caller must close it once done.
+func (a *Archive) Open(file string) (*TSVReader, error) {
+	for _, f := range a.files {
+		if f.Name != file {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		rd, err := NewTSVReader(file, rc)
+		if err != nil {
+			rc.Close()
+			return nil, err
+		}
+		rd.closer = rc
+		return rd, nil
+	}
+	return nil, fmt.Errorf("no %s in archive", file)
+}
+
+// Each calls fn with every record of file, stopping at the first error fn
+// returns
+func (a *Archive) Each(file string, fn func(record interface{}) error) error {
+	rd, err := a.Open(file)
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+	for rd.Next() {
+		if err := fn(rd.Record()); err != nil {
+			return err
+		}
+	}
+	return rd.Err()
+}
+
+// Subs calls fn with every submission of sub.tsv
+func (a *Archive) Subs(fn func(DataSUB) error) error {
+	return a.Each("sub.tsv", func(record interface{}) error { return fn(record.(DataSUB)) })
+}
+
+// Tags calls fn with every tag of tag.tsv
+func (a *Archive) Tags(fn func(DataTAG) error) error {
+	return a.Each("tag.tsv", func(record interface{}) error { return fn(record.(DataTAG)) })
+}
+
+// Dims calls fn with every dimension of dim.tsv
+func (a *Archive) Dims(fn func(DataDIM) error) error {
+	return a.Each("dim.tsv", func(record interface{}) error { return fn(record.(DataDIM)) })
+}
+
+// Nums calls fn with every numeric fact of num.tsv
+func (a *Archive) Nums(fn func(DataNUM) error) error {
+	return a.Each("num.tsv", func(record interface{}) error { return fn(record.(DataNUM)) })
+}
+
+// Txts calls fn with every text fact of txt.tsv
+func (a *Archive) Txts(fn func(DataTXT) error) error {
+	return a.Each("txt.tsv", func(record interface{}) error { return fn(record.(DataTXT)) })
+}
+
+// Rens calls fn with every report of ren.tsv
+func (a *Archive) Rens(fn func(DataREN) error) error {
+	return a.Each("ren.tsv", func(record interface{}) error { return fn(record.(DataREN)) })
+}
+
+// Pres calls fn with every presentation line of pre.tsv
+func (a *Archive) Pres(fn func(DataPRE) error) error {
+	return a.Each("pre.tsv", func(record interface{}) error { return fn(record.(DataPRE)) })
+}
+
+// Cals calls fn with every calculation arc of cal.tsv
+func (a *Archive) Cals(fn func(DataCAL) error) error {
+	return a.Each("cal.tsv", func(record interface{}) error { return fn(record.(DataCAL)) })
+}
@@ -0,0 +1,26 @@
+package models
+
+// DataSIC is an entry of the Standard Industrial Classification reference
+// table bundled with filingsdb, to be joined with data_subs.sic
+type DataSIC struct {
+	/**
+	Four digit Standard Industrial Classification code.
+	*/
+	Sic string `gorm:"index:idx_sics_sic"`
+
+	/**
+	Industry title as listed by the Commission.
+	*/
+	Description string
+
+	/**
+	The two leading digits of the code, grouping
+	related industries.
+	*/
+	MajorGroup string
+
+	/**
+	Title of the major group.
+	*/
+	MajorGroupDescription string
+}
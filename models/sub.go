@@ -318,4 +318,12 @@ type DataSUB struct {
 	terms in the summation.
 	*/
 	Floatmems *int
+
+	/**
+	The data sets the submission was loaded from:
+	DatasetNotes, DatasetStatements, DatasetCompanyFacts
+	or DatasetXBRL.
+	Not a column of sub.tsv.
+	*/
+	Dataset string `gorm:"index:idx_subs_dataset"`
 }
@@ -0,0 +1,84 @@
+package models
+
+// Statuses of a TagMapping
+const (
+	MappingSuggested = "suggested"
+	MappingAccepted  = "accepted"
+	MappingRejected  = "rejected"
+)
+
+// TagMapping is the standard tag a custom tag of a submission is closest to,
+// as suggested by the analysis of the custom tags and possibly reviewed
+type TagMapping struct {
+	/**
+	Accession Number of the submission defining
+	the custom tag.
+	*/
+	Adsh string `gorm:"index:idx_tag_mappings_adsh"`
+
+	/**
+	Central Index Key of the registrant.
+	*/
+	Cik string `gorm:"index:idx_tag_mappings_cik"`
+
+	/**
+	The custom tag, whose version is adsh.
+	*/
+	Tag string
+
+	/**
+	The label of the custom tag.
+	*/
+	Tlabel *string
+
+	/**
+	The statement the custom tag is presented on
+	(BS, IS, CF, EQ, CI, CP or UN), empty if none.
+	*/
+	Stmt string
+
+	/**
+	The standard tag suggested, or accepted by a
+	reviewer, and its version. NULL if none.
+	*/
+	Mapped        *string
+	MappedVersion *string
+
+	/**
+	How close the tags are, from 0 to 1: a weighted
+	average of the calculation, presentation and
+	label scores, those of a custom tag without
+	calculations or presentation left out.
+	*/
+	Score float64
+
+	/**
+	Share of the calculation arcs of the custom tag
+	(to its parents and children) the standard tag
+	has with the same tags in other submissions.
+	*/
+	CalcScore float64
+
+	/**
+	How often the standard tag is presented on the
+	same statement and next to the same lines in
+	other submissions.
+	*/
+	PresScore float64
+
+	/**
+	Similarity of the words of the labels.
+	*/
+	LabelScore float64
+
+	/**
+	suggested, accepted or rejected. The mappings
+	reviewed are kept when suggesting again.
+	*/
+	Status string
+}
+
+// TableName of the mappings of custom tags
+func (TagMapping) TableName() string {
+	return "tag_mappings"
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"github.com/klauspost/compress/zstd"
+)
+
+// DataTXTValue is the zstd compressed value of a text fact, stored apart
+// from data_txts when ingesting with the compressed text policy. The fact
+// is identified by the primary key of txt.tsv.
+type DataTXTValue struct {
+	Adsh    string `gorm:"index:idx_txt_values_key"`
+	Tag     string `gorm:"index:idx_txt_values_key"`
+	Version string
+	Ddate   string
+	Qtrs    int
+	Iprx    int
+	Dimh    string
+
+	/**
+	The zstd compressed value of the fact.
+	*/
+	Value []byte
+}
+
+var (
+	encoder, _ = zstd.NewWriter(nil)
+	decoder, _ = zstd.NewReader(nil)
+)
+
+// CompressValue moves the value of txt to a DataTXTValue, returning the fact
+// without its value
+func CompressValue(txt DataTXT) (DataTXT, DataTXTValue) {
+	value := DataTXTValue{
+		Adsh:    txt.Adsh,
+		Tag:     txt.Tag,
+		Version: txt.Version,
+		Ddate:   txt.Ddate,
+		Qtrs:    txt.Qtrs,
+		Iprx:    txt.Iprx,
+		Dimh:    txt.Dimh,
+	}
+	if txt.Value != nil {
+		value.Value = encoder.EncodeAll([]byte(*txt.Value), nil)
+	}
+	txt.Value = nil
+	return txt, value
+}
+
+// Matches reports whether v is the value of txt
+func (v DataTXTValue) Matches(txt DataTXT) bool {
+	return v.Adsh == txt.Adsh && v.Tag == txt.Tag && v.Version == txt.Version &&
+		v.Ddate == txt.Ddate && v.Qtrs == txt.Qtrs && v.Iprx == txt.Iprx && v.Dimh == txt.Dimh
+}
+
+// Decompress returns the uncompressed value, nil if the fact has none
+func (v DataTXTValue) Decompress() (*string, error) {
+	if v.Value == nil {
+		return nil, nil
+	}
+	b, err := decoder.DecodeAll(v.Value, nil)
+	if err != nil {
+		return nil, err
+	}
+	s := string(b)
+	return &s, nil
+}
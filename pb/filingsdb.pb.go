@@ -0,0 +1,1520 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.13.0
+// source: filingsdb.proto
+
+package pb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type GetSubmissionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Adsh string `protobuf:"bytes,1,opt,name=adsh,proto3" json:"adsh,omitempty"`
+}
+
+func (x *GetSubmissionRequest) Reset() {
+	*x = GetSubmissionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filingsdb_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubmissionRequest) ProtoMessage() {}
+
+func (x *GetSubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filingsdb_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubmissionRequest.ProtoReflect.Descriptor instead.
+func (*GetSubmissionRequest) Descriptor() ([]byte, []int) {
+	return file_filingsdb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetSubmissionRequest) GetAdsh() string {
+	if x != nil {
+		return x.Adsh
+	}
+	return ""
+}
+
+type ListSubmissionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// CIK or ticker of the registrant, every registrant if empty.
+	Company string   `protobuf:"bytes,1,opt,name=company,proto3" json:"company,omitempty"`
+	Forms   []string `protobuf:"bytes,2,rep,name=forms,proto3" json:"forms,omitempty"`
+	Fy      string   `protobuf:"bytes,3,opt,name=fy,proto3" json:"fy,omitempty"`
+	Fp      string   `protobuf:"bytes,4,opt,name=fp,proto3" json:"fp,omitempty"`
+	Sic     string   `protobuf:"bytes,5,opt,name=sic,proto3" json:"sic,omitempty"`
+}
+
+func (x *ListSubmissionsRequest) Reset() {
+	*x = ListSubmissionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filingsdb_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSubmissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubmissionsRequest) ProtoMessage() {}
+
+func (x *ListSubmissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filingsdb_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubmissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubmissionsRequest) Descriptor() ([]byte, []int) {
+	return file_filingsdb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListSubmissionsRequest) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *ListSubmissionsRequest) GetForms() []string {
+	if x != nil {
+		return x.Forms
+	}
+	return nil
+}
+
+func (x *ListSubmissionsRequest) GetFy() string {
+	if x != nil {
+		return x.Fy
+	}
+	return ""
+}
+
+func (x *ListSubmissionsRequest) GetFp() string {
+	if x != nil {
+		return x.Fp
+	}
+	return ""
+}
+
+func (x *ListSubmissionsRequest) GetSic() string {
+	if x != nil {
+		return x.Sic
+	}
+	return ""
+}
+
+// FactQuery selects facts. Empty fields don't filter.
+type FactQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Submissions the facts were reported in.
+	Adsh []string `protobuf:"bytes,2,rep,name=adsh,proto3" json:"adsh,omitempty"`
+	// CIK or ticker of the registrant.
+	Company string   `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	Forms   []string `protobuf:"bytes,4,rep,name=forms,proto3" json:"forms,omitempty"`
+	// Only facts whose ddate (yyyymmdd) is within [ddate_from, ddate_to].
+	DdateFrom string `protobuf:"bytes,5,opt,name=ddate_from,json=ddateFrom,proto3" json:"ddate_from,omitempty"`
+	DdateTo   string `protobuf:"bytes,6,opt,name=ddate_to,json=ddateTo,proto3" json:"ddate_to,omitempty"`
+	// Durations of the facts in quarters, 0 for point in time facts.
+	Qtrs  []int32  `protobuf:"varint,7,rep,packed,name=qtrs,proto3" json:"qtrs,omitempty"`
+	Uoms  []string `protobuf:"bytes,8,rep,name=uoms,proto3" json:"uoms,omitempty"`
+	Dimhs []string `protobuf:"bytes,9,rep,name=dimhs,proto3" json:"dimhs,omitempty"`
+	// Only the fact with the highest priority (iprx = 1) of each key.
+	PrimaryOnly bool `protobuf:"varint,10,opt,name=primary_only,json=primaryOnly,proto3" json:"primary_only,omitempty"`
+}
+
+func (x *FactQuery) Reset() {
+	*x = FactQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filingsdb_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FactQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactQuery) ProtoMessage() {}
+
+func (x *FactQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_filingsdb_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FactQuery.ProtoReflect.Descriptor instead.
+func (*FactQuery) Descriptor() ([]byte, []int) {
+	return file_filingsdb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FactQuery) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *FactQuery) GetAdsh() []string {
+	if x != nil {
+		return x.Adsh
+	}
+	return nil
+}
+
+func (x *FactQuery) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *FactQuery) GetForms() []string {
+	if x != nil {
+		return x.Forms
+	}
+	return nil
+}
+
+func (x *FactQuery) GetDdateFrom() string {
+	if x != nil {
+		return x.DdateFrom
+	}
+	return ""
+}
+
+func (x *FactQuery) GetDdateTo() string {
+	if x != nil {
+		return x.DdateTo
+	}
+	return ""
+}
+
+func (x *FactQuery) GetQtrs() []int32 {
+	if x != nil {
+		return x.Qtrs
+	}
+	return nil
+}
+
+func (x *FactQuery) GetUoms() []string {
+	if x != nil {
+		return x.Uoms
+	}
+	return nil
+}
+
+func (x *FactQuery) GetDimhs() []string {
+	if x != nil {
+		return x.Dimhs
+	}
+	return nil
+}
+
+func (x *FactQuery) GetPrimaryOnly() bool {
+	if x != nil {
+		return x.PrimaryOnly
+	}
+	return false
+}
+
+type GetDimensionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dimhs []string `protobuf:"bytes,1,rep,name=dimhs,proto3" json:"dimhs,omitempty"`
+}
+
+func (x *GetDimensionsRequest) Reset() {
+	*x = GetDimensionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filingsdb_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDimensionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDimensionsRequest) ProtoMessage() {}
+
+func (x *GetDimensionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filingsdb_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDimensionsRequest.ProtoReflect.Descriptor instead.
+func (*GetDimensionsRequest) Descriptor() ([]byte, []int) {
+	return file_filingsdb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetDimensionsRequest) GetDimhs() []string {
+	if x != nil {
+		return x.Dimhs
+	}
+	return nil
+}
+
+type GetDimensionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dimensions []*Dimension `protobuf:"bytes,1,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+}
+
+func (x *GetDimensionsResponse) Reset() {
+	*x = GetDimensionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filingsdb_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDimensionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDimensionsResponse) ProtoMessage() {}
+
+func (x *GetDimensionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_filingsdb_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDimensionsResponse.ProtoReflect.Descriptor instead.
+func (*GetDimensionsResponse) Descriptor() ([]byte, []int) {
+	return file_filingsdb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetDimensionsResponse) GetDimensions() []*Dimension {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+type Submission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Adsh        string `protobuf:"bytes,1,opt,name=adsh,proto3" json:"adsh,omitempty"`
+	Cik         string `protobuf:"bytes,2,opt,name=cik,proto3" json:"cik,omitempty"`
+	Name        string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Sic         string `protobuf:"bytes,4,opt,name=sic,proto3" json:"sic,omitempty"`
+	Countryba   string `protobuf:"bytes,5,opt,name=countryba,proto3" json:"countryba,omitempty"`
+	Stprba      string `protobuf:"bytes,6,opt,name=stprba,proto3" json:"stprba,omitempty"`
+	Cityba      string `protobuf:"bytes,7,opt,name=cityba,proto3" json:"cityba,omitempty"`
+	Zipba       string `protobuf:"bytes,8,opt,name=zipba,proto3" json:"zipba,omitempty"`
+	Bas1        string `protobuf:"bytes,9,opt,name=bas1,proto3" json:"bas1,omitempty"`
+	Bas2        string `protobuf:"bytes,10,opt,name=bas2,proto3" json:"bas2,omitempty"`
+	Baph        string `protobuf:"bytes,11,opt,name=baph,proto3" json:"baph,omitempty"`
+	Countryma   string `protobuf:"bytes,12,opt,name=countryma,proto3" json:"countryma,omitempty"`
+	Stprma      string `protobuf:"bytes,13,opt,name=stprma,proto3" json:"stprma,omitempty"`
+	Cityma      string `protobuf:"bytes,14,opt,name=cityma,proto3" json:"cityma,omitempty"`
+	Zipma       string `protobuf:"bytes,15,opt,name=zipma,proto3" json:"zipma,omitempty"`
+	Mas1        string `protobuf:"bytes,16,opt,name=mas1,proto3" json:"mas1,omitempty"`
+	Mas2        string `protobuf:"bytes,17,opt,name=mas2,proto3" json:"mas2,omitempty"`
+	Countryinc  string `protobuf:"bytes,18,opt,name=countryinc,proto3" json:"countryinc,omitempty"`
+	Stprinc     string `protobuf:"bytes,19,opt,name=stprinc,proto3" json:"stprinc,omitempty"`
+	Ein         string `protobuf:"bytes,20,opt,name=ein,proto3" json:"ein,omitempty"`
+	Former      string `protobuf:"bytes,21,opt,name=former,proto3" json:"former,omitempty"`
+	Changed     string `protobuf:"bytes,22,opt,name=changed,proto3" json:"changed,omitempty"`
+	Afs         string `protobuf:"bytes,23,opt,name=afs,proto3" json:"afs,omitempty"`
+	Wksi        bool   `protobuf:"varint,24,opt,name=wksi,proto3" json:"wksi,omitempty"`
+	Fye         string `protobuf:"bytes,25,opt,name=fye,proto3" json:"fye,omitempty"`
+	Form        string `protobuf:"bytes,26,opt,name=form,proto3" json:"form,omitempty"`
+	Period      string `protobuf:"bytes,27,opt,name=period,proto3" json:"period,omitempty"`
+	Fy          string `protobuf:"bytes,28,opt,name=fy,proto3" json:"fy,omitempty"`
+	Fp          string `protobuf:"bytes,29,opt,name=fp,proto3" json:"fp,omitempty"`
+	Filed       string `protobuf:"bytes,30,opt,name=filed,proto3" json:"filed,omitempty"`
+	Accepted    string `protobuf:"bytes,31,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Prevrpt     bool   `protobuf:"varint,32,opt,name=prevrpt,proto3" json:"prevrpt,omitempty"`
+	Detail      bool   `protobuf:"varint,33,opt,name=detail,proto3" json:"detail,omitempty"`
+	Instance    string `protobuf:"bytes,34,opt,name=instance,proto3" json:"instance,omitempty"`
+	Nciks       int32  `protobuf:"varint,35,opt,name=nciks,proto3" json:"nciks,omitempty"`
+	Aciks       string `protobuf:"bytes,36,opt,name=aciks,proto3" json:"aciks,omitempty"`
+	Pubfloatusd string `protobuf:"bytes,37,opt,name=pubfloatusd,proto3" json:"pubfloatusd,omitempty"`
+	Floatdate   string `protobuf:"bytes,38,opt,name=floatdate,proto3" json:"floatdate,omitempty"`
+	Floataxis   string `protobuf:"bytes,39,opt,name=floataxis,proto3" json:"floataxis,omitempty"`
+	Floatmems   int32  `protobuf:"varint,40,opt,name=floatmems,proto3" json:"floatmems,omitempty"`
+}
+
+func (x *Submission) Reset() {
+	*x = Submission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filingsdb_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Submission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Submission) ProtoMessage() {}
+
+func (x *Submission) ProtoReflect() protoreflect.Message {
+	mi := &file_filingsdb_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Submission.ProtoReflect.Descriptor instead.
+func (*Submission) Descriptor() ([]byte, []int) {
+	return file_filingsdb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Submission) GetAdsh() string {
+	if x != nil {
+		return x.Adsh
+	}
+	return ""
+}
+
+func (x *Submission) GetCik() string {
+	if x != nil {
+		return x.Cik
+	}
+	return ""
+}
+
+func (x *Submission) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Submission) GetSic() string {
+	if x != nil {
+		return x.Sic
+	}
+	return ""
+}
+
+func (x *Submission) GetCountryba() string {
+	if x != nil {
+		return x.Countryba
+	}
+	return ""
+}
+
+func (x *Submission) GetStprba() string {
+	if x != nil {
+		return x.Stprba
+	}
+	return ""
+}
+
+func (x *Submission) GetCityba() string {
+	if x != nil {
+		return x.Cityba
+	}
+	return ""
+}
+
+func (x *Submission) GetZipba() string {
+	if x != nil {
+		return x.Zipba
+	}
+	return ""
+}
+
+func (x *Submission) GetBas1() string {
+	if x != nil {
+		return x.Bas1
+	}
+	return ""
+}
+
+func (x *Submission) GetBas2() string {
+	if x != nil {
+		return x.Bas2
+	}
+	return ""
+}
+
+func (x *Submission) GetBaph() string {
+	if x != nil {
+		return x.Baph
+	}
+	return ""
+}
+
+func (x *Submission) GetCountryma() string {
+	if x != nil {
+		return x.Countryma
+	}
+	return ""
+}
+
+func (x *Submission) GetStprma() string {
+	if x != nil {
+		return x.Stprma
+	}
+	return ""
+}
+
+func (x *Submission) GetCityma() string {
+	if x != nil {
+		return x.Cityma
+	}
+	return ""
+}
+
+func (x *Submission) GetZipma() string {
+	if x != nil {
+		return x.Zipma
+	}
+	return ""
+}
+
+func (x *Submission) GetMas1() string {
+	if x != nil {
+		return x.Mas1
+	}
+	return ""
+}
+
+func (x *Submission) GetMas2() string {
+	if x != nil {
+		return x.Mas2
+	}
+	return ""
+}
+
+func (x *Submission) GetCountryinc() string {
+	if x != nil {
+		return x.Countryinc
+	}
+	return ""
+}
+
+func (x *Submission) GetStprinc() string {
+	if x != nil {
+		return x.Stprinc
+	}
+	return ""
+}
+
+func (x *Submission) GetEin() string {
+	if x != nil {
+		return x.Ein
+	}
+	return ""
+}
+
+func (x *Submission) GetFormer() string {
+	if x != nil {
+		return x.Former
+	}
+	return ""
+}
+
+func (x *Submission) GetChanged() string {
+	if x != nil {
+		return x.Changed
+	}
+	return ""
+}
+
+func (x *Submission) GetAfs() string {
+	if x != nil {
+		return x.Afs
+	}
+	return ""
+}
+
+func (x *Submission) GetWksi() bool {
+	if x != nil {
+		return x.Wksi
+	}
+	return false
+}
+
+func (x *Submission) GetFye() string {
+	if x != nil {
+		return x.Fye
+	}
+	return ""
+}
+
+func (x *Submission) GetForm() string {
+	if x != nil {
+		return x.Form
+	}
+	return ""
+}
+
+func (x *Submission) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *Submission) GetFy() string {
+	if x != nil {
+		return x.Fy
+	}
+	return ""
+}
+
+func (x *Submission) GetFp() string {
+	if x != nil {
+		return x.Fp
+	}
+	return ""
+}
+
+func (x *Submission) GetFiled() string {
+	if x != nil {
+		return x.Filed
+	}
+	return ""
+}
+
+func (x *Submission) GetAccepted() string {
+	if x != nil {
+		return x.Accepted
+	}
+	return ""
+}
+
+func (x *Submission) GetPrevrpt() bool {
+	if x != nil {
+		return x.Prevrpt
+	}
+	return false
+}
+
+func (x *Submission) GetDetail() bool {
+	if x != nil {
+		return x.Detail
+	}
+	return false
+}
+
+func (x *Submission) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *Submission) GetNciks() int32 {
+	if x != nil {
+		return x.Nciks
+	}
+	return 0
+}
+
+func (x *Submission) GetAciks() string {
+	if x != nil {
+		return x.Aciks
+	}
+	return ""
+}
+
+func (x *Submission) GetPubfloatusd() string {
+	if x != nil {
+		return x.Pubfloatusd
+	}
+	return ""
+}
+
+func (x *Submission) GetFloatdate() string {
+	if x != nil {
+		return x.Floatdate
+	}
+	return ""
+}
+
+func (x *Submission) GetFloataxis() string {
+	if x != nil {
+		return x.Floataxis
+	}
+	return ""
+}
+
+func (x *Submission) GetFloatmems() int32 {
+	if x != nil {
+		return x.Floatmems
+	}
+	return 0
+}
+
+type Num struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Adsh     string `protobuf:"bytes,1,opt,name=adsh,proto3" json:"adsh,omitempty"`
+	Tag      string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Version  string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Ddate    string `protobuf:"bytes,4,opt,name=ddate,proto3" json:"ddate,omitempty"`
+	Qtrs     int32  `protobuf:"varint,5,opt,name=qtrs,proto3" json:"qtrs,omitempty"`
+	Uom      string `protobuf:"bytes,6,opt,name=uom,proto3" json:"uom,omitempty"`
+	Dimh     string `protobuf:"bytes,7,opt,name=dimh,proto3" json:"dimh,omitempty"`
+	Iprx     int32  `protobuf:"varint,8,opt,name=iprx,proto3" json:"iprx,omitempty"`
+	Value    string `protobuf:"bytes,9,opt,name=value,proto3" json:"value,omitempty"`
+	Footnote string `protobuf:"bytes,10,opt,name=footnote,proto3" json:"footnote,omitempty"`
+	Footlen  int32  `protobuf:"varint,11,opt,name=footlen,proto3" json:"footlen,omitempty"`
+	Dimn     int32  `protobuf:"varint,12,opt,name=dimn,proto3" json:"dimn,omitempty"`
+	Coreg    string `protobuf:"bytes,13,opt,name=coreg,proto3" json:"coreg,omitempty"`
+	Durp     string `protobuf:"bytes,14,opt,name=durp,proto3" json:"durp,omitempty"`
+	Datp     string `protobuf:"bytes,15,opt,name=datp,proto3" json:"datp,omitempty"`
+	Dcml     int32  `protobuf:"varint,16,opt,name=dcml,proto3" json:"dcml,omitempty"`
+}
+
+func (x *Num) Reset() {
+	*x = Num{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filingsdb_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Num) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Num) ProtoMessage() {}
+
+func (x *Num) ProtoReflect() protoreflect.Message {
+	mi := &file_filingsdb_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Num.ProtoReflect.Descriptor instead.
+func (*Num) Descriptor() ([]byte, []int) {
+	return file_filingsdb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Num) GetAdsh() string {
+	if x != nil {
+		return x.Adsh
+	}
+	return ""
+}
+
+func (x *Num) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Num) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Num) GetDdate() string {
+	if x != nil {
+		return x.Ddate
+	}
+	return ""
+}
+
+func (x *Num) GetQtrs() int32 {
+	if x != nil {
+		return x.Qtrs
+	}
+	return 0
+}
+
+func (x *Num) GetUom() string {
+	if x != nil {
+		return x.Uom
+	}
+	return ""
+}
+
+func (x *Num) GetDimh() string {
+	if x != nil {
+		return x.Dimh
+	}
+	return ""
+}
+
+func (x *Num) GetIprx() int32 {
+	if x != nil {
+		return x.Iprx
+	}
+	return 0
+}
+
+func (x *Num) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Num) GetFootnote() string {
+	if x != nil {
+		return x.Footnote
+	}
+	return ""
+}
+
+func (x *Num) GetFootlen() int32 {
+	if x != nil {
+		return x.Footlen
+	}
+	return 0
+}
+
+func (x *Num) GetDimn() int32 {
+	if x != nil {
+		return x.Dimn
+	}
+	return 0
+}
+
+func (x *Num) GetCoreg() string {
+	if x != nil {
+		return x.Coreg
+	}
+	return ""
+}
+
+func (x *Num) GetDurp() string {
+	if x != nil {
+		return x.Durp
+	}
+	return ""
+}
+
+func (x *Num) GetDatp() string {
+	if x != nil {
+		return x.Datp
+	}
+	return ""
+}
+
+func (x *Num) GetDcml() int32 {
+	if x != nil {
+		return x.Dcml
+	}
+	return 0
+}
+
+type Txt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Adsh     string `protobuf:"bytes,1,opt,name=adsh,proto3" json:"adsh,omitempty"`
+	Tag      string `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Version  string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Ddate    string `protobuf:"bytes,4,opt,name=ddate,proto3" json:"ddate,omitempty"`
+	Qtrs     int32  `protobuf:"varint,5,opt,name=qtrs,proto3" json:"qtrs,omitempty"`
+	Iprx     int32  `protobuf:"varint,6,opt,name=iprx,proto3" json:"iprx,omitempty"`
+	Lang     string `protobuf:"bytes,7,opt,name=lang,proto3" json:"lang,omitempty"`
+	Dcml     int32  `protobuf:"varint,8,opt,name=dcml,proto3" json:"dcml,omitempty"`
+	Durp     string `protobuf:"bytes,9,opt,name=durp,proto3" json:"durp,omitempty"`
+	Datp     string `protobuf:"bytes,10,opt,name=datp,proto3" json:"datp,omitempty"`
+	Dimh     string `protobuf:"bytes,11,opt,name=dimh,proto3" json:"dimh,omitempty"`
+	Dimn     int32  `protobuf:"varint,12,opt,name=dimn,proto3" json:"dimn,omitempty"`
+	Coreg    string `protobuf:"bytes,13,opt,name=coreg,proto3" json:"coreg,omitempty"`
+	Escaped  bool   `protobuf:"varint,14,opt,name=escaped,proto3" json:"escaped,omitempty"`
+	Srclen   int32  `protobuf:"varint,15,opt,name=srclen,proto3" json:"srclen,omitempty"`
+	Txtlen   int32  `protobuf:"varint,16,opt,name=txtlen,proto3" json:"txtlen,omitempty"`
+	Footnote string `protobuf:"bytes,17,opt,name=footnote,proto3" json:"footnote,omitempty"`
+	Footlen  int32  `protobuf:"varint,18,opt,name=footlen,proto3" json:"footlen,omitempty"`
+	Context  string `protobuf:"bytes,19,opt,name=context,proto3" json:"context,omitempty"`
+	Value    string `protobuf:"bytes,20,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Txt) Reset() {
+	*x = Txt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filingsdb_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Txt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Txt) ProtoMessage() {}
+
+func (x *Txt) ProtoReflect() protoreflect.Message {
+	mi := &file_filingsdb_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Txt.ProtoReflect.Descriptor instead.
+func (*Txt) Descriptor() ([]byte, []int) {
+	return file_filingsdb_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Txt) GetAdsh() string {
+	if x != nil {
+		return x.Adsh
+	}
+	return ""
+}
+
+func (x *Txt) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Txt) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Txt) GetDdate() string {
+	if x != nil {
+		return x.Ddate
+	}
+	return ""
+}
+
+func (x *Txt) GetQtrs() int32 {
+	if x != nil {
+		return x.Qtrs
+	}
+	return 0
+}
+
+func (x *Txt) GetIprx() int32 {
+	if x != nil {
+		return x.Iprx
+	}
+	return 0
+}
+
+func (x *Txt) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *Txt) GetDcml() int32 {
+	if x != nil {
+		return x.Dcml
+	}
+	return 0
+}
+
+func (x *Txt) GetDurp() string {
+	if x != nil {
+		return x.Durp
+	}
+	return ""
+}
+
+func (x *Txt) GetDatp() string {
+	if x != nil {
+		return x.Datp
+	}
+	return ""
+}
+
+func (x *Txt) GetDimh() string {
+	if x != nil {
+		return x.Dimh
+	}
+	return ""
+}
+
+func (x *Txt) GetDimn() int32 {
+	if x != nil {
+		return x.Dimn
+	}
+	return 0
+}
+
+func (x *Txt) GetCoreg() string {
+	if x != nil {
+		return x.Coreg
+	}
+	return ""
+}
+
+func (x *Txt) GetEscaped() bool {
+	if x != nil {
+		return x.Escaped
+	}
+	return false
+}
+
+func (x *Txt) GetSrclen() int32 {
+	if x != nil {
+		return x.Srclen
+	}
+	return 0
+}
+
+func (x *Txt) GetTxtlen() int32 {
+	if x != nil {
+		return x.Txtlen
+	}
+	return 0
+}
+
+func (x *Txt) GetFootnote() string {
+	if x != nil {
+		return x.Footnote
+	}
+	return ""
+}
+
+func (x *Txt) GetFootlen() int32 {
+	if x != nil {
+		return x.Footlen
+	}
+	return 0
+}
+
+func (x *Txt) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+func (x *Txt) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type Dimension struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dimh     string `protobuf:"bytes,1,opt,name=dimh,proto3" json:"dimh,omitempty"`
+	Segments string `protobuf:"bytes,2,opt,name=segments,proto3" json:"segments,omitempty"`
+	Segt     bool   `protobuf:"varint,3,opt,name=segt,proto3" json:"segt,omitempty"`
+}
+
+func (x *Dimension) Reset() {
+	*x = Dimension{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filingsdb_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dimension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dimension) ProtoMessage() {}
+
+func (x *Dimension) ProtoReflect() protoreflect.Message {
+	mi := &file_filingsdb_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dimension.ProtoReflect.Descriptor instead.
+func (*Dimension) Descriptor() ([]byte, []int) {
+	return file_filingsdb_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Dimension) GetDimh() string {
+	if x != nil {
+		return x.Dimh
+	}
+	return ""
+}
+
+func (x *Dimension) GetSegments() string {
+	if x != nil {
+		return x.Segments
+	}
+	return ""
+}
+
+func (x *Dimension) GetSegt() bool {
+	if x != nil {
+		return x.Segt
+	}
+	return false
+}
+
+var File_filingsdb_proto protoreflect.FileDescriptor
+
+var file_filingsdb_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0c, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e, 0x76, 0x31, 0x22,
+	0x2a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x73, 0x68, 0x22, 0x7a, 0x0a, 0x16, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x66, 0x6f, 0x72, 0x6d, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x66, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x66, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x63, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x63, 0x22, 0xfe, 0x01, 0x0a, 0x09, 0x46, 0x61, 0x63, 0x74,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x73,
+	0x68, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x73, 0x68, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x6d, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x64, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x64, 0x64, 0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x19, 0x0a, 0x08,
+	0x64, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x64, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x71, 0x74, 0x72, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x71, 0x74, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x6f, 0x6d, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6f, 0x6d, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x64, 0x69, 0x6d, 0x68, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x64, 0x69, 0x6d, 0x68, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79,
+	0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x70, 0x72, 0x69,
+	0x6d, 0x61, 0x72, 0x79, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x2c, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x44,
+	0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x64, 0x69, 0x6d, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x64, 0x69, 0x6d, 0x68, 0x73, 0x22, 0x50, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6d,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x37, 0x0a, 0x0a, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x69,
+	0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xae, 0x07, 0x0a, 0x0a, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x63,
+	0x69, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x6b, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x73, 0x69, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x62, 0x61,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x62,
+	0x61, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x70, 0x72, 0x62, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x70, 0x72, 0x62, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x69, 0x74,
+	0x79, 0x62, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x69, 0x74, 0x79, 0x62,
+	0x61, 0x12, 0x14, 0x0a, 0x05, 0x7a, 0x69, 0x70, 0x62, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x7a, 0x69, 0x70, 0x62, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x31, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x31, 0x12, 0x12, 0x0a, 0x04, 0x62,
+	0x61, 0x73, 0x32, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x32, 0x12,
+	0x12, 0x0a, 0x04, 0x62, 0x61, 0x70, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62,
+	0x61, 0x70, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x6d, 0x61,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x6d,
+	0x61, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x70, 0x72, 0x6d, 0x61, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x70, 0x72, 0x6d, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x69, 0x74,
+	0x79, 0x6d, 0x61, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x69, 0x74, 0x79, 0x6d,
+	0x61, 0x12, 0x14, 0x0a, 0x05, 0x7a, 0x69, 0x70, 0x6d, 0x61, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x7a, 0x69, 0x70, 0x6d, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x31, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61, 0x73, 0x31, 0x12, 0x12, 0x0a, 0x04, 0x6d,
+	0x61, 0x73, 0x32, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61, 0x73, 0x32, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x69, 0x6e, 0x63, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x69, 0x6e, 0x63, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x74, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x73, 0x74, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x69, 0x6e,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x65, 0x72, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x61, 0x66, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x66, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x77, 0x6b, 0x73, 0x69, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x77,
+	0x6b, 0x73, 0x69, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x79, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x66, 0x79, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x1a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x79, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x66,
+	0x79, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x70, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x66,
+	0x70, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x65, 0x64, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x72, 0x70, 0x74, 0x18, 0x20,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x65, 0x76, 0x72, 0x70, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x21, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x22, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x63, 0x69, 0x6b, 0x73, 0x18, 0x23, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6e, 0x63, 0x69, 0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x69, 0x6b, 0x73,
+	0x18, 0x24, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x69, 0x6b, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x70, 0x75, 0x62, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x75, 0x73, 0x64, 0x18, 0x25, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x70, 0x75, 0x62, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x75, 0x73, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x64, 0x61, 0x74, 0x65, 0x18, 0x26, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x61, 0x78, 0x69, 0x73, 0x18, 0x27, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x61, 0x78, 0x69, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x66,
+	0x6c, 0x6f, 0x61, 0x74, 0x6d, 0x65, 0x6d, 0x73, 0x18, 0x28, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x66, 0x6c, 0x6f, 0x61, 0x74, 0x6d, 0x65, 0x6d, 0x73, 0x22, 0xdb, 0x02, 0x0a, 0x03, 0x4e, 0x75,
+	0x6d, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x61, 0x64, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x64, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x71, 0x74, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x71, 0x74, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x6f, 0x6d, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x69, 0x6d, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x6d,
+	0x68, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x70, 0x72, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x69, 0x70, 0x72, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66,
+	0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
+	0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x6f, 0x6f, 0x74, 0x6c,
+	0x65, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x66, 0x6f, 0x6f, 0x74, 0x6c, 0x65,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x69, 0x6d, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x64, 0x69, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x72, 0x65, 0x67, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x72, 0x65, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x75, 0x72, 0x70, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x75, 0x72, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x70, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x63, 0x6d, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x64, 0x63, 0x6d, 0x6c, 0x22, 0xc1, 0x03, 0x0a, 0x03, 0x54, 0x78, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x61, 0x64, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61,
+	0x64, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x64, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x64, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x71, 0x74, 0x72, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x71, 0x74, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x70, 0x72,
+	0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x69, 0x70, 0x72, 0x78, 0x12, 0x12, 0x0a,
+	0x04, 0x6c, 0x61, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x6e,
+	0x67, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x63, 0x6d, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x64, 0x63, 0x6d, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x75, 0x72, 0x70, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x75, 0x72, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x70, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x69, 0x6d, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x6d,
+	0x68, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x69, 0x6d, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x64, 0x69, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x72, 0x65, 0x67, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x72, 0x65, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x73, 0x63, 0x61, 0x70, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x73,
+	0x63, 0x61, 0x70, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x72, 0x63, 0x6c, 0x65, 0x6e, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x72, 0x63, 0x6c, 0x65, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x78, 0x74, 0x6c, 0x65, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74,
+	0x78, 0x74, 0x6c, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74,
+	0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x6f, 0x6f, 0x74, 0x6e, 0x6f, 0x74,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x6f, 0x6f, 0x74, 0x6c, 0x65, 0x6e, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x66, 0x6f, 0x6f, 0x74, 0x6c, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x14,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4f, 0x0a, 0x09, 0x44,
+	0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x69, 0x6d, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x69, 0x6d, 0x68, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x67, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x65, 0x67, 0x74, 0x32, 0xff, 0x02, 0x0a,
+	0x07, 0x46, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x4d, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53,
+	0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e, 0x66, 0x69, 0x6c, 0x69,
+	0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x53, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x2e, 0x66, 0x69, 0x6c,
+	0x69, 0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x75,
+	0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x30, 0x01, 0x12, 0x3a, 0x0a, 0x0a,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x75, 0x6d, 0x73, 0x12, 0x17, 0x2e, 0x66, 0x69, 0x6c,
+	0x69, 0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x63, 0x74, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x1a, 0x11, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x75, 0x6d, 0x30, 0x01, 0x12, 0x3a, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x54, 0x78, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73,
+	0x64, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x63, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a,
+	0x11, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x78, 0x74, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6d, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x73, 0x64,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x69, 0x6c, 0x69,
+	0x6e, 0x67, 0x73, 0x64, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6d, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x18,
+	0x5a, 0x16, 0x65, 0x73, 0x77, 0x69, 0x61, 0x63, 0x2e, 0x6d, 0x65, 0x2f, 0x66, 0x69, 0x6c, 0x69,
+	0x6e, 0x67, 0x73, 0x64, 0x62, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filingsdb_proto_rawDescOnce sync.Once
+	file_filingsdb_proto_rawDescData = file_filingsdb_proto_rawDesc
+)
+
+func file_filingsdb_proto_rawDescGZIP() []byte {
+	file_filingsdb_proto_rawDescOnce.Do(func() {
+		file_filingsdb_proto_rawDescData = protoimpl.X.CompressGZIP(file_filingsdb_proto_rawDescData)
+	})
+	return file_filingsdb_proto_rawDescData
+}
+
+var file_filingsdb_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_filingsdb_proto_goTypes = []interface{}{
+	(*GetSubmissionRequest)(nil),   // 0: filingsdb.v1.GetSubmissionRequest
+	(*ListSubmissionsRequest)(nil), // 1: filingsdb.v1.ListSubmissionsRequest
+	(*FactQuery)(nil),              // 2: filingsdb.v1.FactQuery
+	(*GetDimensionsRequest)(nil),   // 3: filingsdb.v1.GetDimensionsRequest
+	(*GetDimensionsResponse)(nil),  // 4: filingsdb.v1.GetDimensionsResponse
+	(*Submission)(nil),             // 5: filingsdb.v1.Submission
+	(*Num)(nil),                    // 6: filingsdb.v1.Num
+	(*Txt)(nil),                    // 7: filingsdb.v1.Txt
+	(*Dimension)(nil),              // 8: filingsdb.v1.Dimension
+}
+var file_filingsdb_proto_depIdxs = []int32{
+	8, // 0: filingsdb.v1.GetDimensionsResponse.dimensions:type_name -> filingsdb.v1.Dimension
+	0, // 1: filingsdb.v1.Filings.GetSubmission:input_type -> filingsdb.v1.GetSubmissionRequest
+	1, // 2: filingsdb.v1.Filings.ListSubmissions:input_type -> filingsdb.v1.ListSubmissionsRequest
+	2, // 3: filingsdb.v1.Filings.StreamNums:input_type -> filingsdb.v1.FactQuery
+	2, // 4: filingsdb.v1.Filings.StreamTxts:input_type -> filingsdb.v1.FactQuery
+	3, // 5: filingsdb.v1.Filings.GetDimensions:input_type -> filingsdb.v1.GetDimensionsRequest
+	5, // 6: filingsdb.v1.Filings.GetSubmission:output_type -> filingsdb.v1.Submission
+	5, // 7: filingsdb.v1.Filings.ListSubmissions:output_type -> filingsdb.v1.Submission
+	6, // 8: filingsdb.v1.Filings.StreamNums:output_type -> filingsdb.v1.Num
+	7, // 9: filingsdb.v1.Filings.StreamTxts:output_type -> filingsdb.v1.Txt
+	4, // 10: filingsdb.v1.Filings.GetDimensions:output_type -> filingsdb.v1.GetDimensionsResponse
+	6, // [6:11] is the sub-list for method output_type
+	1, // [1:6] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_filingsdb_proto_init() }
+func file_filingsdb_proto_init() {
+	if File_filingsdb_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filingsdb_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSubmissionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filingsdb_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSubmissionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filingsdb_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FactQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filingsdb_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDimensionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filingsdb_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDimensionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filingsdb_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Submission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filingsdb_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Num); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filingsdb_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Txt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filingsdb_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dimension); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filingsdb_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_filingsdb_proto_goTypes,
+		DependencyIndexes: file_filingsdb_proto_depIdxs,
+		MessageInfos:      file_filingsdb_proto_msgTypes,
+	}.Build()
+	File_filingsdb_proto = out.File
+	file_filingsdb_proto_rawDesc = nil
+	file_filingsdb_proto_goTypes = nil
+	file_filingsdb_proto_depIdxs = nil
+}
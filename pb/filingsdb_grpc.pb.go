@@ -0,0 +1,335 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// FilingsClient is the client API for Filings service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FilingsClient interface {
+	// GetSubmission returns the submission (sub.tsv) with the given adsh.
+	GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*Submission, error)
+	// ListSubmissions streams the submissions matching the request, most
+	// recent first.
+	ListSubmissions(ctx context.Context, in *ListSubmissionsRequest, opts ...grpc.CallOption) (Filings_ListSubmissionsClient, error)
+	// StreamNums streams the numeric facts (num.tsv) matching the query.
+	StreamNums(ctx context.Context, in *FactQuery, opts ...grpc.CallOption) (Filings_StreamNumsClient, error)
+	// StreamTxts streams the text facts (txt.tsv) matching the query.
+	StreamTxts(ctx context.Context, in *FactQuery, opts ...grpc.CallOption) (Filings_StreamTxtsClient, error)
+	// GetDimensions returns the segments (dim.tsv) of the given dimension hashes.
+	GetDimensions(ctx context.Context, in *GetDimensionsRequest, opts ...grpc.CallOption) (*GetDimensionsResponse, error)
+}
+
+type filingsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFilingsClient(cc grpc.ClientConnInterface) FilingsClient {
+	return &filingsClient{cc}
+}
+
+func (c *filingsClient) GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*Submission, error) {
+	out := new(Submission)
+	err := c.cc.Invoke(ctx, "/filingsdb.v1.Filings/GetSubmission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filingsClient) ListSubmissions(ctx context.Context, in *ListSubmissionsRequest, opts ...grpc.CallOption) (Filings_ListSubmissionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Filings_serviceDesc.Streams[0], "/filingsdb.v1.Filings/ListSubmissions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &filingsListSubmissionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Filings_ListSubmissionsClient interface {
+	Recv() (*Submission, error)
+	grpc.ClientStream
+}
+
+type filingsListSubmissionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *filingsListSubmissionsClient) Recv() (*Submission, error) {
+	m := new(Submission)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *filingsClient) StreamNums(ctx context.Context, in *FactQuery, opts ...grpc.CallOption) (Filings_StreamNumsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Filings_serviceDesc.Streams[1], "/filingsdb.v1.Filings/StreamNums", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &filingsStreamNumsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Filings_StreamNumsClient interface {
+	Recv() (*Num, error)
+	grpc.ClientStream
+}
+
+type filingsStreamNumsClient struct {
+	grpc.ClientStream
+}
+
+func (x *filingsStreamNumsClient) Recv() (*Num, error) {
+	m := new(Num)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *filingsClient) StreamTxts(ctx context.Context, in *FactQuery, opts ...grpc.CallOption) (Filings_StreamTxtsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Filings_serviceDesc.Streams[2], "/filingsdb.v1.Filings/StreamTxts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &filingsStreamTxtsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Filings_StreamTxtsClient interface {
+	Recv() (*Txt, error)
+	grpc.ClientStream
+}
+
+type filingsStreamTxtsClient struct {
+	grpc.ClientStream
+}
+
+func (x *filingsStreamTxtsClient) Recv() (*Txt, error) {
+	m := new(Txt)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *filingsClient) GetDimensions(ctx context.Context, in *GetDimensionsRequest, opts ...grpc.CallOption) (*GetDimensionsResponse, error) {
+	out := new(GetDimensionsResponse)
+	err := c.cc.Invoke(ctx, "/filingsdb.v1.Filings/GetDimensions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FilingsServer is the server API for Filings service.
+// All implementations must embed UnimplementedFilingsServer
+// for forward compatibility
+type FilingsServer interface {
+	// GetSubmission returns the submission (sub.tsv) with the given adsh.
+	GetSubmission(context.Context, *GetSubmissionRequest) (*Submission, error)
+	// ListSubmissions streams the submissions matching the request, most
+	// recent first.
+	ListSubmissions(*ListSubmissionsRequest, Filings_ListSubmissionsServer) error
+	// StreamNums streams the numeric facts (num.tsv) matching the query.
+	StreamNums(*FactQuery, Filings_StreamNumsServer) error
+	// StreamTxts streams the text facts (txt.tsv) matching the query.
+	StreamTxts(*FactQuery, Filings_StreamTxtsServer) error
+	// GetDimensions returns the segments (dim.tsv) of the given dimension hashes.
+	GetDimensions(context.Context, *GetDimensionsRequest) (*GetDimensionsResponse, error)
+	mustEmbedUnimplementedFilingsServer()
+}
+
+// UnimplementedFilingsServer must be embedded to have forward compatible implementations.
+type UnimplementedFilingsServer struct {
+}
+
+func (UnimplementedFilingsServer) GetSubmission(context.Context, *GetSubmissionRequest) (*Submission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubmission not implemented")
+}
+func (UnimplementedFilingsServer) ListSubmissions(*ListSubmissionsRequest, Filings_ListSubmissionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListSubmissions not implemented")
+}
+func (UnimplementedFilingsServer) StreamNums(*FactQuery, Filings_StreamNumsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamNums not implemented")
+}
+func (UnimplementedFilingsServer) StreamTxts(*FactQuery, Filings_StreamTxtsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTxts not implemented")
+}
+func (UnimplementedFilingsServer) GetDimensions(context.Context, *GetDimensionsRequest) (*GetDimensionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDimensions not implemented")
+}
+func (UnimplementedFilingsServer) mustEmbedUnimplementedFilingsServer() {}
+
+// UnsafeFilingsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FilingsServer will
+// result in compilation errors.
+type UnsafeFilingsServer interface {
+	mustEmbedUnimplementedFilingsServer()
+}
+
+func RegisterFilingsServer(s grpc.ServiceRegistrar, srv FilingsServer) {
+	s.RegisterService(&_Filings_serviceDesc, srv)
+}
+
+func _Filings_GetSubmission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubmissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilingsServer).GetSubmission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filingsdb.v1.Filings/GetSubmission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilingsServer).GetSubmission(ctx, req.(*GetSubmissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Filings_ListSubmissions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSubmissionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FilingsServer).ListSubmissions(m, &filingsListSubmissionsServer{stream})
+}
+
+type Filings_ListSubmissionsServer interface {
+	Send(*Submission) error
+	grpc.ServerStream
+}
+
+type filingsListSubmissionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *filingsListSubmissionsServer) Send(m *Submission) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Filings_StreamNums_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FactQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FilingsServer).StreamNums(m, &filingsStreamNumsServer{stream})
+}
+
+type Filings_StreamNumsServer interface {
+	Send(*Num) error
+	grpc.ServerStream
+}
+
+type filingsStreamNumsServer struct {
+	grpc.ServerStream
+}
+
+func (x *filingsStreamNumsServer) Send(m *Num) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Filings_StreamTxts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FactQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FilingsServer).StreamTxts(m, &filingsStreamTxtsServer{stream})
+}
+
+type Filings_StreamTxtsServer interface {
+	Send(*Txt) error
+	grpc.ServerStream
+}
+
+type filingsStreamTxtsServer struct {
+	grpc.ServerStream
+}
+
+func (x *filingsStreamTxtsServer) Send(m *Txt) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Filings_GetDimensions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDimensionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilingsServer).GetDimensions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filingsdb.v1.Filings/GetDimensions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilingsServer).GetDimensions(ctx, req.(*GetDimensionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Filings_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "filingsdb.v1.Filings",
+	HandlerType: (*FilingsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSubmission",
+			Handler:    _Filings_GetSubmission_Handler,
+		},
+		{
+			MethodName: "GetDimensions",
+			Handler:    _Filings_GetDimensions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListSubmissions",
+			Handler:       _Filings_ListSubmissions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamNums",
+			Handler:       _Filings_StreamNums_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTxts",
+			Handler:       _Filings_StreamTxts_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "filingsdb.proto",
+}
@@ -0,0 +1,190 @@
+package peers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"eswiac.me/filingsdb/screen"
+	"eswiac.me/filingsdb/sic"
+	"gorm.io/gorm"
+)
+
+// DefaultMetrics are compared when none are requested
+var DefaultMetrics = []string{"Revenue", "NetIncome", "Assets", "GrossMargin", "NetMargin", "ROE", "CurrentRatio", "DebtToEquity"}
+
+// Query compares a company with the other companies of its industry
+type Query struct {
+	query.Filter
+	Cik string
+
+	// Compare with the companies of the same SIC major group (e.g. 28xx)
+	// instead of the same SIC code
+	MajorGroup bool
+
+	/**
+	The fiscal year and period (FY, Q1, Q2, Q3) to compare.
+	Defaults to the fiscal year of the last annual report
+	of the company.
+	*/
+	Fy string
+	Fp string
+
+	// Metrics to compare, anything a screen column accepts: tags, concepts
+	// (optionally suffixed by _ttm, _fy or _mrq), ratios or expressions of them
+	Metrics []string
+}
+
+// Stats is the distribution of a metric among peers
+type Stats struct {
+	Metric string
+
+	// The value of the company, nil if unknown
+	Value *float64
+
+	// Count of peers reporting the metric, the company included
+	Count  int
+	Min    float64
+	Q1     float64
+	Median float64
+	Q3     float64
+	Max    float64
+
+	// Rank of the company, 1 for the highest value, 0 if its value is unknown
+	Rank int
+}
+
+// Comparison is a company compared with its peers
+type Comparison struct {
+	Company screen.Row
+
+	// The SIC code or major group of the peers and its title
+	Industry    string
+	Description string
+
+	Fy      string
+	Fp      string
+	Metrics []string
+
+	// The submissions of the peers for the period, the company included
+	Peers []screen.Row
+	Stats []Stats
+}
+
+// Compare finds the companies sharing the SIC code (or major group) of the
+// company, reads their metrics for the same fiscal period out of their last
+// submission for it, and computes the distribution of each metric
+func Compare(db *gorm.DB, q Query) (*Comparison, error) {
+	metrics := q.Metrics
+	if len(metrics) == 0 {
+		metrics = DefaultMetrics
+	}
+	fp := q.Fp
+	if fp == "" {
+		fp = "FY"
+	}
+
+	tx := db.Where("data_subs.cik = ? AND data_subs.form IN ? AND data_subs.fp = ?", q.Cik, query.StatementForms, fp)
+	if q.Fy != "" {
+		tx = tx.Where("data_subs.fy = ?", q.Fy)
+	}
+	subs := []models.DataSUB{}
+	err := tx.Scopes(q.Filter.Scope).Order("data_subs.accepted DESC").Limit(1).Find(&subs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf("no %s financial statements found for CIK %s", fp, q.Cik)
+	}
+	sub := subs[0]
+
+	industry, description := sub.Sic, sic.Description(sub.Sic)
+	conds := []string{"sic = " + quote(sub.Sic)}
+	if q.MajorGroup {
+		industry = sic.MajorGroup(sub.Sic)
+		description = sic.MajorGroups[industry]
+		conds = []string{fmt.Sprintf("sic >= %s and sic <= %s", quote(industry+"00"), quote(industry+"99"))}
+	}
+	forms := []string{}
+	for _, form := range query.StatementForms {
+		forms = append(forms, quote(form))
+	}
+	conds = append(conds,
+		"fy = "+quote(sub.Fy),
+		"fp = "+quote(fp),
+		"form in ("+strings.Join(forms, ", ")+")",
+	)
+
+	result, err := screen.Run(db, screen.Query{
+		Filter:     q.Filter,
+		Expression: strings.Join(conds, " and "),
+		Columns:    metrics,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Comparison{Industry: industry, Description: description, Fy: sub.Fy, Fp: fp, Metrics: metrics, Peers: result.Rows}
+	for _, row := range result.Rows {
+		if row.Sub.Cik == sub.Cik {
+			c.Company = row
+		}
+	}
+	if c.Company.Sub.Cik == "" {
+		return nil, fmt.Errorf("CIK %s is missing from its own industry screen", q.Cik)
+	}
+	for i, metric := range metrics {
+		c.Stats = append(c.Stats, stats(metric, c.Company.Values[i], column(result.Rows, i)))
+	}
+	return c, nil
+}
+
+// column returns the numeric values of the i-th column of rows
+func column(rows []screen.Row, i int) []float64 {
+	values := []float64{}
+	for _, row := range rows {
+		if f, ok := row.Values[i].(float64); ok && !math.IsNaN(f) && !math.IsInf(f, 0) {
+			values = append(values, f)
+		}
+	}
+	return values
+}
+
+func stats(metric string, value interface{}, values []float64) Stats {
+	s := Stats{Metric: metric, Count: len(values)}
+	if len(values) == 0 {
+		return s
+	}
+	sort.Float64s(values)
+	s.Min, s.Max = values[0], values[len(values)-1]
+	s.Q1, s.Median, s.Q3 = quantile(values, 0.25), quantile(values, 0.5), quantile(values, 0.75)
+	if f, ok := value.(float64); ok {
+		s.Value = &f
+		s.Rank = 1
+		for _, v := range values {
+			if v > f {
+				s.Rank++
+			}
+		}
+	}
+	return s
+}
+
+// quantile interpolates the p-quantile of sorted values linearly between the
+// closest ranks
+func quantile(sorted []float64, p float64) float64 {
+	pos := p * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	if lower+1 >= len(sorted) {
+		return sorted[lower]
+	}
+	return sorted[lower] + (pos-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// quote formats s as a string literal of the screen expression language
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
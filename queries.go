@@ -0,0 +1,145 @@
+package filingsdb
+
+import (
+	"context"
+
+	"eswiac.me/filingsdb/customtags"
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/peers"
+	"eswiac.me/filingsdb/query"
+	"eswiac.me/filingsdb/ratios"
+	"eswiac.me/filingsdb/render"
+	"eswiac.me/filingsdb/screen"
+)
+
+// ResolveCIK returns the CIK of a company given either its CIK or its ticker
+func (db *DB) ResolveCIK(ctx context.Context, company string) (string, error) {
+	return query.ResolveCIK(db.gorm.WithContext(ctx), company)
+}
+
+// ResolveDimh returns the dimension hash of segments, e.g. "ProductOrService=Advertising;"
+func (db *DB) ResolveDimh(ctx context.Context, segments string) (string, error) {
+	return query.ResolveDimh(db.gorm.WithContext(ctx), segments)
+}
+
+// Facts returns the numeric facts matching q, oldest accepted first
+func (db *DB) Facts(ctx context.Context, q query.FactQuery) ([]query.Fact, error) {
+	return query.Facts(db.gorm.WithContext(ctx), q)
+}
+
+// Fundamentals returns the most recent value of each tag of a company
+func (db *DB) Fundamentals(ctx context.Context, q query.FactQuery) ([]query.Fact, error) {
+	return query.Fundamentals(db.gorm.WithContext(ctx), q)
+}
+
+// Series returns the values of a tag of a company, one per period
+func (db *DB) Series(ctx context.Context, q query.FactQuery, tag string) ([]query.Fact, error) {
+	return query.Series(db.gorm.WithContext(ctx), q, tag)
+}
+
+// TimeSeries returns the values of tags of a company, one row per period
+func (db *DB) TimeSeries(ctx context.Context, q query.FactQuery) (*query.TimeSeries, error) {
+	return query.GetTimeSeries(db.gorm.WithContext(ctx), q)
+}
+
+// Texts returns the text facts of the submission adsh, all of them if tags is
+// empty, decompressing the values stored with the TextCompress policy
+func (db *DB) Texts(ctx context.Context, adsh string, tags []string) ([]models.DataTXT, error) {
+	return query.Texts(db.gorm.WithContext(ctx), adsh, tags)
+}
+
+// Filing returns the facts of the submission adsh, with their dimensions
+// and tags
+func (db *DB) Filing(ctx context.Context, adsh string) (*query.Filing, error) {
+	return query.GetFiling(db.gorm.WithContext(ctx), adsh)
+}
+
+// Reports returns the report tree of the submission adsh, as the EDGAR
+// viewer lists it
+func (db *DB) Reports(ctx context.Context, adsh string) (*query.ReportTree, error) {
+	return query.Reports(db.gorm.WithContext(ctx), adsh)
+}
+
+// Report returns a report of the submission adsh, given its number (4 or
+// R4), with its presentation lines and their values
+func (db *DB) Report(ctx context.Context, adsh string, report string) (*query.Report, error) {
+	return query.FilingReport(db.gorm.WithContext(ctx), adsh, report)
+}
+
+// RenderFiling writes a static HTML site of the submission adsh to dir
+func (db *DB) RenderFiling(ctx context.Context, adsh string, dir string) error {
+	return render.Filing(db.gorm.WithContext(ctx), adsh, dir)
+}
+
+// RenderCompany writes a static HTML page of the trends and filings of the
+// company cik to dir
+func (db *DB) RenderCompany(ctx context.Context, cik string, dir string) error {
+	return render.Company(db.gorm.WithContext(ctx), cik, dir)
+}
+
+// Tags searches the tags of the database, with their usage
+func (db *DB) Tags(ctx context.Context, q query.TagQuery) (*query.TagResult, error) {
+	return query.Tags(db.gorm.WithContext(ctx), q)
+}
+
+// CustomTagUsage returns the use of custom tags by each company of the
+// submissions of q, the companies with the largest share of custom facts first
+func (db *DB) CustomTagUsage(ctx context.Context, q customtags.Query) ([]customtags.Usage, error) {
+	return customtags.CompanyUsage(db.gorm.WithContext(ctx), q)
+}
+
+// SuggestTagMappings stores the standard tag closest to each custom tag of
+// the submissions of q in the tag_mappings table, keeping the mappings
+// already reviewed
+func (db *DB) SuggestTagMappings(ctx context.Context, q customtags.Query) error {
+	return customtags.Materialize(db.gorm.WithContext(ctx), q, BATCH_SIZE)
+}
+
+// TagMappings returns the stored mappings of the custom tags of the
+// submissions of q with the given status, any if empty
+func (db *DB) TagMappings(ctx context.Context, q customtags.Query, status string) ([]models.TagMapping, error) {
+	return customtags.Mappings(db.gorm.WithContext(ctx), q, status)
+}
+
+// ReviewTagMapping accepts or rejects the mapping of the custom tag of the
+// submission adsh, mapping it to another standard tag if mapped isn't empty
+func (db *DB) ReviewTagMapping(ctx context.Context, adsh string, tag string, status string, mapped string) error {
+	return customtags.Review(db.gorm.WithContext(ctx), adsh, tag, status, mapped)
+}
+
+// LatestStatement returns a statement as presented in the most recent filing of a company
+func (db *DB) LatestStatement(ctx context.Context, q query.StatementQuery) (*query.Statement, error) {
+	return query.LatestStatement(db.gorm.WithContext(ctx), q)
+}
+
+// FilingStatement returns a statement as presented in the submission adsh
+func (db *DB) FilingStatement(ctx context.Context, adsh string, stmt string, filter query.Filter) (*query.Statement, error) {
+	return query.FilingStatement(db.gorm.WithContext(ctx), adsh, stmt, filter)
+}
+
+// Ratios computes the default ratios for every submission of a company with
+// one of the given forms, oldest first
+func (db *DB) Ratios(ctx context.Context, cik string, forms []string) ([]ratios.Result, error) {
+	return ratios.ForCompany(db.gorm.WithContext(ctx), cik, forms, ratios.Ratios)
+}
+
+// SubmissionRatios computes the default ratios for the submission adsh
+func (db *DB) SubmissionRatios(ctx context.Context, adsh string) ([]ratios.Result, error) {
+	return ratios.ForSubmission(db.gorm.WithContext(ctx), adsh, ratios.Ratios)
+}
+
+// MaterializeRatios stores the default ratios of every submission with one
+// of the given forms in the ratios table
+func (db *DB) MaterializeRatios(ctx context.Context, forms []string) error {
+	return ratios.Materialize(db.gorm.WithContext(ctx), forms, ratios.Ratios, BATCH_SIZE)
+}
+
+// Screen returns the companies matching a screen
+func (db *DB) Screen(ctx context.Context, q screen.Query) (*screen.Result, error) {
+	return screen.Run(db.gorm.WithContext(ctx), q)
+}
+
+// Peers compares a company with the other companies of its industry
+func (db *DB) Peers(ctx context.Context, q peers.Query) (*peers.Comparison, error) {
+	return peers.Compare(db.gorm.WithContext(ctx), q)
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"fmt"
+	"time"
+)
+
+// acceptedLayout is the format of DataSUB.Accepted, e.g. "2019-01-31 16:05:00.0"
+const acceptedLayout = "2006-01-02 15:04:05.0"
+
+// eastern is the timezone EDGAR acceptance timestamps are recorded in
+var eastern = loadEastern()
+
+// Eastern returns the timezone of the acceptance timestamps of EDGAR,
+// America/New_York
+func Eastern() *time.Location {
+	return eastern
+}
+
+func loadEastern() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		// no tzdata available, fall back to EST
+		return time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}
+
+// ParseAsOf parses the point in time used to avoid look-ahead bias.
+//
+// A bare date (2019-06-30) means "what the market knew by the end of that day":
+// filings accepted after 5:30pm EST are considered filed on the following
+// business day, so the cutoff is 17:30 Eastern on that date. A full timestamp
+// (2019-06-30 12:00:00 or RFC 3339) is used as is, interpreted as Eastern time
+// unless it carries its own offset.
+func ParseAsOf(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "20060102"} {
+		if t, err := time.ParseInLocation(layout, s, eastern); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 17, 30, 0, 0, eastern), nil
+		}
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, s, eastern); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(eastern), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse `%s` as a date or timestamp", s)
+}
+
+// AcceptedCutoff formats t the way DataSUB.Accepted is stored so both can be
+// compared as strings
+func AcceptedCutoff(t time.Time) string {
+	return t.In(eastern).Format(acceptedLayout)
+}
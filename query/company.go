@@ -0,0 +1,91 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnknownTicker is the error of ResolveCIK given a ticker it can't find
+var ErrUnknownTicker = errors.New("unknown ticker")
+
+// ResolveCIK returns the CIK, as stored in data_subs, of a company given
+// either its CIK (with or without leading zeros) or its ticker
+func ResolveCIK(db *gorm.DB, company string) (string, error) {
+	company = strings.TrimSpace(company)
+	if _, err := strconv.Atoi(company); err == nil {
+		return strings.TrimLeft(company, "0"), nil
+	}
+	ciks := []string{}
+	err := db.Table("data_tickers").
+		Where("ticker = ?", strings.ToUpper(company)).
+		Pluck("cik", &ciks).Error
+	if err != nil {
+		return "", err
+	}
+	if len(ciks) == 0 {
+		return "", fmt.Errorf("%w `%s`", ErrUnknownTicker, company)
+	}
+	return ciks[0], nil
+}
+
+// CompanyMatch is a company found by SearchCompanies
+type CompanyMatch struct {
+	Cik  string
+	Name string
+
+	// Tickers of the company, if known
+	Tickers []string `gorm:"-"`
+
+	// Count of the submissions of the company
+	Filings int
+}
+
+// SearchCompanies returns the companies with submissions whose name contains
+// text, whose ticker starts with it or whose CIK is text, up to limit, the
+// companies with the most submissions first. Every company if text is empty.
+func SearchCompanies(db *gorm.DB, text string, limit int) ([]CompanyMatch, error) {
+	text = strings.TrimSpace(text)
+	escape := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace
+	like, prefix := "%"+escape(strings.ToLower(text))+"%", escape(strings.ToUpper(text))+"%"
+	// company_tickers is there once submissions.zip is loaded
+	tickers := "SELECT cik, ticker FROM data_tickers"
+	if db.Migrator().HasTable("company_tickers") {
+		tickers += " UNION SELECT cik, ticker FROM company_tickers"
+	}
+	matches := []CompanyMatch{}
+	err := db.Raw(`SELECT data_subs.cik, MAX(data_subs.name) AS name, COUNT(*) AS filings
+		FROM data_subs
+		WHERE LOWER(data_subs.name) LIKE ? ESCAPE '\' OR data_subs.cik = ?
+			OR data_subs.cik IN (SELECT cik FROM (`+tickers+`) WHERE ticker LIKE ? ESCAPE '\')
+		GROUP BY data_subs.cik
+		ORDER BY filings DESC, name
+		LIMIT ?`, like, strings.TrimLeft(text, "0"), prefix, limit).Scan(&matches).Error
+	if err != nil || len(matches) == 0 {
+		return matches, err
+	}
+
+	ciks := []string{}
+	for _, m := range matches {
+		ciks = append(ciks, m.Cik)
+	}
+	found := []struct {
+		Cik    string
+		Ticker string
+	}{}
+	err = db.Raw(`SELECT DISTINCT cik, ticker FROM (`+tickers+`) WHERE cik IN ? ORDER BY ticker`, ciks).Scan(&found).Error
+	if err != nil {
+		return nil, err
+	}
+	byCik := map[string][]string{}
+	for _, t := range found {
+		byCik[t.Cik] = append(byCik[t.Cik], t.Ticker)
+	}
+	for i := range matches {
+		matches[i].Tickers = byCik[matches[i].Cik]
+	}
+	return matches, nil
+}
@@ -0,0 +1,43 @@
+package query
+
+import (
+	"time"
+)
+
+// FiscalQuarter returns the number of quarters elapsed in the fiscal year at
+// the fiscal period focus fp
+func FiscalQuarter(fp string) int {
+	switch fp {
+	case "Q1":
+		return 1
+	case "Q2", "H1":
+		return 2
+	case "Q3", "M9":
+		return 3
+	}
+	return 4
+}
+
+// MonthEnd rounds a yyyymmdd date to the nearest month end, the way ddate is
+func MonthEnd(date string) string {
+	t, err := time.Parse("20060102", date)
+	if err != nil {
+		return date
+	}
+	if t.Day() < 15 {
+		t = t.AddDate(0, 0, -t.Day())
+	} else {
+		t = time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	}
+	return t.Format("20060102")
+}
+
+// ShiftQuarters moves a month end ddate by n quarters
+func ShiftQuarters(ddate string, n int) string {
+	t, err := time.Parse("20060102", ddate)
+	if err != nil {
+		return ddate
+	}
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 3*n+1, -1)
+	return first.Format("20060102")
+}
@@ -0,0 +1,33 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ResolveDimh returns the dimh of a dimension given either its 32-byte
+// hexadecimal key or its segments, e.g. "ProductOrService=Advertising;".
+// An empty dimension is NoDimensions.
+func ResolveDimh(db *gorm.DB, dim string) (string, error) {
+	dim = strings.TrimSpace(dim)
+	if dim == "" {
+		return NoDimensions, nil
+	}
+	if strings.HasPrefix(dim, "0x") {
+		return dim, nil
+	}
+	if !strings.HasSuffix(dim, ";") {
+		dim += ";"
+	}
+	dimhs := []string{}
+	err := db.Table("data_dims").Where("segments = ?", dim).Limit(1).Pluck("dimh", &dimhs).Error
+	if err != nil {
+		return "", err
+	}
+	if len(dimhs) == 0 {
+		return "", fmt.Errorf("unknown dimension `%s`", dim)
+	}
+	return dimhs[0], nil
+}
@@ -0,0 +1,77 @@
+package query
+
+import (
+	"sort"
+
+	"eswiac.me/filingsdb/models"
+	"gorm.io/gorm"
+)
+
+// FactDetail is what a submission tells about a tag: its definition, and
+// its facts with their dimensions and footnotes
+type FactDetail struct {
+	// The tag, nil if data_tags doesn't have it
+	Tag *models.DataTAG
+
+	Nums []DimensionalNUM
+	Txts []models.DataTXT
+}
+
+// DimensionalNUM is a numeric fact with the segments of its dimension
+type DimensionalNUM struct {
+	models.DataNUM
+
+	// Segments of the dimension, empty for NoDimensions
+	Segments string
+}
+
+// GetFactDetail returns the definition of the tag of version and its facts
+// in the submission adsh, every dimension and priority included
+func GetFactDetail(db *gorm.DB, adsh string, tag string, version string) (*FactDetail, error) {
+	d := &FactDetail{}
+	tags := []models.DataTAG{}
+	if err := db.Where("tag = ? AND version = ?", tag, version).Limit(1).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		d.Tag = &tags[0]
+	}
+
+	nums := []models.DataNUM{}
+	if err := db.Where("adsh = ? AND tag = ? AND version = ?", adsh, tag, version).Order("ddate DESC, qtrs DESC, iprx").Find(&nums).Error; err != nil {
+		return nil, err
+	}
+	dimhs := []string{}
+	for _, n := range nums {
+		dimhs = append(dimhs, n.Dimh)
+	}
+	segments := map[string]string{}
+	if err := inChunks(distinct(dimhs), func(chunk []string) error {
+		found := []models.DataDIM{}
+		err := db.Where("dimh IN ?", chunk).Find(&found).Error
+		for _, dim := range found {
+			segments[dim.Dimh] = dim.Segments
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	for _, n := range nums {
+		d.Nums = append(d.Nums, DimensionalNUM{DataNUM: n, Segments: segments[n.Dimh]})
+	}
+	// the consolidated entity first
+	sort.SliceStable(d.Nums, func(i, j int) bool {
+		return d.Nums[i].Segments == "" && d.Nums[j].Segments != ""
+	})
+
+	txts, err := Texts(db, adsh, []string{tag})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range txts {
+		if t.Version == version {
+			d.Txts = append(d.Txts, t)
+		}
+	}
+	return d, nil
+}
@@ -0,0 +1,139 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"gorm.io/gorm"
+)
+
+// Filing is everything the data sets tell about the facts of a submission
+type Filing struct {
+	Sub  models.DataSUB
+	Nums []models.DataNUM
+	Txts []models.DataTXT
+
+	// Dimensions of the facts, by dimh
+	Dims map[string]models.DataDIM
+
+	/**
+	Tags of the facts, and the axes and members of their
+	dimensions (whose names dim.tsv shortens), by tag.
+	The custom tags of the submission come first, then
+	the standard ones.
+	*/
+	Tags map[string]models.DataTAG
+}
+
+// GetFiling returns the facts of the submission adsh, every priority (iprx)
+// included, with their dimensions and tags
+func GetFiling(db *gorm.DB, adsh string) (*Filing, error) {
+	subs := []models.DataSUB{}
+	if err := db.Where("adsh = ?", adsh).Limit(1).Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf("unknown submission %v", adsh)
+	}
+	f := &Filing{Sub: subs[0], Dims: map[string]models.DataDIM{}, Tags: map[string]models.DataTAG{}}
+	if err := db.Where("adsh = ?", adsh).Order("tag, ddate, qtrs, dimh, iprx").Find(&f.Nums).Error; err != nil {
+		return nil, err
+	}
+	txts, err := Texts(db, adsh, nil)
+	if err != nil {
+		return nil, err
+	}
+	f.Txts = txts
+
+	dimhs, names := []string{}, map[string]bool{}
+	for _, n := range f.Nums {
+		dimhs = append(dimhs, n.Dimh)
+		names[n.Tag] = true
+	}
+	for _, t := range f.Txts {
+		dimhs = append(dimhs, t.Dimh)
+		names[t.Tag] = true
+	}
+	dims := []models.DataDIM{}
+	if err := inChunks(distinct(dimhs), func(chunk []string) error {
+		found := []models.DataDIM{}
+		err := db.Where("dimh IN ?", chunk).Find(&found).Error
+		dims = append(dims, found...)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	for _, dim := range dims {
+		f.Dims[dim.Dimh] = dim
+		for _, name := range SegmentTagNames(dim.Segments) {
+			names[name] = true
+		}
+	}
+
+	tagNames := []string{}
+	for name := range names {
+		tagNames = append(tagNames, name)
+	}
+	tags := []models.DataTAG{}
+	if err := inChunks(tagNames, func(chunk []string) error {
+		found := []models.DataTAG{}
+		err := db.Where("tag IN ? AND (version = ? OR custom = ?)", chunk, adsh, false).Order("version DESC").Find(&found).Error
+		tags = append(tags, found...)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		if existing, ok := f.Tags[t.Tag]; !ok || !existing.Custom && t.Custom {
+			f.Tags[t.Tag] = t
+		}
+	}
+	return f, nil
+}
+
+// SegmentTagNames returns the names the axes and members of segments may
+// have had before dim.tsv shortened them, e.g. ProductOrServiceAxis and
+// StatementProductOrServiceAxis for ProductOrService
+func SegmentTagNames(segments string) []string {
+	names := []string{}
+	for _, pair := range strings.Split(segments, ";") {
+		i := strings.Index(pair, "=")
+		if i < 0 {
+			continue
+		}
+		axis, member := pair[:i], pair[i+1:]
+		names = append(names, axis+"Axis", "Statement"+axis+"Axis", member, member+"Member", member+"Domain", "Statement"+member+"Member")
+	}
+	return names
+}
+
+// inChunks calls fn with the values, chunkSize at a time not to exceed the
+// number of variables of a statement
+func inChunks(values []string, fn func([]string) error) error {
+	for i := 0; i < len(values); i += chunkSize {
+		end := i + chunkSize
+		if end > len(values) {
+			end = len(values)
+		}
+		if err := fn(values[i:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chunkSize is the number of values of the IN clauses of inChunks
+const chunkSize = 500
+
+func distinct(values []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
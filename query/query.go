@@ -0,0 +1,202 @@
+package query
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// NoDimensions is the dimh of facts reported without any dimension segment
+const NoDimensions = "0x00000000"
+
+// Filter restricts which submissions the fact queries read from
+type Filter struct {
+	/**
+	If set, only submissions accepted at or before AsOf are
+	considered, so results reflect what was publicly known at
+	that moment. See ParseAsOf.
+	*/
+	AsOf time.Time
+}
+
+// Scope applies the filter to a query joining data_subs
+func (f Filter) Scope(tx *gorm.DB) *gorm.DB {
+	if !f.AsOf.IsZero() {
+		tx = tx.Where("data_subs.accepted <= ?", AcceptedCutoff(f.AsOf))
+	}
+	return tx
+}
+
+// Fact is a numeric fact along with the submission that reported it
+type Fact struct {
+	Adsh     string
+	Cik      string
+	Form     string
+	Accepted string
+	Tag      string
+	Version  string
+	Ddate    string
+	Qtrs     int
+	Uom      string
+	Dimh     string
+	Iprx     int
+	Value    *decimal.Decimal
+	Coreg    *string
+}
+
+// FactQuery selects numeric facts of a single company
+type FactQuery struct {
+	Filter
+
+	// Central Index Key of the company
+	Cik string
+
+	// Accession Number of a single submission, all of them if empty
+	Adsh string
+
+	// Tags to select, all of them if empty
+	Tags []string
+
+	// Unit of measure, any if empty
+	Uom string
+
+	/**
+	Dimensional key of the facts. Defaults to NoDimensions,
+	i.e. the consolidated entity without any segment.
+	*/
+	Dimh string
+
+	// Include facts with iprx greater than 1
+	AllPriorities bool
+}
+
+const factColumns = "data_nums.adsh, data_subs.cik, data_subs.form, data_subs.accepted, " +
+	"data_nums.tag, data_nums.version, data_nums.ddate, data_nums.qtrs, data_nums.uom, " +
+	"data_nums.dimh, data_nums.iprx, data_nums.value, data_nums.coreg"
+
+// Facts returns every fact matching q, across all the submissions of the
+// company, oldest accepted first
+func Facts(db *gorm.DB, q FactQuery) ([]Fact, error) {
+	dimh := q.Dimh
+	if dimh == "" {
+		dimh = NoDimensions
+	}
+	tx := db.Table("data_nums").
+		Select(factColumns).
+		Joins("JOIN data_subs ON data_subs.adsh = data_nums.adsh").
+		Where("data_subs.cik = ?", q.Cik).
+		Where("data_nums.dimh = ?", dimh).
+		Scopes(q.Filter.Scope)
+	if len(q.Tags) > 0 {
+		tx = tx.Where("data_nums.tag IN ?", q.Tags)
+	}
+	if q.Adsh != "" {
+		tx = tx.Where("data_nums.adsh = ?", q.Adsh)
+	}
+	if q.Uom != "" {
+		tx = tx.Where("data_nums.uom = ?", q.Uom)
+	}
+	if !q.AllPriorities {
+		tx = tx.Where("data_nums.iprx = 1")
+	}
+	if dimh == NoDimensions {
+		tx = tx.Where("data_nums.coreg IS NULL")
+	}
+
+	facts := []Fact{}
+	err := tx.Order("data_subs.accepted, data_nums.adsh").Scan(&facts).Error
+	return facts, err
+}
+
+type factKey struct {
+	tag   string
+	ddate string
+	qtrs  int
+	uom   string
+	dimh  string
+	coreg string
+	iprx  int
+}
+
+func keyOf(f Fact) factKey {
+	k := factKey{tag: f.Tag, ddate: f.Ddate, qtrs: f.Qtrs, uom: f.Uom, dimh: f.Dimh, iprx: f.Iprx}
+	if f.Coreg != nil {
+		k.coreg = *f.Coreg
+	}
+	return k
+}
+
+// Latest keeps, for each fact reported by several submissions (e.g. the
+// comparative periods of a 10-K or an amendment), the value of the most
+// recently accepted one. facts must be sorted oldest accepted first, as
+// returned by Facts. The result is sorted by tag, period and duration.
+func Latest(facts []Fact) []Fact {
+	latest := map[factKey]Fact{}
+	for _, f := range facts {
+		latest[keyOf(f)] = f
+	}
+	out := make([]Fact, 0, len(latest))
+	for _, f := range latest {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		if a.Ddate != b.Ddate {
+			return a.Ddate < b.Ddate
+		}
+		if a.Qtrs != b.Qtrs {
+			return a.Qtrs < b.Qtrs
+		}
+		if a.Uom != b.Uom {
+			return a.Uom < b.Uom
+		}
+		return a.Iprx < b.Iprx
+	})
+	return out
+}
+
+// Series returns one value per period for tag, as known at q.AsOf
+func Series(db *gorm.DB, q FactQuery, tag string) ([]Fact, error) {
+	q.Tags = []string{tag}
+	facts, err := Facts(db, q)
+	if err != nil {
+		return nil, err
+	}
+	return Latest(facts), nil
+}
+
+// Fundamentals returns the most recent value of each tag for every duration
+// it was reported with, as known at q.AsOf
+func Fundamentals(db *gorm.DB, q FactQuery) ([]Fact, error) {
+	facts, err := Facts(db, q)
+	if err != nil {
+		return nil, err
+	}
+	type tagQtrs struct {
+		tag  string
+		qtrs int
+		uom  string
+	}
+	recent := map[tagQtrs]Fact{}
+	order := []tagQtrs{}
+	for _, f := range Latest(facts) {
+		k := tagQtrs{f.Tag, f.Qtrs, f.Uom}
+		prev, ok := recent[k]
+		if !ok {
+			order = append(order, k)
+		}
+		if !ok || f.Ddate >= prev.Ddate {
+			recent[k] = f
+		}
+	}
+	out := make([]Fact, 0, len(order))
+	for _, k := range order {
+		out = append(out, recent[k])
+	}
+	return out, nil
+}
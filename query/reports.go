@@ -0,0 +1,316 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"eswiac.me/filingsdb/models"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// MenuCategories are the menu categories of the reports, in the order of
+// the EDGAR viewer, with their names
+var MenuCategories = []struct {
+	Menucat string
+	Name    string
+}{
+	{"C", "Cover"},
+	{"S", "Financial Statements"},
+	{"N", "Notes to Financial Statements"},
+	{"P", "Accounting Policies"},
+	{"T", "Notes Tables"},
+	{"D", "Notes Details"},
+	{"O", "Other"},
+	{"U", "Uncategorized"},
+}
+
+// ReportTree is the reports ("R files") of a filing as the EDGAR viewer
+// lists them, by menu category
+type ReportTree struct {
+	Sub        models.DataSUB
+	Categories []ReportCategory
+}
+
+// ReportCategory is a menu category of the reports of a filing
+type ReportCategory struct {
+	// C, S, N, P, T, D, O or U, the reports without a category being U
+	Menucat string
+	Name    string
+	Reports []*ReportNode
+}
+
+// ReportNode is a report of a filing with the reports of the same category
+// under it
+type ReportNode struct {
+	models.DataREN
+
+	// The short name of the parent report (the note of a policy, table or
+	// detail), empty if none
+	Parent string
+
+	Children []*ReportNode
+}
+
+// Reports returns the report tree of the submission adsh
+func Reports(db *gorm.DB, adsh string) (*ReportTree, error) {
+	sub, err := submission(db, adsh)
+	if err != nil {
+		return nil, err
+	}
+	rens := []models.DataREN{}
+	if err := db.Where("adsh = ?", adsh).Find(&rens).Error; err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rens, func(i, j int) bool {
+		return reportNumber(rens[i].Report) < reportNumber(rens[j].Report)
+	})
+
+	nodes := map[string]*ReportNode{}
+	for _, ren := range rens {
+		nodes[ren.Report] = &ReportNode{DataREN: ren}
+	}
+	menucat := func(ren models.DataREN) string {
+		if ren.Menucat == nil || *ren.Menucat == "" {
+			return "U"
+		}
+		return *ren.Menucat
+	}
+	byMenucat := map[string][]*ReportNode{}
+	for _, ren := range rens {
+		node := nodes[ren.Report]
+		var parent *ReportNode
+		if ren.Parentreport != nil && *ren.Parentreport != ren.Report {
+			parent = nodes[*ren.Parentreport]
+		}
+		if parent != nil {
+			node.Parent = parent.Shortname
+		}
+		if parent != nil && menucat(parent.DataREN) == menucat(ren) {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		byMenucat[menucat(ren)] = append(byMenucat[menucat(ren)], node)
+	}
+
+	tree := &ReportTree{Sub: sub}
+	for _, c := range MenuCategories {
+		if len(byMenucat[c.Menucat]) > 0 {
+			tree.Categories = append(tree.Categories, ReportCategory{Menucat: c.Menucat, Name: c.Name, Reports: byMenucat[c.Menucat]})
+		}
+	}
+	return tree, nil
+}
+
+// Report is a report of a filing: its presentation lines with the values
+// the filing reports for them, in columns
+type Report struct {
+	Sub     models.DataSUB
+	Ren     models.DataREN
+	Columns []ReportColumn
+	Lines   []ReportLine
+}
+
+// ReportColumn is a period, and dimension, of the values of a report
+type ReportColumn struct {
+	Ddate string
+	Qtrs  int
+	Dimh  string
+
+	// Segments of the dimension, empty for NoDimensions
+	Segments string
+}
+
+// Period returns the period of the column the way the EDGAR viewer puts it,
+// e.g. 12 Months Ended Dec. 31, 2018
+func (c ReportColumn) Period() string {
+	ddate, err := time.Parse("20060102", c.Ddate)
+	if err != nil {
+		return c.Ddate
+	}
+	date := ddate.Format("Jan. 2, 2006")
+	if ddate.Month() == time.May {
+		date = ddate.Format("Jan 2, 2006")
+	}
+	if c.Qtrs == 0 {
+		return date
+	}
+	return fmt.Sprintf("%d Months Ended %s", 3*c.Qtrs, date)
+}
+
+// ReportLine is a presentation line of a report
+type ReportLine struct {
+	Line     int
+	Tag      string
+	Version  string
+	Plabel   string
+	Negating bool
+
+	// Parenthetical, presented along with another line
+	Inpth bool
+
+	// The values of the line, one by column, nil where none is reported
+	Values []*ReportValue
+
+	// The text facts of the tag without dimensions, the text blocks of
+	// the notes
+	Texts []models.DataTXT
+}
+
+// ReportValue is a numeric fact of a report
+type ReportValue struct {
+	Value *decimal.Decimal
+	Uom   string
+}
+
+// FilingReport returns the report of the submission adsh, given its number
+// (4 or R4), with its values. Dimensional values are in columns of their
+// own, only if the report presents all their axes.
+func FilingReport(db *gorm.DB, adsh string, report string) (*Report, error) {
+	sub, err := submission(db, adsh)
+	if err != nil {
+		return nil, err
+	}
+	number := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(report)), "R")
+	rens := []models.DataREN{}
+	if err := db.Where("adsh = ? AND report = ?", adsh, number).Limit(1).Find(&rens).Error; err != nil {
+		return nil, err
+	}
+	if len(rens) == 0 {
+		return nil, fmt.Errorf("no report %s in submission %s", report, adsh)
+	}
+	r := &Report{Sub: sub, Ren: rens[0]}
+
+	pres := []models.DataPRE{}
+	if err := db.Where("adsh = ? AND report = ?", adsh, reportNumber(number)).Order("line").Find(&pres).Error; err != nil {
+		return nil, err
+	}
+	tags, axes := []string{}, map[string]bool{}
+	for _, pre := range pres {
+		tags = append(tags, pre.Tag)
+		if strings.HasSuffix(pre.Tag, "Axis") {
+			axes[strings.TrimPrefix(strings.TrimSuffix(pre.Tag, "Axis"), "Statement")] = true
+		}
+	}
+
+	nums := []models.DataNUM{}
+	if err := inChunks(distinct(tags), func(chunk []string) error {
+		found := []models.DataNUM{}
+		err := db.Where("adsh = ? AND tag IN ? AND coreg IS NULL", adsh, chunk).Order("iprx").Find(&found).Error
+		nums = append(nums, found...)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	dimhs := []string{}
+	for _, n := range nums {
+		if n.Dimh != NoDimensions {
+			dimhs = append(dimhs, n.Dimh)
+		}
+	}
+	segments := map[string]string{NoDimensions: ""}
+	if err := inChunks(distinct(dimhs), func(chunk []string) error {
+		found := []models.DataDIM{}
+		err := db.Where("dimh IN ?", chunk).Find(&found).Error
+		for _, dim := range found {
+			segments[dim.Dimh] = dim.Segments
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	// presented reports the values of dimensions whose axes are all on
+	// the report
+	presented := func(dimh string) bool {
+		s, ok := segments[dimh]
+		if !ok {
+			return false
+		}
+		for _, pair := range strings.Split(s, ";") {
+			if i := strings.Index(pair, "="); i >= 0 && !axes[pair[:i]] {
+				return false
+			}
+		}
+		return true
+	}
+
+	columns := map[ReportColumn]bool{}
+	values := map[string]map[ReportColumn]*ReportValue{}
+	for _, n := range nums {
+		if !presented(n.Dimh) {
+			continue
+		}
+		c := ReportColumn{Ddate: n.Ddate, Qtrs: n.Qtrs, Dimh: n.Dimh, Segments: segments[n.Dimh]}
+		if values[n.Tag] == nil {
+			values[n.Tag] = map[ReportColumn]*ReportValue{}
+		}
+		if _, ok := values[n.Tag][c]; ok {
+			continue // a lower iprx came first
+		}
+		values[n.Tag][c] = &ReportValue{Value: n.Value, Uom: n.Uom}
+		columns[c] = true
+	}
+	for c := range columns {
+		r.Columns = append(r.Columns, c)
+	}
+	sort.Slice(r.Columns, func(i, j int) bool {
+		a, b := r.Columns[i], r.Columns[j]
+		if a.Segments != b.Segments {
+			return a.Segments < b.Segments
+		}
+		if a.Qtrs != b.Qtrs {
+			return a.Qtrs > b.Qtrs
+		}
+		return a.Ddate > b.Ddate
+	})
+
+	txts, err := Texts(db, adsh, distinct(tags))
+	if err != nil {
+		return nil, err
+	}
+	texts := map[string][]models.DataTXT{}
+	for _, t := range txts {
+		if t.Dimh == NoDimensions && t.Value != nil {
+			texts[t.Tag] = append(texts[t.Tag], t)
+		}
+	}
+
+	for _, pre := range pres {
+		line := ReportLine{
+			Line:     pre.Line,
+			Tag:      pre.Tag,
+			Version:  pre.Version,
+			Plabel:   pre.Plabel,
+			Negating: pre.Negating,
+			Inpth:    pre.Inpth == "1",
+			Texts:    texts[pre.Tag],
+		}
+		for _, c := range r.Columns {
+			line.Values = append(line.Values, values[pre.Tag][c])
+		}
+		r.Lines = append(r.Lines, line)
+	}
+	return r, nil
+}
+
+// submission returns the submission adsh
+func submission(db *gorm.DB, adsh string) (models.DataSUB, error) {
+	subs := []models.DataSUB{}
+	if err := db.Where("adsh = ?", adsh).Limit(1).Find(&subs).Error; err != nil {
+		return models.DataSUB{}, err
+	}
+	if len(subs) == 0 {
+		return models.DataSUB{}, fmt.Errorf("unknown submission %v", adsh)
+	}
+	return subs[0], nil
+}
+
+// reportNumber returns the number of the report field of ren.tsv, 0 if
+// not a number
+func reportNumber(report string) int {
+	n, _ := strconv.Atoi(report)
+	return n
+}
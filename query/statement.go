@@ -0,0 +1,163 @@
+package query
+
+import (
+	"fmt"
+
+	"eswiac.me/filingsdb/models"
+	"gorm.io/gorm"
+)
+
+// StatementForms are the periodic reports statements are read from by default
+var StatementForms = []string{"10-K", "10-Q", "20-F", "40-F"}
+
+// Statement is a financial statement as presented in a filing
+type Statement struct {
+	// The filing the statement was presented in
+	Sub models.DataSUB
+
+	/**
+	The financial statement (BS = Balance Sheet, IS = Income Statement,
+	CF = Cash Flow, EQ = Equity, CI = Comprehensive Income, ...)
+	*/
+	Stmt string
+
+	// The "R file" report of the statement
+	Report int
+
+	Lines []StatementLine
+}
+
+// StatementLine is a line item of a statement with its values for each
+// period reported in the filing
+type StatementLine struct {
+	Line     int
+	Tag      string
+	Version  string
+	Plabel   string
+	Negating bool
+	Facts    []Fact
+}
+
+// StatementQuery selects the statement of the most recent filing of a company
+type StatementQuery struct {
+	Filter
+
+	// Central Index Key of the company
+	Cik string
+
+	// Financial statement, e.g. BS, IS or CF
+	Stmt string
+
+	// Forms to pick the filing from, StatementForms if empty
+	Forms []string
+}
+
+// LatestStatement returns stmt as presented in the most recent filing of the
+// company accepted at q.AsOf. Values come from the most recent submission
+// reporting them at that moment, so later amendments or comparative periods
+// of subsequent filings supersede the original values.
+func LatestStatement(db *gorm.DB, q StatementQuery) (*Statement, error) {
+	forms := q.Forms
+	if len(forms) == 0 {
+		forms = StatementForms
+	}
+	subs := []models.DataSUB{}
+	err := db.Table("data_subs").
+		Where("data_subs.cik = ?", q.Cik).
+		Where("data_subs.form IN ?", forms).
+		Where("EXISTS (SELECT 1 FROM data_pres WHERE data_pres.adsh = data_subs.adsh AND data_pres.stmt = ?)", q.Stmt).
+		Scopes(q.Filter.Scope).
+		Order("data_subs.accepted DESC").
+		Limit(1).
+		Find(&subs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf("no %v statement filed by cik %v", q.Stmt, q.Cik)
+	}
+	return statementOf(db, subs[0], q.Stmt, q.Filter)
+}
+
+// FilingStatement returns stmt as presented in the submission adsh, with
+// the values known at filter.AsOf
+func FilingStatement(db *gorm.DB, adsh string, stmt string, filter Filter) (*Statement, error) {
+	subs := []models.DataSUB{}
+	err := db.Where("adsh = ?", adsh).Limit(1).Find(&subs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf("unknown submission %v", adsh)
+	}
+	return statementOf(db, subs[0], stmt, filter)
+}
+
+func statementOf(db *gorm.DB, sub models.DataSUB, stmt string, filter Filter) (*Statement, error) {
+	// the periods presented come from the filing itself, which must have
+	// been known at filter.AsOf
+	if !filter.AsOf.IsZero() && sub.Accepted > AcceptedCutoff(filter.AsOf) {
+		return nil, fmt.Errorf("submission %v was accepted at %v, after %v", sub.Adsh, sub.Accepted, AcceptedCutoff(filter.AsOf))
+	}
+	pres := []models.DataPRE{}
+	err := db.Where("adsh = ? AND stmt = ? AND inpth = '0'", sub.Adsh, stmt).
+		Order("report, line").
+		Find(&pres).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(pres) == 0 {
+		return nil, fmt.Errorf("no %v statement in submission %v", stmt, sub.Adsh)
+	}
+
+	// a filing may split a statement into several reports, keep the first one
+	report := pres[0].Report
+	tags := []string{}
+	for _, pre := range pres {
+		if pre.Report == report {
+			tags = append(tags, pre.Tag)
+		}
+	}
+
+	// periods presented in the filing itself
+	own, err := Facts(db, FactQuery{Cik: sub.Cik, Adsh: sub.Adsh, Tags: tags})
+	if err != nil {
+		return nil, err
+	}
+	type period struct {
+		tag   string
+		ddate string
+		qtrs  int
+	}
+	presented := map[period]bool{}
+	for _, f := range own {
+		presented[period{f.Tag, f.Ddate, f.Qtrs}] = true
+	}
+
+	known, err := Facts(db, FactQuery{Filter: filter, Cik: sub.Cik, Tags: tags})
+	if err != nil {
+		return nil, err
+	}
+	values := map[string][]Fact{}
+	for _, f := range Latest(known) {
+		if presented[period{f.Tag, f.Ddate, f.Qtrs}] {
+			values[f.Tag] = append(values[f.Tag], f)
+		}
+	}
+
+	st := &Statement{Sub: sub, Stmt: stmt, Report: report}
+	for _, pre := range pres {
+		if pre.Report != report {
+			continue
+		}
+		st.Lines = append(st.Lines, StatementLine{
+			Line:     pre.Line,
+			Tag:      pre.Tag,
+			Version:  pre.Version,
+			Plabel:   pre.Plabel,
+			Negating: pre.Negating,
+			Facts:    values[pre.Tag],
+		})
+	}
+	return st, nil
+}
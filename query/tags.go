@@ -0,0 +1,172 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"gorm.io/gorm"
+)
+
+// TagQuery searches data_tags
+type TagQuery struct {
+	/**
+	Text searched in the names, labels and
+	documentation of the tags, case insensitive.
+	Every tag if empty.
+	*/
+	Text string
+
+	/**
+	Only custom tags if true, only standard ones
+	if false, both if nil.
+	*/
+	Custom *bool
+
+	/**
+	Taxonomy version, e.g. us-gaap/2019 or the adsh of
+	custom tags, or a taxonomy for all its versions,
+	e.g. us-gaap. Any if empty.
+	*/
+	Version string
+
+	/**
+	Order of the tags: "usage" (the most used first)
+	or "tag" (by name), the default.
+	*/
+	Sort string
+
+	// Page of the tags, all of them if Limit is 0
+	Limit  int
+	Offset int
+}
+
+// TagUsage is a tag with the count of filings and companies reporting
+// facts of it
+type TagUsage struct {
+	models.DataTAG
+	Filings   int
+	Companies int
+}
+
+// TagResult is a page of tags matching a TagQuery
+type TagResult struct {
+	Tags []TagUsage
+
+	// Count of the tags matching, across all pages
+	Total int
+}
+
+// Tags returns the tags matching q, with their usage in the numeric and
+// text facts of the database
+func Tags(db *gorm.DB, q TagQuery) (*TagResult, error) {
+	if q.Sort != "" && q.Sort != "usage" && q.Sort != "tag" {
+		return nil, fmt.Errorf("unknown sort `%s`, expected usage or tag", q.Sort)
+	}
+	filter := func(tx *gorm.DB) *gorm.DB {
+		if text := strings.TrimSpace(q.Text); text != "" {
+			like := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.ToLower(text)) + "%"
+			tx = tx.Where(`(LOWER(tag) LIKE ? ESCAPE '\' OR LOWER(tlabel) LIKE ? ESCAPE '\' OR LOWER(doc) LIKE ? ESCAPE '\')`, like, like, like)
+		}
+		if q.Custom != nil {
+			tx = tx.Where("custom = ?", *q.Custom)
+		}
+		if q.Version != "" {
+			// us-gaap/2019 or the adsh of custom tags, us-gaap for all its
+			// versions and the tags of companyfacts.zip, versioned by
+			// taxonomy only
+			if strings.Contains(q.Version, "/") || q.Version[0] >= '0' && q.Version[0] <= '9' {
+				tx = tx.Where("version = ?", q.Version)
+			} else {
+				tx = tx.Where("(version = ? OR version LIKE ?)", q.Version, q.Version+"/%")
+			}
+		}
+		return tx
+	}
+
+	// by name, the page is read from the database
+	if q.Sort != "usage" {
+		var total int64
+		if err := db.Model(&models.DataTAG{}).Scopes(filter).Count(&total).Error; err != nil {
+			return nil, err
+		}
+		tx := db.Scopes(filter).Order("tag, version")
+		if q.Limit > 0 {
+			tx = tx.Limit(q.Limit)
+		} else if q.Offset > 0 {
+			tx = tx.Limit(math.MaxInt32) // sqlite only takes an offset along with a limit
+		}
+		tags := []models.DataTAG{}
+		if err := tx.Offset(q.Offset).Find(&tags).Error; err != nil {
+			return nil, err
+		}
+		return withUsage(db, tags, int(total))
+	}
+
+	// by usage, that of every matching tag is needed
+	tags := []models.DataTAG{}
+	if err := db.Scopes(filter).Order("tag, version").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	result, err := withUsage(db, tags, len(tags))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(result.Tags, func(i, j int) bool {
+		return result.Tags[i].Filings > result.Tags[j].Filings
+	})
+	start, end := q.Offset, len(result.Tags)
+	if start > end {
+		start = end
+	}
+	if q.Limit > 0 && start+q.Limit < end {
+		end = start + q.Limit
+	}
+	result.Tags = result.Tags[start:end]
+	return result, nil
+}
+
+// withUsage returns tags along with their usage, total being the count of
+// the tags matching across all pages
+func withUsage(db *gorm.DB, tags []models.DataTAG, total int) (*TagResult, error) {
+	usage, err := tagUsage(db, tags)
+	if err != nil {
+		return nil, err
+	}
+	result := &TagResult{Tags: []TagUsage{}, Total: total}
+	for _, t := range tags {
+		u := usage[t.Tag+"\t"+t.Version]
+		result.Tags = append(result.Tags, TagUsage{DataTAG: t, Filings: u.Filings, Companies: u.Companies})
+	}
+	return result, nil
+}
+
+// tagUsage returns the count of filings and companies with facts of each of
+// tags, by tag and version
+func tagUsage(db *gorm.DB, tags []models.DataTAG) (map[string]TagUsage, error) {
+	names := []string{}
+	for _, t := range tags {
+		names = append(names, t.Tag)
+	}
+	usage := map[string]TagUsage{}
+	err := inChunks(distinct(names), func(chunk []string) error {
+		rows := []struct {
+			Tag       string
+			Version   string
+			Filings   int
+			Companies int
+		}{}
+		err := db.Raw(`SELECT facts.tag, facts.version, COUNT(DISTINCT facts.adsh) AS filings, COUNT(DISTINCT data_subs.cik) AS companies
+			FROM (SELECT DISTINCT adsh, tag, version FROM data_nums WHERE tag IN ?
+				UNION SELECT DISTINCT adsh, tag, version FROM data_txts WHERE tag IN ?) AS facts
+			JOIN data_subs ON data_subs.adsh = facts.adsh
+			GROUP BY facts.tag, facts.version`, chunk, chunk).Scan(&rows).Error
+		for _, r := range rows {
+			usage[r.Tag+"\t"+r.Version] = TagUsage{Filings: r.Filings, Companies: r.Companies}
+		}
+		return err
+	})
+	return usage, err
+}
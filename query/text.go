@@ -0,0 +1,68 @@
+package query
+
+import (
+	"eswiac.me/filingsdb/models"
+	"gorm.io/gorm"
+)
+
+// Texts returns the text facts of the submission adsh, all of them if tags
+// is empty, with their values
+func Texts(db *gorm.DB, adsh string, tags []string) ([]models.DataTXT, error) {
+	tx := db.Where("adsh = ?", adsh)
+	if len(tags) > 0 {
+		tx = tx.Where("tag IN ?", tags)
+	}
+	txts := []models.DataTXT{}
+	if err := tx.Order("tag, ddate, qtrs, iprx").Find(&txts).Error; err != nil {
+		return nil, err
+	}
+	return txts, TextValues(db, txts)
+}
+
+// TextValues fills in the values of text facts stored compressed in
+// data_txt_values, i.e. the ones ingested with the compressed text policy.
+// Facts having their value in data_txts are left as is.
+func TextValues(db *gorm.DB, txts []models.DataTXT) error {
+	adshs := []string{}
+	seen := map[string]bool{}
+	for _, txt := range txts {
+		if txt.Value == nil && txt.Srclen > 0 && !seen[txt.Adsh] {
+			seen[txt.Adsh] = true
+			adshs = append(adshs, txt.Adsh)
+		}
+	}
+	if len(adshs) == 0 {
+		return nil
+	}
+	tags := []string{}
+	seenTags := map[string]bool{}
+	for _, txt := range txts {
+		if seen[txt.Adsh] && !seenTags[txt.Tag] {
+			seenTags[txt.Tag] = true
+			tags = append(tags, txt.Tag)
+		}
+	}
+	values := []models.DataTXTValue{}
+	err := db.Where("adsh IN ? AND tag IN ?", adshs, tags).Find(&values).Error
+	if err != nil {
+		return err
+	}
+	byTag := map[string][]models.DataTXTValue{}
+	for _, v := range values {
+		byTag[v.Adsh+v.Tag] = append(byTag[v.Adsh+v.Tag], v)
+	}
+	for i, txt := range txts {
+		if txt.Value != nil {
+			continue
+		}
+		for _, v := range byTag[txt.Adsh+txt.Tag] {
+			if v.Matches(txt) {
+				if txts[i].Value, err = v.Decompress(); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package query
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// Column is a column of a time series, the values of a tag in a given unit
+type Column struct {
+	// Name of the column, the tag suffixed by its unit if it's reported in several
+	Name string
+	Tag  string
+	Uom  string
+}
+
+// TimeSeriesRow holds the values of a single period, in the order of the columns
+type TimeSeriesRow struct {
+	Ddate  string
+	Qtrs   int
+	Values []*decimal.Decimal
+}
+
+// TimeSeries is a tidy table of facts with one row per period and one column
+// per tag
+type TimeSeries struct {
+	Cik     string
+	Columns []Column
+	Rows    []TimeSeriesRow
+}
+
+// GetTimeSeries returns the values of q.Tags over all the periods reported by the
+// company. A value reported by several filings (comparative periods,
+// amendments) is taken from the most recently accepted one, as known at q.AsOf.
+func GetTimeSeries(db *gorm.DB, q FactQuery) (*TimeSeries, error) {
+	facts, err := Facts(db, q)
+	if err != nil {
+		return nil, err
+	}
+	facts = Latest(facts)
+
+	tags := q.Tags
+	if len(tags) == 0 {
+		seen := map[string]bool{}
+		for _, f := range facts {
+			if !seen[f.Tag] {
+				seen[f.Tag] = true
+				tags = append(tags, f.Tag)
+			}
+		}
+	}
+	uoms := map[string][]string{}
+	for _, f := range facts {
+		if !contains(uoms[f.Tag], f.Uom) {
+			uoms[f.Tag] = append(uoms[f.Tag], f.Uom)
+		}
+	}
+
+	ts := &TimeSeries{Cik: q.Cik}
+	index := map[Column]int{}
+	for _, tag := range tags {
+		sort.Strings(uoms[tag])
+		for _, uom := range uoms[tag] {
+			col := Column{Name: tag, Tag: tag, Uom: uom}
+			if len(uoms[tag]) > 1 {
+				col.Name = tag + "_" + uom
+			}
+			index[Column{Tag: tag, Uom: uom}] = len(ts.Columns)
+			ts.Columns = append(ts.Columns, col)
+		}
+	}
+
+	type period struct {
+		ddate string
+		qtrs  int
+	}
+	rows := map[period]*TimeSeriesRow{}
+	iprx := map[period][]int{}
+	for _, f := range facts {
+		i, ok := index[Column{Tag: f.Tag, Uom: f.Uom}]
+		if !ok {
+			continue
+		}
+		p := period{f.Ddate, f.Qtrs}
+		row, ok := rows[p]
+		if !ok {
+			row = &TimeSeriesRow{Ddate: f.Ddate, Qtrs: f.Qtrs, Values: make([]*decimal.Decimal, len(ts.Columns))}
+			rows[p] = row
+			iprx[p] = make([]int, len(ts.Columns))
+		}
+		// keep the highest priority fact when iprx > 1 are included
+		if row.Values[i] == nil || f.Iprx < iprx[p][i] {
+			row.Values[i] = f.Value
+			iprx[p][i] = f.Iprx
+		}
+	}
+	for _, row := range rows {
+		ts.Rows = append(ts.Rows, *row)
+	}
+	sort.Slice(ts.Rows, func(i, j int) bool {
+		if ts.Rows[i].Ddate != ts.Rows[j].Ddate {
+			return ts.Rows[i].Ddate < ts.Rows[j].Ddate
+		}
+		return ts.Rows[i].Qtrs < ts.Rows[j].Qtrs
+	})
+	return ts, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package ratios
+
+import (
+	"strings"
+)
+
+// Concept is a canonical accounting concept reported by filers under one of
+// several tags. The first tag found is used.
+type Concept struct {
+	Name string
+	Tags []string
+
+	// Point in time (balance sheet) concept, as opposed to a duration
+	Instant bool
+}
+
+// Operand is a term of a ratio numerator or denominator
+type Operand struct {
+	Concept *Concept
+
+	// Subtract the operand instead of adding it
+	Negate bool
+
+	/**
+	Use the average of the balance at the start and at the
+	end of the period instead of the ending balance. Only
+	meaningful for instant concepts.
+	*/
+	Average bool
+
+	// A missing optional operand counts as zero instead of failing the ratio
+	Optional bool
+}
+
+// Ratio is a declarative ratio definition: the sum of the numerator operands
+// divided by the sum of the denominator operands
+type Ratio struct {
+	Name        string
+	Description string
+	Numerator   []Operand
+
+	// The value is the numerator alone if there's no denominator
+	Denominator []Operand
+
+	/**
+	Read duration concepts over the fiscal year to date (e.g. 2
+	quarters for a Q2 10-Q) instead of the fiscal quarter. Cash flow
+	statements are usually only reported year to date.
+	*/
+	YearToDate bool
+}
+
+var (
+	Revenue = &Concept{Name: "Revenue", Tags: []string{
+		"Revenues",
+		"RevenueFromContractWithCustomerExcludingAssessedTax",
+		"RevenueFromContractWithCustomerIncludingAssessedTax",
+		"SalesRevenueNet",
+		"SalesRevenueGoodsNet",
+		"SalesRevenueServicesNet",
+	}}
+	CostOfRevenue = &Concept{Name: "CostOfRevenue", Tags: []string{
+		"CostOfRevenue",
+		"CostOfGoodsAndServicesSold",
+		"CostOfGoodsSold",
+		"CostOfServices",
+	}}
+	GrossProfit = &Concept{Name: "GrossProfit", Tags: []string{
+		"GrossProfit",
+	}}
+	OperatingIncome = &Concept{Name: "OperatingIncome", Tags: []string{
+		"OperatingIncomeLoss",
+	}}
+	NetIncome = &Concept{Name: "NetIncome", Tags: []string{
+		"NetIncomeLoss",
+		"ProfitLoss",
+		"NetIncomeLossAvailableToCommonStockholdersBasic",
+	}}
+	OperatingCashFlow = &Concept{Name: "OperatingCashFlow", Tags: []string{
+		"NetCashProvidedByUsedInOperatingActivities",
+		"NetCashProvidedByUsedInOperatingActivitiesContinuingOperations",
+	}}
+	CapitalExpenditure = &Concept{Name: "CapitalExpenditure", Tags: []string{
+		"PaymentsToAcquirePropertyPlantAndEquipment",
+		"PaymentsToAcquireProductiveAssets",
+	}}
+	Assets = &Concept{Name: "Assets", Instant: true, Tags: []string{
+		"Assets",
+	}}
+	AssetsCurrent = &Concept{Name: "AssetsCurrent", Instant: true, Tags: []string{
+		"AssetsCurrent",
+	}}
+	LiabilitiesCurrent = &Concept{Name: "LiabilitiesCurrent", Instant: true, Tags: []string{
+		"LiabilitiesCurrent",
+	}}
+	Equity = &Concept{Name: "Equity", Instant: true, Tags: []string{
+		"StockholdersEquity",
+		"StockholdersEquityIncludingPortionAttributableToNoncontrollingInterest",
+	}}
+	LongTermDebt = &Concept{Name: "LongTermDebt", Instant: true, Tags: []string{
+		"LongTermDebt",
+		"LongTermDebtNoncurrent",
+	}}
+	ShortTermDebt = &Concept{Name: "ShortTermDebt", Instant: true, Tags: []string{
+		"DebtCurrent",
+		"LongTermDebtCurrent",
+		"ShortTermBorrowings",
+	}}
+)
+
+// Concepts are the canonical concepts the default ratios are defined over
+var Concepts = []*Concept{
+	Revenue,
+	CostOfRevenue,
+	GrossProfit,
+	OperatingIncome,
+	NetIncome,
+	OperatingCashFlow,
+	CapitalExpenditure,
+	Assets,
+	AssetsCurrent,
+	LiabilitiesCurrent,
+	Equity,
+	LongTermDebt,
+	ShortTermDebt,
+}
+
+// Ratios are the default ratio definitions
+var Ratios = []Ratio{
+	{
+		Name:        "GrossMargin",
+		Description: "(Revenue - Cost of revenue) / Revenue",
+		Numerator:   []Operand{{Concept: Revenue}, {Concept: CostOfRevenue, Negate: true}},
+		Denominator: []Operand{{Concept: Revenue}},
+	},
+	{
+		Name:        "OperatingMargin",
+		Description: "Operating income / Revenue",
+		Numerator:   []Operand{{Concept: OperatingIncome}},
+		Denominator: []Operand{{Concept: Revenue}},
+	},
+	{
+		Name:        "NetMargin",
+		Description: "Net income / Revenue",
+		Numerator:   []Operand{{Concept: NetIncome}},
+		Denominator: []Operand{{Concept: Revenue}},
+	},
+	{
+		Name:        "ROE",
+		Description: "Net income / Average equity",
+		Numerator:   []Operand{{Concept: NetIncome}},
+		Denominator: []Operand{{Concept: Equity, Average: true}},
+	},
+	{
+		Name:        "ROA",
+		Description: "Net income / Average total assets",
+		Numerator:   []Operand{{Concept: NetIncome}},
+		Denominator: []Operand{{Concept: Assets, Average: true}},
+	},
+	{
+		Name:        "CurrentRatio",
+		Description: "Current assets / Current liabilities",
+		Numerator:   []Operand{{Concept: AssetsCurrent}},
+		Denominator: []Operand{{Concept: LiabilitiesCurrent}},
+	},
+	{
+		Name:        "DebtToEquity",
+		Description: "(Long-term debt + Short-term debt) / Equity",
+		Numerator:   []Operand{{Concept: LongTermDebt}, {Concept: ShortTermDebt, Optional: true}},
+		Denominator: []Operand{{Concept: Equity}},
+	},
+	{
+		Name:        "FCF",
+		Description: "Operating cash flow - Capital expenditure, year to date",
+		Numerator:   []Operand{{Concept: OperatingCashFlow}, {Concept: CapitalExpenditure, Negate: true, Optional: true}},
+		YearToDate:  true,
+	},
+}
+
+// FindConcept returns the concept of Concepts named name, case insensitively
+func FindConcept(name string) *Concept {
+	for _, c := range Concepts {
+		if strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// FindRatio returns the ratio of Ratios named name, case insensitively
+func FindRatio(name string) *Ratio {
+	for i := range Ratios {
+		if strings.EqualFold(Ratios[i].Name, name) {
+			return &Ratios[i]
+		}
+	}
+	return nil
+}
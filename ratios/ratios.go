@@ -0,0 +1,260 @@
+package ratios
+
+import (
+	"fmt"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// Result is the value of a ratio for a submission
+type Result struct {
+	Name  string
+	Adsh  string
+	Cik   string
+	Ddate string
+	Qtrs  int
+
+	// nil if an input is missing or the denominator is zero
+	Value *decimal.Decimal
+
+	// The inputs that could not be found, as concept@ddate
+	Missing []string
+}
+
+// Model converts the result to the row materialized in the ratios table
+func (r Result) Model() models.Ratio {
+	ratio := models.Ratio{Adsh: r.Adsh, Cik: r.Cik, Name: r.Name, Ddate: r.Ddate, Qtrs: r.Qtrs, Value: r.Value}
+	if len(r.Missing) > 0 {
+		missing := strings.Join(r.Missing, ",")
+		ratio.Missing = &missing
+	}
+	return ratio
+}
+
+// Tags returns every tag the given ratios may read
+func Tags(ratios []Ratio) []string {
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, r := range ratios {
+		for _, op := range append(append([]Operand{}, r.Numerator...), r.Denominator...) {
+			for _, tag := range op.Concept.Tags {
+				if !seen[tag] {
+					seen[tag] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+	return tags
+}
+
+type factKey struct {
+	tag   string
+	ddate string
+	qtrs  int
+}
+
+// Values are the values of the facts known at a given time, by unit of measure
+type Values map[factKey]map[string]decimal.Decimal
+
+// KnownValues returns the values of the facts accepted at or before
+// accepted, facts being sorted oldest accepted first as returned by query.Facts
+func KnownValues(accepted string, facts []query.Fact) Values {
+	known := []query.Fact{}
+	for _, f := range facts {
+		if f.Accepted <= accepted {
+			known = append(known, f)
+		}
+	}
+	v := Values{}
+	for _, f := range query.Latest(known) {
+		if f.Value == nil {
+			continue
+		}
+		k := factKey{f.Tag, f.Ddate, f.Qtrs}
+		if v[k] == nil {
+			v[k] = map[string]decimal.Decimal{}
+		}
+		v[k][f.Uom] = *f.Value
+	}
+	return v
+}
+
+// Tag returns the value of tag at ddate over qtrs quarters, in USD if
+// reported in several units, in the only unit otherwise (e.g. USD/shares for
+// an EPS)
+func (v Values) Tag(tag string, ddate string, qtrs int) (decimal.Decimal, bool) {
+	units := v[factKey{tag, ddate, qtrs}]
+	if value, ok := units["USD"]; ok {
+		return value, true
+	}
+	if len(units) == 1 {
+		for _, value := range units {
+			return value, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// Concept returns the value of the first tag of c reported at ddate over qtrs
+// quarters, qtrs being ignored for point in time concepts
+func (v Values) Concept(c *Concept, ddate string, qtrs int) (decimal.Decimal, bool) {
+	if c.Instant {
+		qtrs = 0
+	}
+	for _, tag := range c.Tags {
+		if value, ok := v.Tag(tag, ddate, qtrs); ok {
+			return value, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// Compute computes ratios for sub. facts are the facts of the company as
+// returned by query.Facts; only the ones accepted at or before sub are used,
+// so the ratios are what could be computed the day the submission was filed.
+func Compute(sub models.DataSUB, facts []query.Fact, ratios []Ratio) []Result {
+	v := KnownValues(sub.Accepted, facts)
+	ddate := query.MonthEnd(sub.Period)
+	results := []Result{}
+	for _, r := range ratios {
+		qtrs := 1
+		if sub.Fp == "FY" {
+			qtrs = 4
+		}
+		if r.YearToDate {
+			qtrs = query.FiscalQuarter(sub.Fp)
+		}
+
+		result := Result{Name: r.Name, Adsh: sub.Adsh, Cik: sub.Cik, Ddate: ddate}
+		num, numInstant := v.sum(r.Numerator, ddate, qtrs, &result.Missing)
+		value := num
+		instant := numInstant
+		if len(r.Denominator) > 0 {
+			den, denInstant := v.sum(r.Denominator, ddate, qtrs, &result.Missing)
+			instant = instant && denInstant
+			if den.IsZero() {
+				if len(result.Missing) == 0 {
+					result.Missing = append(result.Missing, "zero denominator")
+				}
+			} else {
+				value = num.DivRound(den, 8)
+			}
+		}
+		if !instant {
+			result.Qtrs = qtrs
+		}
+		if len(result.Missing) == 0 {
+			result.Value = &value
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// sum adds up the operands at ddate over qtrs quarters, recording the ones
+// missing. It also reports whether all the operands are point in time values.
+func (v Values) sum(operands []Operand, ddate string, qtrs int, missing *[]string) (decimal.Decimal, bool) {
+	total := decimal.Zero
+	instant := true
+	for _, op := range operands {
+		value, ok := v.Concept(op.Concept, ddate, qtrs)
+		if !ok && !op.Optional {
+			*missing = append(*missing, op.Concept.Name+"@"+ddate)
+		}
+		if op.Average && op.Concept.Instant {
+			start := query.ShiftQuarters(ddate, -qtrs)
+			opening, found := v.Concept(op.Concept, start, 0)
+			if !found && !op.Optional {
+				*missing = append(*missing, op.Concept.Name+"@"+start)
+			}
+			value = value.Add(opening).Div(decimal.NewFromInt(2))
+		}
+		if !op.Concept.Instant || op.Average {
+			instant = false
+		}
+		if op.Negate {
+			value = value.Neg()
+		}
+		total = total.Add(value)
+	}
+	return total, instant
+}
+
+// ForSubmission computes ratios for the submission adsh
+func ForSubmission(db *gorm.DB, adsh string, ratios []Ratio) ([]Result, error) {
+	subs := []models.DataSUB{}
+	if err := db.Where("adsh = ?", adsh).Limit(1).Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, fmt.Errorf("unknown submission %v", adsh)
+	}
+	facts, err := query.Facts(db, query.FactQuery{Cik: subs[0].Cik, Tags: Tags(ratios)})
+	if err != nil {
+		return nil, err
+	}
+	return Compute(subs[0], facts, ratios), nil
+}
+
+// ForCompany computes ratios for every submission of the company with one of
+// the given forms, oldest first
+func ForCompany(db *gorm.DB, cik string, forms []string, ratios []Ratio) ([]Result, error) {
+	subs := []models.DataSUB{}
+	err := db.Where("cik = ? AND form IN ?", cik, forms).Order("accepted").Find(&subs).Error
+	if err != nil {
+		return nil, err
+	}
+	facts, err := query.Facts(db, query.FactQuery{Cik: cik, Tags: Tags(ratios)})
+	if err != nil {
+		return nil, err
+	}
+	results := []Result{}
+	for _, sub := range subs {
+		results = append(results, Compute(sub, facts, ratios)...)
+	}
+	return results, nil
+}
+
+// Materialize computes ratios for every submission with one of the given
+// forms and stores them in the ratios table, replacing its content
+func Materialize(db *gorm.DB, forms []string, ratios []Ratio, batchSize int) error {
+	// the table is never left emptied or half written
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().DropTable(&models.Ratio{}); err != nil {
+			return err
+		}
+		if err := tx.AutoMigrate(&models.Ratio{}); err != nil {
+			return err
+		}
+		ciks := []string{}
+		err := tx.Table("data_subs").Where("form IN ?", forms).Distinct("cik").Pluck("cik", &ciks).Error
+		if err != nil {
+			return err
+		}
+		rows := []models.Ratio{}
+		for _, cik := range ciks {
+			results, err := ForCompany(tx, cik, forms, ratios)
+			if err != nil {
+				return err
+			}
+			for _, r := range results {
+				rows = append(rows, r.Model())
+			}
+			if len(rows) >= batchSize {
+				if err := tx.Create(&rows).Error; err != nil {
+					return err
+				}
+				rows = []models.Ratio{}
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+}
@@ -0,0 +1,40 @@
+package ratios
+
+import (
+	"testing"
+
+	"eswiac.me/filingsdb/query"
+	"github.com/shopspring/decimal"
+)
+
+func TestKnownValuesUnits(t *testing.T) {
+	fact := func(tag, uom, accepted string, value int64) query.Fact {
+		v := decimal.New(value, 0)
+		return query.Fact{Adsh: accepted, Accepted: accepted, Tag: tag, Ddate: "20181231", Qtrs: 4, Uom: uom, Value: &v}
+	}
+	facts := []query.Fact{
+		fact("Revenues", "USD", "2019-02-01", 100),
+		fact("Revenues", "ZAR", "2019-02-01", 1400),
+		fact("Revenues", "USD", "2019-03-01", 110),
+		fact("EarningsPerShareBasic", "USD/shares", "2019-02-01", 2),
+		fact("OtherIncome", "EUR", "2019-02-01", 5),
+		fact("OtherIncome", "GBP", "2019-02-01", 4),
+	}
+	tests := []struct {
+		accepted string
+		tag      string
+		value    string
+	}{
+		{"2019-02-01", "Revenues", "100"},
+		{"2019-03-01", "Revenues", "110"},
+		{"2019-03-01", "EarningsPerShareBasic", "2"},
+		// neither USD nor a single unit
+		{"2019-03-01", "OtherIncome", ""},
+	}
+	for _, test := range tests {
+		value, ok := KnownValues(test.accepted, facts).Tag(test.tag, "20181231", 4)
+		if (ok && value.String() != test.value) || (!ok && test.value != "") {
+			t.Errorf("%s as of %s = %s %v, expected %q", test.tag, test.accepted, value, ok, test.value)
+		}
+	}
+}
@@ -0,0 +1,431 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"eswiac.me/filingsdb/ratios"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// AnnualForms are the forms of the fiscal years of the company pages
+var AnnualForms = []string{"10-K", "10-K/A", "20-F", "20-F/A", "40-F", "40-F/A"}
+
+// Filing writes a static HTML site of the submission adsh to dir: an
+// index.html page listing its reports, one page per report (R4.html) with
+// its statement lines and values or its notes, a dimensions.html page
+// breaking its facts down by dimension and a company.html page of the
+// trends of its company. The pages need nothing but themselves to be read.
+func Filing(db *gorm.DB, adsh string, dir string) error {
+	tree, err := query.Reports(db, adsh)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	nav := []link{{"index.html", "Filing"}, {"dimensions.html", "Dimensions"}, {"company.html", "Company"}}
+	sub := tree.Sub
+
+	reports := []*query.ReportNode{}
+	var walk func(nodes []*query.ReportNode)
+	walk = func(nodes []*query.ReportNode) {
+		for _, node := range nodes {
+			reports = append(reports, node)
+			walk(node.Children)
+		}
+	}
+	for _, c := range tree.Categories {
+		walk(c.Reports)
+	}
+	for _, node := range reports {
+		r, err := query.FilingReport(db, adsh, node.Report)
+		if err != nil {
+			return err
+		}
+		p := newReportPage(r)
+		p.page = page{Title: fmt.Sprintf("R%s %s", node.Report, node.Shortname), Nav: nav}
+		if err := write(dir, "R"+node.Report+".html", "report", p); err != nil {
+			return err
+		}
+	}
+
+	f, err := query.GetFiling(db, adsh)
+	if err != nil {
+		return err
+	}
+	dims := newDimensionsPage(f)
+	dims.page = page{Title: fmt.Sprintf("%s %s dimensions", sub.Name, sub.Form), Nav: nav}
+	if err := write(dir, "dimensions.html", "dimensions", dims); err != nil {
+		return err
+	}
+
+	if err := writeCompany(db, sub.Cik, dir, "company.html", nav); err != nil {
+		return err
+	}
+	index := filingPage{
+		page:       page{Title: fmt.Sprintf("%s %s %s", sub.Name, sub.Form, sub.Period), Nav: nav},
+		Sub:        sub,
+		Folder:     folder(sub),
+		Tree:       tree,
+		Dimensions: len(dims.Groups),
+	}
+	return write(dir, "index.html", "filing", index)
+}
+
+// Company writes a static HTML page of the company cik to dir/index.html:
+// the trends of its main concepts and ratios over its fiscal years, and its
+// filings
+func Company(db *gorm.DB, cik string, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return writeCompany(db, cik, dir, "index.html", nil)
+}
+
+// page is what every page has
+type page struct {
+	Title string
+	Nav   []link
+}
+
+type link struct {
+	Href string
+	Text string
+}
+
+type filingPage struct {
+	page
+	Sub        models.DataSUB
+	Folder     string
+	Tree       *query.ReportTree
+	Dimensions int
+}
+
+type reportPage struct {
+	page
+	Report *query.Report
+
+	// The headers of the columns, and their segments if any has
+	Periods  []string
+	Segments []string
+
+	Rows  []reportRow
+	Texts []reportText
+}
+
+type reportRow struct {
+	Label  string
+	Tag    string
+	Inpth  bool
+	Values []string
+}
+
+type reportText struct {
+	Label string
+	Text  string
+}
+
+func newReportPage(r *query.Report) reportPage {
+	p := reportPage{Report: r}
+	dimensional := false
+	for _, c := range r.Columns {
+		p.Periods = append(p.Periods, c.Period())
+		p.Segments = append(p.Segments, strings.TrimSuffix(c.Segments, ";"))
+		dimensional = dimensional || c.Segments != ""
+	}
+	if !dimensional {
+		p.Segments = nil
+	}
+	for _, line := range r.Lines {
+		row := reportRow{Label: line.Plabel, Tag: line.Tag, Inpth: line.Inpth}
+		for _, v := range line.Values {
+			switch {
+			case v == nil || v.Value == nil:
+				row.Values = append(row.Values, "")
+			case line.Negating:
+				row.Values = append(row.Values, formatValue(v.Value.Neg(), v.Uom))
+			default:
+				row.Values = append(row.Values, formatValue(*v.Value, v.Uom))
+			}
+		}
+		for _, t := range line.Texts {
+			p.Texts = append(p.Texts, reportText{Label: line.Plabel, Text: *t.Value})
+		}
+		p.Rows = append(p.Rows, row)
+	}
+	return p
+}
+
+type dimensionsPage struct {
+	page
+	Sub    models.DataSUB
+	Groups []dimensionGroup
+}
+
+// dimensionGroup is the facts of a filing for a dimension
+type dimensionGroup struct {
+	Segments string
+	Rows     []dimensionRow
+}
+
+type dimensionRow struct {
+	Label  string
+	Tag    string
+	Period string
+	Value  string
+}
+
+func newDimensionsPage(f *query.Filing) dimensionsPage {
+	p := dimensionsPage{Sub: f.Sub}
+	groups := map[string]*dimensionGroup{}
+	for _, n := range f.Nums {
+		dim, ok := f.Dims[n.Dimh]
+		if n.Dimh == query.NoDimensions || !ok || n.Value == nil {
+			continue
+		}
+		g, ok := groups[dim.Segments]
+		if !ok {
+			g = &dimensionGroup{Segments: strings.TrimSuffix(dim.Segments, ";")}
+			groups[dim.Segments] = g
+		}
+		label := n.Tag
+		if t, ok := f.Tags[n.Tag]; ok && t.Tlabel != nil {
+			label = *t.Tlabel
+		}
+		period := query.ReportColumn{Ddate: n.Ddate, Qtrs: n.Qtrs}.Period()
+		if n.Coreg != nil {
+			period += " (" + *n.Coreg + ")"
+		}
+		g.Rows = append(g.Rows, dimensionRow{Label: label, Tag: n.Tag, Period: period, Value: formatValue(*n.Value, n.Uom)})
+	}
+	for _, g := range groups {
+		p.Groups = append(p.Groups, *g)
+	}
+	sort.Slice(p.Groups, func(i, j int) bool {
+		return p.Groups[i].Segments < p.Groups[j].Segments
+	})
+	return p
+}
+
+type companyPage struct {
+	page
+	Sub     models.DataSUB
+	Years   []string
+	Trends  []trend
+	Ratios  []trend
+	Filings []models.DataSUB
+}
+
+// trend is the values of a concept or a ratio over the fiscal years
+type trend struct {
+	Name   string
+	Values []string
+	Chart  template.HTML
+}
+
+// writeCompany writes the page of the company cik to dir/file
+func writeCompany(db *gorm.DB, cik string, dir string, file string, nav []link) error {
+	filings := []models.DataSUB{}
+	if err := db.Where("cik = ?", cik).Order("accepted DESC").Find(&filings).Error; err != nil {
+		return err
+	}
+	if len(filings) == 0 {
+		return fmt.Errorf("no submission of cik %s", cik)
+	}
+	p := companyPage{page: page{Title: filings[0].Name, Nav: nav}, Sub: filings[0], Filings: filings}
+
+	// the last annual report of each fiscal year, oldest first
+	annual, byDdate := []models.DataSUB{}, map[string]bool{}
+	for _, sub := range filings {
+		ddate := query.MonthEnd(sub.Period)
+		if sub.Fp == "FY" && contains(AnnualForms, sub.Form) && !byDdate[ddate] {
+			byDdate[ddate] = true
+			annual = append([]models.DataSUB{sub}, annual...)
+		}
+	}
+	for _, sub := range annual {
+		p.Years = append(p.Years, fiscalYear(sub))
+	}
+
+	tags := []string{}
+	for _, c := range ratios.Concepts {
+		tags = append(tags, c.Tags...)
+	}
+	facts, err := query.Facts(db, query.FactQuery{Cik: cik, Tags: tags})
+	if err != nil {
+		return err
+	}
+	values := ratios.KnownValues(filings[0].Accepted, facts)
+	for _, c := range ratios.Concepts {
+		series := []*decimal.Decimal{}
+		for _, sub := range annual {
+			if v, ok := values.Concept(c, query.MonthEnd(sub.Period), 4); ok {
+				series = append(series, &v)
+			} else {
+				series = append(series, nil)
+			}
+		}
+		p.Trends = append(p.Trends, newTrend(c.Name, series, func(v decimal.Decimal) string {
+			return formatValue(v, "USD")
+		}))
+	}
+
+	results, err := ratios.ForCompany(db, cik, AnnualForms, ratios.Ratios)
+	if err != nil {
+		return err
+	}
+	byRatio := map[string]*decimal.Decimal{}
+	for _, r := range results {
+		byRatio[r.Adsh+"\t"+r.Name] = r.Value
+	}
+	for _, r := range ratios.Ratios {
+		series := []*decimal.Decimal{}
+		for _, sub := range annual {
+			series = append(series, byRatio[sub.Adsh+"\t"+r.Name])
+		}
+		p.Ratios = append(p.Ratios, newTrend(r.Name, series, ratioFormats[r.Name]))
+	}
+	return write(dir, file, "company", p)
+}
+
+// ratioFormats format the values of the ratios, percentages but for
+// FCF, an amount, and the ratios of balances
+var ratioFormats = func() map[string]func(decimal.Decimal) string {
+	percent := func(v decimal.Decimal) string {
+		return v.Mul(decimal.NewFromInt(100)).StringFixed(1) + "%"
+	}
+	formats := map[string]func(decimal.Decimal) string{}
+	for _, r := range ratios.Ratios {
+		formats[r.Name] = percent
+	}
+	formats["CurrentRatio"] = func(v decimal.Decimal) string { return v.StringFixed(2) }
+	formats["DebtToEquity"] = formats["CurrentRatio"]
+	formats["FCF"] = func(v decimal.Decimal) string { return formatValue(v, "USD") }
+	return formats
+}()
+
+func newTrend(name string, series []*decimal.Decimal, format func(decimal.Decimal) string) trend {
+	t := trend{Name: name, Chart: sparkline(series)}
+	for _, v := range series {
+		if v == nil {
+			t.Values = append(t.Values, "")
+			continue
+		}
+		t.Values = append(t.Values, format(*v))
+	}
+	return t
+}
+
+// sparkline returns an SVG bar chart of the values, empty if less than two
+// are known
+func sparkline(series []*decimal.Decimal) template.HTML {
+	const width, height, bar = 8, 24, 6
+	known, max := 0, 0.0
+	for _, v := range series {
+		if v != nil {
+			known++
+			f, _ := v.Abs().Float64()
+			if f > max {
+				max = f
+			}
+		}
+	}
+	if known < 2 || max == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg class="chart" width="%d" height="%d" viewBox="0 0 %[1]d %[2]d">`, width*len(series), height)
+	for i, v := range series {
+		if v == nil {
+			continue
+		}
+		f, _ := v.Float64()
+		h := int(float64(height/2) * abs(f) / max)
+		if h == 0 {
+			h = 1
+		}
+		if f >= 0 {
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d"/>`, i*width, height/2-h, bar, h)
+		} else {
+			fmt.Fprintf(&b, `<rect class="negative" x="%d" y="%d" width="%d" height="%d"/>`, i*width, height/2, bar, h)
+		}
+	}
+	b.WriteString("</svg>")
+	return template.HTML(b.String())
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+// fiscalYear returns the fiscal year of an annual report, the year of its
+// period if it doesn't tell
+func fiscalYear(sub models.DataSUB) string {
+	if sub.Fy != "" {
+		return sub.Fy
+	}
+	if len(sub.Period) >= 4 {
+		return sub.Period[:4]
+	}
+	return sub.Period
+}
+
+// formatValue returns v with thousands separators, and its unit unless USD
+func formatValue(v decimal.Decimal, uom string) string {
+	s := v.String()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	integer, fraction := s, ""
+	if i := strings.Index(s, "."); i >= 0 {
+		integer, fraction = s[:i], s[i:]
+	}
+	for i := len(integer) - 3; i > 0; i -= 3 {
+		integer = integer[:i] + "," + integer[i:]
+	}
+	s = sign + integer + fraction
+	if uom != "USD" && uom != "" {
+		s += " " + uom
+	}
+	return s
+}
+
+// folder returns the URL of the EDGAR folder of sub
+func folder(sub models.DataSUB) string {
+	return fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/%s/%s/", sub.Cik, strings.Replace(sub.Adsh, "-", "", -1))
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// write executes the template name with data into dir/file
+func write(dir string, file string, name string, data interface{}) error {
+	path := filepath.Join(dir, file)
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := templates.ExecuteTemplate(out, name, data); err != nil {
+		out.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,112 @@
+package render
+
+import (
+	"html/template"
+)
+
+// templates are the pages of the sites, the styles inlined for the pages to
+// be self-contained
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"folder": folder,
+}).Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 72em; padding: 0 1em; color: #222; }
+nav a { margin-right: 1.5em; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.2em; margin-top: 2em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { padding: .25em .75em; border-bottom: 1px solid #ddd; vertical-align: top; }
+th { background: #f4f4f4; text-align: left; }
+td.num, th.num { text-align: right; white-space: nowrap; }
+tr.inpth td { font-style: italic; color: #555; }
+dl { display: grid; grid-template-columns: max-content auto; gap: .25em 1em; }
+dt { font-weight: bold; }
+ul.tree { list-style: none; padding-left: 1em; }
+.parent, .tag { color: #777; font-size: .85em; }
+.text { white-space: pre-wrap; line-height: 1.4; }
+svg.chart rect { fill: #4a7ab5; }
+svg.chart rect.negative { fill: #c0392b; }
+</style>
+</head>
+<body>
+{{if .Nav}}<nav>{{range .Nav}}<a href="{{.Href}}">{{.Text}}</a>{{end}}</nav>{{end}}
+<h1>{{.Title}}</h1>
+{{end}}
+
+{{define "footer"}}
+</body>
+</html>
+{{end}}
+
+{{define "node"}}<li><a href="R{{.Report}}.html">R{{.Report}} {{.Shortname}}</a>{{if .Parent}} <span class="parent">{{.Parent}}</span>{{end}}
+{{if .Children}}<ul class="tree">{{range .Children}}{{template "node" .}}{{end}}</ul>{{end}}</li>
+{{end}}
+
+{{define "filing"}}{{template "header" .}}
+<dl>
+<dt>Company</dt><dd><a href="company.html">{{.Sub.Name}}</a> (CIK {{.Sub.Cik}})</dd>
+<dt>Form</dt><dd>{{.Sub.Form}}</dd>
+<dt>Period</dt><dd>{{.Sub.Period}} ({{.Sub.Fy}} {{.Sub.Fp}})</dd>
+<dt>Filed</dt><dd>{{.Sub.Filed}}, accepted {{.Sub.Accepted}}</dd>
+<dt>Accession number</dt><dd><a href="{{.Folder}}">{{.Sub.Adsh}}</a></dd>
+</dl>
+{{range .Tree.Categories}}<h2>{{.Name}}</h2>
+<ul class="tree">{{range .Reports}}{{template "node" .}}{{end}}</ul>
+{{end}}
+{{if .Dimensions}}<h2>Dimensions</h2>
+<p>The facts of the filing broken down by <a href="dimensions.html">dimension</a> ({{.Dimensions}}).</p>
+{{end}}
+{{template "footer"}}{{end}}
+
+{{define "report"}}{{template "header" .}}
+<p>{{.Report.Ren.Longname}}</p>
+{{if .Periods}}<table>
+<tr><th></th>{{range .Periods}}<th class="num">{{.}}</th>{{end}}</tr>
+{{if .Segments}}<tr><th></th>{{range .Segments}}<th class="num">{{.}}</th>{{end}}</tr>{{end}}
+{{range .Rows}}<tr{{if .Inpth}} class="inpth"{{end}}><td title="{{.Tag}}">{{.Label}}</td>{{range .Values}}<td class="num">{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}
+{{range .Texts}}<h2>{{.Label}}</h2>
+<div class="text">{{.Text}}</div>
+{{end}}
+{{template "footer"}}{{end}}
+
+{{define "dimensions"}}{{template "header" .}}
+{{range .Groups}}<h2>{{.Segments}}</h2>
+<table>
+<tr><th>Concept</th><th>Period</th><th class="num">Value</th></tr>
+{{range .Rows}}<tr><td>{{.Label}} <span class="tag">{{.Tag}}</span></td><td>{{.Period}}</td><td class="num">{{.Value}}</td></tr>
+{{end}}</table>
+{{else}}<p>The facts of the filing have no dimensions.</p>
+{{end}}
+{{template "footer"}}{{end}}
+
+{{define "company"}}{{template "header" .}}
+<dl>
+<dt>CIK</dt><dd>{{.Sub.Cik}}</dd>
+<dt>SIC</dt><dd>{{.Sub.Sic}}</dd>
+<dt>Fiscal year end</dt><dd>{{.Sub.Fye}}</dd>
+</dl>
+{{if .Years}}<h2>Fiscal years</h2>
+<table>
+<tr><th></th>{{range .Years}}<th class="num">{{.}}</th>{{end}}<th></th></tr>
+{{range .Trends}}<tr><td>{{.Name}}</td>{{range .Values}}<td class="num">{{.}}</td>{{end}}<td>{{.Chart}}</td></tr>
+{{end}}</table>
+<h2>Ratios</h2>
+<table>
+<tr><th></th>{{range .Years}}<th class="num">{{.}}</th>{{end}}<th></th></tr>
+{{range .Ratios}}<tr><td>{{.Name}}</td>{{range .Values}}<td class="num">{{.}}</td>{{end}}<td>{{.Chart}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Filings</h2>
+<table>
+<tr><th>Form</th><th>Period</th><th>Accepted</th><th>Accession number</th></tr>
+{{range .Filings}}<tr><td>{{.Form}}</td><td>{{.Period}}</td><td>{{.Accepted}}</td><td><a href="{{folder .}}">{{.Adsh}}</a></td></tr>
+{{end}}</table>
+{{template "footer"}}{{end}}
+`))
@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/pb"
+	"github.com/shopspring/decimal"
+)
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func dec(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
+
+func integer(i *int) int32 {
+	if i == nil {
+		return 0
+	}
+	return int32(*i)
+}
+
+func submissionOf(s models.DataSUB) *pb.Submission {
+	return &pb.Submission{
+		Adsh:        s.Adsh,
+		Cik:         s.Cik,
+		Name:        s.Name,
+		Sic:         s.Sic,
+		Countryba:   s.Countryba,
+		Stprba:      str(s.Stprba),
+		Cityba:      s.Cityba,
+		Zipba:       str(s.Zipba),
+		Bas1:        str(s.Bas1),
+		Bas2:        str(s.Bas2),
+		Baph:        str(s.Baph),
+		Countryma:   str(s.Countryma),
+		Stprma:      str(s.Stprma),
+		Cityma:      str(s.Cityma),
+		Zipma:       str(s.Zipma),
+		Mas1:        str(s.Mas1),
+		Mas2:        str(s.Mas2),
+		Countryinc:  s.Countryinc,
+		Stprinc:     str(s.Stprinc),
+		Ein:         str(s.Ein),
+		Former:      str(s.Former),
+		Changed:     str(s.Changed),
+		Afs:         str(s.Afs),
+		Wksi:        s.Wksi,
+		Fye:         s.Fye,
+		Form:        s.Form,
+		Period:      s.Period,
+		Fy:          s.Fy,
+		Fp:          s.Fp,
+		Filed:       s.Filed,
+		Accepted:    s.Accepted,
+		Prevrpt:     s.Prevrpt,
+		Detail:      s.Detail,
+		Instance:    s.Instance,
+		Nciks:       int32(s.Nciks),
+		Aciks:       str(s.Aciks),
+		Pubfloatusd: dec(s.Pubfloatusd),
+		Floatdate:   str(s.Floatdate),
+		Floataxis:   str(s.Floataxis),
+		Floatmems:   integer(s.Floatmems),
+	}
+}
+
+func numOf(n models.DataNUM) *pb.Num {
+	return &pb.Num{
+		Adsh:     n.Adsh,
+		Tag:      n.Tag,
+		Version:  n.Version,
+		Ddate:    n.Ddate,
+		Qtrs:     int32(n.Qtrs),
+		Uom:      n.Uom,
+		Dimh:     n.Dimh,
+		Iprx:     int32(n.Iprx),
+		Value:    dec(n.Value),
+		Footnote: str(n.Footnote),
+		Footlen:  int32(n.Footlen),
+		Dimn:     int32(n.Dimn),
+		Coreg:    str(n.Coreg),
+		Durp:     dec(n.Durp),
+		Datp:     dec(n.Datp),
+		Dcml:     int32(n.Dcml),
+	}
+}
+
+func txtOf(t models.DataTXT) *pb.Txt {
+	return &pb.Txt{
+		Adsh:     t.Adsh,
+		Tag:      t.Tag,
+		Version:  t.Version,
+		Ddate:    t.Ddate,
+		Qtrs:     int32(t.Qtrs),
+		Iprx:     int32(t.Iprx),
+		Lang:     t.Lang,
+		Dcml:     int32(t.Dcml),
+		Durp:     t.Durp.String(),
+		Datp:     t.Datp.String(),
+		Dimh:     t.Dimh,
+		Dimn:     integer(t.Dimn),
+		Coreg:    str(t.Coreg),
+		Escaped:  t.Escaped,
+		Srclen:   int32(t.Srclen),
+		Txtlen:   integer(t.Txtlen),
+		Footnote: str(t.Footnote),
+		Footlen:  integer(t.Footlen),
+		Context:  t.Context,
+		Value:    str(t.Value),
+	}
+}
+
+func dimensionOf(d models.DataDIM) *pb.Dimension {
+	return &pb.Dimension{Dimh: d.Dimh, Segments: d.Segments, Segt: d.Segt}
+}
@@ -0,0 +1,206 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/pb"
+	"eswiac.me/filingsdb/query"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Server implements the Filings gRPC service over a filings database
+type Server struct {
+	pb.UnimplementedFilingsServer
+	db *gorm.DB
+}
+
+// NewServer returns the Filings service over db
+func NewServer(db *gorm.DB) *Server {
+	return &Server{db: db}
+}
+
+// Register registers the service on s
+func (s *Server) Register(g *grpc.Server) {
+	pb.RegisterFilingsServer(g, s)
+}
+
+func (s *Server) GetSubmission(ctx context.Context, req *pb.GetSubmissionRequest) (*pb.Submission, error) {
+	subs := []models.DataSUB{}
+	if err := s.db.WithContext(ctx).Where("adsh = ?", req.Adsh).Limit(1).Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "unknown submission %s", req.Adsh)
+	}
+	return submissionOf(subs[0]), nil
+}
+
+func (s *Server) ListSubmissions(req *pb.ListSubmissionsRequest, stream pb.Filings_ListSubmissionsServer) error {
+	tx := s.db.WithContext(stream.Context()).Model(&models.DataSUB{})
+	if req.Company != "" {
+		cik, err := s.cik(stream.Context(), req.Company)
+		if err != nil {
+			return err
+		}
+		tx = tx.Where("cik = ?", cik)
+	}
+	if len(req.Forms) > 0 {
+		tx = tx.Where("form IN ?", req.Forms)
+	}
+	for column, v := range map[string]string{"fy": req.Fy, "fp": req.Fp, "sic": req.Sic} {
+		if v != "" {
+			tx = tx.Where(column+" = ?", v)
+		}
+	}
+	rows, err := tx.Order("accepted DESC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		sub := models.DataSUB{}
+		if err := s.db.ScanRows(rows, &sub); err != nil {
+			return err
+		}
+		if err := stream.Send(submissionOf(sub)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (s *Server) StreamNums(q *pb.FactQuery, stream pb.Filings_StreamNumsServer) error {
+	rows, err := s.facts(stream.Context(), &models.DataNUM{}, q)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		num := models.DataNUM{}
+		if err := s.db.ScanRows(rows, &num); err != nil {
+			return err
+		}
+		if err := stream.Send(numOf(num)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (s *Server) StreamTxts(q *pb.FactQuery, stream pb.Filings_StreamTxtsServer) error {
+	rows, err := s.facts(stream.Context(), &models.DataTXT{}, q)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	// values stored compressed are read for textChunk facts at once
+	txts := []models.DataTXT{}
+	send := func() error {
+		if err := query.TextValues(s.db.WithContext(stream.Context()), txts); err != nil {
+			return err
+		}
+		for _, txt := range txts {
+			if err := stream.Send(txtOf(txt)); err != nil {
+				return err
+			}
+		}
+		txts = txts[:0]
+		return nil
+	}
+	for rows.Next() {
+		txt := models.DataTXT{}
+		if err := s.db.ScanRows(rows, &txt); err != nil {
+			return err
+		}
+		txts = append(txts, txt)
+		if len(txts) >= textChunk {
+			if err := send(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return send()
+}
+
+// textChunk is the count of text facts streamed at once
+const textChunk = 100
+
+func (s *Server) GetDimensions(ctx context.Context, req *pb.GetDimensionsRequest) (*pb.GetDimensionsResponse, error) {
+	dims := []models.DataDIM{}
+	if err := s.db.WithContext(ctx).Where("dimh IN ?", req.Dimhs).Find(&dims).Error; err != nil {
+		return nil, err
+	}
+	resp := &pb.GetDimensionsResponse{}
+	for _, d := range dims {
+		resp.Dimensions = append(resp.Dimensions, dimensionOf(d))
+	}
+	return resp, nil
+}
+
+// facts returns a cursor over the rows of the facts table of model matching q
+func (s *Server) facts(ctx context.Context, model interface{}, q *pb.FactQuery) (*sql.Rows, error) {
+	tx := s.db.WithContext(ctx).Model(model)
+	if len(q.Tags) > 0 {
+		tx = tx.Where("tag IN ?", q.Tags)
+	}
+	if len(q.Adsh) > 0 {
+		tx = tx.Where("adsh IN ?", q.Adsh)
+	}
+	if q.Company != "" || len(q.Forms) > 0 {
+		subs := s.db.Table("data_subs").Select("adsh")
+		if q.Company != "" {
+			cik, err := s.cik(ctx, q.Company)
+			if err != nil {
+				return nil, err
+			}
+			subs = subs.Where("cik = ?", cik)
+		}
+		if len(q.Forms) > 0 {
+			subs = subs.Where("form IN ?", q.Forms)
+		}
+		tx = tx.Where("adsh IN (?)", subs)
+	}
+	if q.DdateFrom != "" {
+		tx = tx.Where("ddate >= ?", q.DdateFrom)
+	}
+	if q.DdateTo != "" {
+		tx = tx.Where("ddate <= ?", q.DdateTo)
+	}
+	if len(q.Qtrs) > 0 {
+		tx = tx.Where("qtrs IN ?", q.Qtrs)
+	}
+	if len(q.Uoms) > 0 {
+		if _, ok := model.(*models.DataNUM); !ok {
+			return nil, status.Error(codes.InvalidArgument, "text facts have no unit of measure")
+		}
+		tx = tx.Where("uom IN ?", q.Uoms)
+	}
+	if len(q.Dimhs) > 0 {
+		tx = tx.Where("dimh IN ?", q.Dimhs)
+	}
+	if q.PrimaryOnly {
+		tx = tx.Where("iprx = 1")
+	}
+	return tx.Order("adsh, tag, ddate, qtrs").Rows()
+}
+
+// cik resolves a CIK or ticker
+func (s *Server) cik(ctx context.Context, company string) (string, error) {
+	cik, err := query.ResolveCIK(s.db.WithContext(ctx), company)
+	if errors.Is(err, query.ErrUnknownTicker) {
+		return "", status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return "", err
+	}
+	return cik, nil
+}
@@ -0,0 +1,260 @@
+package filingsdb
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Source lists the archives of an S3 compatible object store bucket, e.g.
+// a mirror of the data sets on AWS S3 or MinIO. Requests are signed with AWS
+// Signature Version 4 if AccessKey is set.
+type S3Source struct {
+	/**
+	The address of the object store, e.g. https://s3.us-east-1.amazonaws.com
+	or http://localhost:9000. Objects are addressed in path style:
+	<Endpoint>/<Bucket>/<key>.
+	*/
+	Endpoint string
+	Bucket   string
+
+	// Only list the keys starting with Prefix, e.g. "notes/"
+	Prefix string
+
+	// us-east-1 if empty
+	Region    string
+	AccessKey string
+	SecretKey string
+
+	// Session token of temporary credentials
+	SessionToken string
+
+	// Client (DefaultClient if nil) retries and rate limits the requests
+	Client *Client
+}
+
+// NewS3Source returns a source of the archives of bucket under prefix, with
+// the credentials, region and endpoint of the AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_REGION and AWS_ENDPOINT_URL
+// environment variables
+func NewS3Source(bucket string, prefix string) *S3Source {
+	s := &S3Source{
+		Endpoint:     os.Getenv("AWS_ENDPOINT_URL"),
+		Bucket:       bucket,
+		Prefix:       prefix,
+		Region:       os.Getenv("AWS_REGION"),
+		AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if s.Region == "" {
+		s.Region = "us-east-1"
+	}
+	if s.Endpoint == "" {
+		s.Endpoint = "https://s3." + s.Region + ".amazonaws.com"
+	}
+	return s
+}
+
+// listBucketResult is the answer of ListObjectsV2
+type listBucketResult struct {
+	Contents []struct {
+		Key  string
+		Size int64
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// ListArchives lists the zip objects of the bucket under the prefix, sorted
+// by name
+func (s *S3Source) ListArchives(ctx context.Context) ([]Archive, error) {
+	archives := []Archive{}
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {s.Prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := s.get(ctx, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		result := listBucketResult{}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("s3://%s/%s: %w", s.Bucket, s.Prefix, err)
+		}
+		for _, object := range result.Contents {
+			if strings.HasSuffix(object.Key, ".zip") {
+				archives = append(archives, Archive{Name: path.Base(object.Key), URL: s.objectURL(object.Key)})
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		token = result.NextContinuationToken
+	}
+	sort.Slice(archives, func(i, j int) bool { return archives[i].Name < archives[j].Name })
+	return archives, nil
+}
+
+// Fetch downloads the object to a temporary file
+func (s *S3Source) Fetch(ctx context.Context, archive Archive, progress io.Writer) (string, func(), error) {
+	key, err := url.PathUnescape(strings.TrimPrefix(archive.URL, s.objectURL("")))
+	if err != nil {
+		return "", nil, err
+	}
+	tmpFile, err := ioutil.TempFile("", "filingsdb-*.zip")
+	if err != nil {
+		return "", nil, err
+	}
+	release := func() { os.Remove(tmpFile.Name()) }
+	err = s.download(ctx, key, tmpFile, progress)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		release()
+		return "", nil, err
+	}
+	return tmpFile.Name(), release, nil
+}
+
+func (s *S3Source) download(ctx context.Context, key string, out io.Writer, progress io.Writer) error {
+	resp, err := s.get(ctx, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var body io.Reader = resp.Body
+	if progress != nil {
+		body = io.TeeReader(resp.Body, progress)
+	}
+	_, err = io.Copy(out, body)
+	return err
+}
+
+// objectURL returns the path style URL of key
+func (s *S3Source) objectURL(key string) string {
+	return strings.TrimRight(s.Endpoint, "/") + "/" + s.Bucket + "/" + (&url.URL{Path: key}).EscapedPath()
+}
+
+// get gets the object key, the bucket itself if empty, signing the request
+func (s *S3Source) get(ctx context.Context, key string, query url.Values, header http.Header) (*http.Response, error) {
+	u, err := url.Parse(strings.TrimRight(s.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	u.Path += "/" + s.Bucket + "/" + key
+	u.RawQuery = canonicalQuery(query)
+	h := http.Header{}
+	for k, v := range header {
+		h[k] = v
+	}
+	if s.AccessKey != "" {
+		s.sign(u, h, time.Now())
+	}
+	client := s.Client
+	if client == nil {
+		client = DefaultClient
+	}
+	return client.Get(ctx, u.String(), h)
+}
+
+// emptySHA256 is the hash of the empty payload of GET requests
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// sign adds the AWS Signature Version 4 headers of a GET request of u to
+// header, see https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *S3Source) sign(u *url.URL, header http.Header, now time.Time) {
+	now = now.UTC()
+	date := now.Format("20060102")
+	header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	header.Set("X-Amz-Content-Sha256", emptySHA256)
+	if s.SessionToken != "" {
+		header.Set("X-Amz-Security-Token", s.SessionToken)
+	}
+
+	// the host and the x-amz-* headers, plus the range of partial requests
+	names := []string{"host"}
+	values := map[string]string{"host": u.Host}
+	for k := range header {
+		name := strings.ToLower(k)
+		if strings.HasPrefix(name, "x-amz-") || name == "range" {
+			names = append(names, name)
+			values[name] = strings.TrimSpace(header.Get(k))
+		}
+	}
+	sort.Strings(names)
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + values[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		u.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		emptySHA256,
+	}, "\n")
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format("20060102T150405Z"),
+		scope,
+		hash([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), date)
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		s.AccessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery encodes query sorted by key with the URI encoding of AWS
+func canonicalQuery(query url.Values) string {
+	keys := []string{}
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	params := []string{}
+	for _, k := range keys {
+		for _, v := range query[k] {
+			params = append(params, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// awsEscape percent-encodes everything but the unreserved characters
+func awsEscape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
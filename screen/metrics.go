@@ -0,0 +1,108 @@
+package screen
+
+import (
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"eswiac.me/filingsdb/ratios"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// metric is a value derived from the facts of a filing: a canonical concept
+// (e.g. Revenue) or a raw tag (e.g. Revenues), possibly suffixed by the
+// period to read it over (e.g. Revenue_ttm)
+type metric struct {
+	concept *ratios.Concept
+
+	/**
+	"" for the period of the filing (the fiscal quarter of a 10-Q,
+	the year of a 10-K), "ttm" for the trailing twelve months,
+	"fy" for the last fiscal year, "mrq" for the most recent quarter.
+	*/
+	period string
+}
+
+var periodSuffixes = []string{"ttm", "fy", "mrq"}
+
+// parseMetric resolves name to a metric, nil if it's neither a canonical
+// concept nor a tag known to the database
+func parseMetric(db *gorm.DB, name string) (*metric, error) {
+	base, period := name, ""
+	for _, suffix := range periodSuffixes {
+		if strings.HasSuffix(strings.ToLower(name), "_"+suffix) {
+			base, period = name[:len(name)-len(suffix)-1], suffix
+			break
+		}
+	}
+	if c := ratios.FindConcept(base); c != nil {
+		return &metric{concept: c, period: period}, nil
+	}
+	iords := []*string{}
+	err := db.Table("data_tags").Where("tag = ?", base).Limit(1).Pluck("iord", &iords).Error
+	if err != nil || len(iords) == 0 {
+		return nil, err
+	}
+	instant := iords[0] != nil && *iords[0] == "I"
+	return &metric{concept: &ratios.Concept{Name: base, Tags: []string{base}, Instant: instant}, period: period}, nil
+}
+
+// value reads the metric for sub out of the values known when it was accepted
+func (m *metric) value(v ratios.Values, sub models.DataSUB) (decimal.Decimal, bool) {
+	c := m.concept
+	ddate := query.MonthEnd(sub.Period)
+	q := query.FiscalQuarter(sub.Fp)
+	fyEnd := ddate
+	if q != 4 {
+		fyEnd = query.ShiftQuarters(ddate, -q)
+	}
+
+	if c.Instant {
+		if m.period == "fy" {
+			return v.Concept(c, fyEnd, 0)
+		}
+		return v.Concept(c, ddate, 0)
+	}
+
+	switch m.period {
+	case "fy":
+		return v.Concept(c, fyEnd, 4)
+	case "ttm":
+		if value, ok := v.Concept(c, ddate, 4); ok {
+			return value, true
+		}
+		// last fiscal year + year to date - same period of the last fiscal year
+		fy, fyOk := v.Concept(c, fyEnd, 4)
+		ytd, ytdOk := v.Concept(c, ddate, q)
+		prior, priorOk := v.Concept(c, query.ShiftQuarters(ddate, -4), q)
+		if fyOk && ytdOk && priorOk {
+			return fy.Add(ytd).Sub(prior), true
+		}
+		// sum of the last four quarters
+		total := decimal.Zero
+		for i := 0; i < 4; i++ {
+			value, ok := v.Concept(c, query.ShiftQuarters(ddate, -i), 1)
+			if !ok {
+				return decimal.Zero, false
+			}
+			total = total.Add(value)
+		}
+		return total, true
+	case "mrq":
+		if value, ok := v.Concept(c, ddate, 1); ok {
+			return value, true
+		}
+		// the fourth quarter is rarely reported on its own
+		fy, fyOk := v.Concept(c, ddate, 4)
+		m9, m9Ok := v.Concept(c, query.ShiftQuarters(ddate, -1), 3)
+		if fyOk && m9Ok {
+			return fy.Sub(m9), true
+		}
+		return decimal.Zero, false
+	}
+	if q == 4 {
+		return v.Concept(c, ddate, 4)
+	}
+	return v.Concept(c, ddate, 1)
+}
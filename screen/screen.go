@@ -0,0 +1,280 @@
+package screen
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"eswiac.me/filingsdb/expr"
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"eswiac.me/filingsdb/ratios"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// DefaultColumns are the columns returned when none are requested, followed
+// by the metrics the expression refers to
+var DefaultColumns = []string{"cik", "ticker", "name", "sic", "form", "period"}
+
+// tickerColumn looks up the ticker of the registrant of a submission
+const tickerColumn = "(SELECT data_tickers.ticker FROM data_tickers WHERE data_tickers.cik = data_subs.cik LIMIT 1)"
+
+// Query is a stock screen
+type Query struct {
+	query.Filter
+
+	/**
+	The filter expression, e.g.
+	sic in (2834,2836) and Revenue_ttm > 1e8 and NetIncome_ttm < 0 and form = '10-K'
+
+	Identifiers are either columns of data_subs (sic, form, fy, countryba...),
+	ticker, ratio names (ROE, CurrentRatio...) or metrics: a canonical concept
+	(Revenue, NetIncome, Assets...) or a tag (Revenues, NetIncomeLoss...)
+	optionally suffixed by _ttm (trailing twelve months), _fy (last fiscal year)
+	or _mrq (most recent quarter).
+	*/
+	Expression string
+
+	// Columns to return, expressions over the same identifiers
+	Columns []string
+}
+
+// Row is a company matching the screen, represented by its most recent
+// submission matching the submission conditions of the expression
+type Row struct {
+	Sub    models.DataSUB
+	Ticker *string
+	Values []interface{}
+}
+
+// Result lists the companies matching a screen
+type Result struct {
+	Columns []string
+	Rows    []Row
+}
+
+// Run screens the companies of the database. Conditions on submission columns
+// are compiled to SQL, the other ones are evaluated on the facts known when the
+// most recent matching submission of each company was accepted.
+func Run(db *gorm.DB, q Query) (*Result, error) {
+	cond, err := expr.Parse(q.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := q.Columns
+	if len(columns) == 0 {
+		columns = append([]string{}, DefaultColumns...)
+		for _, name := range expr.Idents(cond) {
+			if _, ok := subColumn(name); !ok {
+				columns = append(columns, name)
+			}
+		}
+	}
+	columnExprs := []expr.Expr{}
+	for _, col := range columns {
+		e, err := expr.Parse(col)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", col, err)
+		}
+		columnExprs = append(columnExprs, e)
+	}
+
+	// resolve every identifier upfront to report unknown ones
+	s := &screener{db: db, metrics: map[string]*metric{}, ratios: map[string]*ratios.Ratio{}}
+	for _, e := range append([]expr.Expr{cond}, columnExprs...) {
+		for _, name := range expr.Idents(e) {
+			if err := s.resolve(name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// conditions on the submission only are left to the database
+	sqlConds, goConds := []expr.Expr{}, []expr.Expr{}
+	for _, c := range expr.Conjuncts(cond) {
+		if _, _, ok := expr.ToSQL(c, subColumn); ok {
+			sqlConds = append(sqlConds, c)
+		} else {
+			goConds = append(goConds, c)
+		}
+	}
+	tx := db.Table("data_subs").
+		Select("data_subs.*, " + tickerColumn + " AS ticker").
+		Scopes(q.Filter.Scope)
+	if len(sqlConds) > 0 {
+		where, args, _ := expr.ToSQL(expr.And(sqlConds), subColumn)
+		tx = tx.Where(where, args...)
+	}
+	type subRow struct {
+		models.DataSUB
+		Ticker *string
+	}
+	subs := []subRow{}
+	if err := tx.Order("data_subs.cik, data_subs.accepted DESC").Find(&subs).Error; err != nil {
+		return nil, err
+	}
+
+	rest := expr.And(goConds)
+	result := &Result{Columns: columns}
+	for i, sub := range subs {
+		// the most recent submission of each company
+		if i > 0 && subs[i-1].Cik == sub.Cik {
+			continue
+		}
+		env, err := s.env(sub.DataSUB, sub.Ticker)
+		if err != nil {
+			return nil, err
+		}
+		if rest != nil {
+			match, err := expr.Match(rest, env)
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+		}
+		row := Row{Sub: sub.DataSUB, Ticker: sub.Ticker}
+		for _, e := range columnExprs {
+			v, err := e.Eval(env)
+			if err != nil {
+				return nil, err
+			}
+			row.Values = append(row.Values, v)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	sort.SliceStable(result.Rows, func(i, j int) bool {
+		return result.Rows[i].Sub.Name < result.Rows[j].Sub.Name
+	})
+	return result, nil
+}
+
+// subColumn maps an identifier to a column of data_subs
+func subColumn(name string) (string, bool) {
+	name = strings.ToLower(name)
+	if name == "ticker" {
+		return tickerColumn, true
+	}
+	if _, ok := subFields[name]; ok {
+		return "data_subs." + name, true
+	}
+	return "", false
+}
+
+// subFields indexes the fields of DataSUB by column name
+var subFields = func() map[string]int {
+	fields := map[string]int{}
+	t := reflect.TypeOf(models.DataSUB{})
+	for i := 0; i < t.NumField(); i++ {
+		fields[strings.ToLower(t.Field(i).Name)] = i
+	}
+	return fields
+}()
+
+type screener struct {
+	db      *gorm.DB
+	metrics map[string]*metric
+	ratios  map[string]*ratios.Ratio
+	tags    []string
+}
+
+func (s *screener) resolve(name string) error {
+	if _, ok := subColumn(name); ok {
+		return nil
+	}
+	if _, ok := s.metrics[name]; ok {
+		return nil
+	}
+	if _, ok := s.ratios[name]; ok {
+		return nil
+	}
+	if r := ratios.FindRatio(name); r != nil {
+		s.ratios[name] = r
+		s.tags = append(s.tags, ratios.Tags([]ratios.Ratio{*r})...)
+		return nil
+	}
+	m, err := parseMetric(s.db, name)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return fmt.Errorf("unknown identifier `%s`: not a submission column, ratio, concept or tag", name)
+	}
+	s.metrics[name] = m
+	s.tags = append(s.tags, m.concept.Tags...)
+	return nil
+}
+
+// env returns the values of the identifiers for a submission
+func (s *screener) env(sub models.DataSUB, ticker *string) (expr.Env, error) {
+	env := &filingEnv{s: s, sub: sub, ticker: ticker}
+	if len(s.tags) > 0 {
+		facts, err := query.Facts(s.db, query.FactQuery{Cik: sub.Cik, Tags: s.tags})
+		if err != nil {
+			return nil, err
+		}
+		env.facts = facts
+		env.values = ratios.KnownValues(sub.Accepted, facts)
+	}
+	return env, nil
+}
+
+type filingEnv struct {
+	s      *screener
+	sub    models.DataSUB
+	ticker *string
+	facts  []query.Fact
+	values ratios.Values
+}
+
+func (e *filingEnv) Lookup(name string) (interface{}, error) {
+	lower := strings.ToLower(name)
+	if lower == "ticker" {
+		if e.ticker == nil {
+			return nil, nil
+		}
+		return *e.ticker, nil
+	}
+	if i, ok := subFields[lower]; ok {
+		return fieldValue(reflect.ValueOf(e.sub).Field(i)), nil
+	}
+	if r, ok := e.s.ratios[name]; ok {
+		result := ratios.Compute(e.sub, e.facts, []ratios.Ratio{*r})[0]
+		if result.Value == nil {
+			return nil, nil
+		}
+		f, _ := result.Value.Float64()
+		return f, nil
+	}
+	if m, ok := e.s.metrics[name]; ok {
+		value, ok := m.value(e.values, e.sub)
+		if !ok {
+			return nil, nil
+		}
+		f, _ := value.Float64()
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown identifier `%s`", name)
+}
+
+// fieldValue converts a DataSUB field to an expression value
+func fieldValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch value := v.Interface().(type) {
+	case decimal.Decimal:
+		f, _ := value.Float64()
+		return f
+	case int:
+		return float64(value)
+	}
+	return v.Interface()
+}
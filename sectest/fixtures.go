@@ -0,0 +1,242 @@
+package sectest
+
+import (
+	"archive/zip"
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// SampleFiles are the files of a small data set archive of 2019q1: the
+// filings of Facebook (10-K, 10-Q and 10-K/A), Pfizer, Amgen, Biogen and
+// Moderna with their main facts, statements of the Facebook 10-K, a custom
+// tag, a dimension, text facts and calculation arcs
+var SampleFiles = map[string]string{
+	"sub.tsv": `adsh	cik	name	sic	countryba	stprba	cityba	zipba	bas1	bas2	baph	countryma	stprma	cityma	zipma	mas1	mas2	countryinc	stprinc	ein	former	changed	afs	wksi	fye	form	period	fy	fp	filed	accepted	prevrpt	detail	instance	nciks	aciks	pubfloatusd	floatdate	floataxis	floatmems
+0001326801-18-000032	0001326801	FACEBOOK INC	7370	US	CA	MENLO PARK	94025	1 MAIN ST		650-000-0000	US	CA	MENLO PARK	94025	1 MAIN ST		US	DE	201665019			1-LAF	0	1231	10-K	20171231	2017	FY	20180201	2018-02-01 16:10:00.0	0	1	x-20171231.xml	1					
+0001326801-19-000009	0001326801	FACEBOOK INC	7370	US	CA	MENLO PARK	94025	1 MAIN ST		650-000-0000	US	CA	MENLO PARK	94025	1 MAIN ST		US	DE	201665019			1-LAF	0	1231	10-K	20181231	2018	FY	20190131	2019-01-31 16:05:00.0	0	1	x-20181231.xml	1					
+0001326801-19-000020	0001326801	FACEBOOK INC	7370	US	CA	MENLO PARK	94025	1 MAIN ST		650-000-0000	US	CA	MENLO PARK	94025	1 MAIN ST		US	DE	201665019			1-LAF	0	1231	10-Q	20190331	2019	Q1	20190425	2019-04-24 18:02:00.0	0	1	x-20190331.xml	1					
+0001326801-19-000030	0001326801	FACEBOOK INC	7370	US	CA	MENLO PARK	94025	1 MAIN ST		650-000-0000	US	CA	MENLO PARK	94025	1 MAIN ST		US	DE	201665019			1-LAF	0	1231	10-K/A	20181231	2018	FY	20190601	2019-06-01 09:00:00.0	0	1	x-20181231.xml	1					
+0000078003-19-000015	0000078003	PFIZER INC	2834	US	CA	MENLO PARK	94025	1 MAIN ST		650-000-0000	US	CA	MENLO PARK	94025	1 MAIN ST		US	DE	201665019			1-LAF	0	1231	10-K	20181231	2018	FY	20190228	2019-02-28 17:03:00.0	0	1	x-20181231.xml	1					
+0000318154-19-000010	0000318154	AMGEN INC	2836	US	CA	MENLO PARK	94025	1 MAIN ST		650-000-0000	US	CA	MENLO PARK	94025	1 MAIN ST		US	DE	201665019			1-LAF	0	1231	10-K	20181231	2018	FY	20190213	2019-02-13 16:30:00.0	0	1	x-20181231.xml	1					
+0000875045-19-000008	0000875045	BIOGEN INC	2836	US	CA	MENLO PARK	94025	1 MAIN ST		650-000-0000	US	CA	MENLO PARK	94025	1 MAIN ST		US	DE	201665019			1-LAF	0	1231	10-K	20181231	2018	FY	20190206	2019-02-06 07:00:00.0	0	1	x-20181231.xml	1					
+0001682852-19-000012	0001682852	MODERNA INC	2834	US	CA	MENLO PARK	94025	1 MAIN ST		650-000-0000	US	CA	MENLO PARK	94025	1 MAIN ST		US	DE	201665019			1-LAF	0	1231	10-K	20181231	2018	FY	20190314	2019-03-14 08:00:00.0	0	1	x-20181231.xml	1					
+`,
+	"tag.tsv": `tag	version	custom	abstract	datatype	iord	crdr	tlabel	doc
+Revenues	us-gaap/2018	0	0	monetary	D	C	Revenues	Amount of revenue recognized from goods sold, services rendered.
+NetIncomeLoss	us-gaap/2018	0	0	monetary	D	C	Net Income (Loss) Attributable to Parent	The portion of profit or loss for the period, net of income taxes.
+Assets	us-gaap/2018	0	0	monetary	I	D	Assets	Sum of the carrying amounts of all assets.
+AssetsCurrent	us-gaap/2018	0	0	monetary	I	D	Assets, Current	Sum of current assets.
+Liabilities	us-gaap/2018	0	0	monetary	I	C	Liabilities	Sum of liabilities.
+LiabilitiesCurrent	us-gaap/2018	0	0	monetary	I	C	Liabilities, Current	Sum of current liabilities.
+StockholdersEquity	us-gaap/2018	0	0	monetary	I	C	Stockholders' Equity Attributable to Parent	Total equity.
+LiabilitiesAndStockholdersEquity	us-gaap/2018	0	0	monetary	I	C	Liabilities and Equity	Total of liabilities and equity.
+NetCashProvidedByUsedInOperatingActivities	us-gaap/2018	0	0	monetary	D	D	Net Cash Provided by (Used in) Operating Activities	Operating cash flow.
+PaymentsToAcquirePropertyPlantAndEquipment	us-gaap/2018	0	0	monetary	D	C	Payments to Acquire Property, Plant, and Equipment	Capex.
+CostOfRevenue	us-gaap/2018	0	0	monetary	D	D	Cost of Revenue	Cost of revenue.
+GrossProfit	us-gaap/2018	0	0	monetary	D	C	Gross Profit	Gross profit.
+LongTermDebt	us-gaap/2018	0	0	monetary	I	C	Long-term Debt	Long-term debt.
+NatureOfOperations	us-gaap/2018	0	0	textBlock	D		Nature of Operations [Text Block]	Nature of operations.
+DocumentType	dei/2018	0	0	string	D		Document Type	Document type.
+AdvertisingRevenue	0001326801-19-000009	1	0	monetary	D	C	Advertising revenue	Revenue from ads.
+`,
+	"dim.tsv": `dimh	segments	segt
+0x00000000		0
+0xb962c3ba2680d0ffb075eb5a6a1c623e	ProductOrService=Advertising;	0
+`,
+	"num.tsv": `adsh	tag	version	ddate	qtrs	uom	dimh	iprx	value	footnote	footlen	dimn	coreg	durp	datp	dcml
+0001326801-18-000032	Revenues	us-gaap/2018	20171231	4	USD	0x00000000	1	40653000000		0	0		0.0	0.0	-6
+0001326801-18-000032	CostOfRevenue	us-gaap/2018	20171231	4	USD	0x00000000	1	5454000000		0	0		0.0	0.0	-6
+0001326801-18-000032	NetIncomeLoss	us-gaap/2018	20171231	4	USD	0x00000000	1	15934000000		0	0		0.0	0.0	-6
+0001326801-18-000032	Assets	us-gaap/2018	20171231	0	USD	0x00000000	1	84524000000		0	0		0.0	0.0	-6
+0001326801-18-000032	AssetsCurrent	us-gaap/2018	20171231	0	USD	0x00000000	1	48563000000		0	0		0.0	0.0	-6
+0001326801-18-000032	Liabilities	us-gaap/2018	20171231	0	USD	0x00000000	1	10177000000		0	0		0.0	0.0	-6
+0001326801-18-000032	LiabilitiesCurrent	us-gaap/2018	20171231	0	USD	0x00000000	1	3760000000		0	0		0.0	0.0	-6
+0001326801-18-000032	StockholdersEquity	us-gaap/2018	20171231	0	USD	0x00000000	1	74347000000		0	0		0.0	0.0	-6
+0001326801-18-000032	LongTermDebt	us-gaap/2018	20171231	0	USD	0x00000000	1	0		0	0		0.0	0.0	-6
+0001326801-18-000032	NetCashProvidedByUsedInOperatingActivities	us-gaap/2018	20171231	4	USD	0x00000000	1	24216000000		0	0		0.0	0.0	-6
+0001326801-18-000032	PaymentsToAcquirePropertyPlantAndEquipment	us-gaap/2018	20171231	4	USD	0x00000000	1	6733000000		0	0		0.0	0.0	-6
+0001326801-19-000009	Revenues	us-gaap/2018	20171231	4	USD	0x00000000	1	40653000000		0	0		0.0	0.0	-6
+0001326801-19-000009	CostOfRevenue	us-gaap/2018	20171231	4	USD	0x00000000	1	5454000000		0	0		0.0	0.0	-6
+0001326801-19-000009	NetIncomeLoss	us-gaap/2018	20171231	4	USD	0x00000000	1	15934000000		0	0		0.0	0.0	-6
+0001326801-19-000009	Assets	us-gaap/2018	20171231	0	USD	0x00000000	1	84524000000		0	0		0.0	0.0	-6
+0001326801-19-000009	AssetsCurrent	us-gaap/2018	20171231	0	USD	0x00000000	1	48563000000		0	0		0.0	0.0	-6
+0001326801-19-000009	Liabilities	us-gaap/2018	20171231	0	USD	0x00000000	1	10177000000		0	0		0.0	0.0	-6
+0001326801-19-000009	LiabilitiesCurrent	us-gaap/2018	20171231	0	USD	0x00000000	1	3760000000		0	0		0.0	0.0	-6
+0001326801-19-000009	StockholdersEquity	us-gaap/2018	20171231	0	USD	0x00000000	1	74347000000		0	0		0.0	0.0	-6
+0001326801-19-000009	LongTermDebt	us-gaap/2018	20171231	0	USD	0x00000000	1	0		0	0		0.0	0.0	-6
+0001326801-19-000009	NetCashProvidedByUsedInOperatingActivities	us-gaap/2018	20171231	4	USD	0x00000000	1	24216000000		0	0		0.0	0.0	-6
+0001326801-19-000009	PaymentsToAcquirePropertyPlantAndEquipment	us-gaap/2018	20171231	4	USD	0x00000000	1	6733000000		0	0		0.0	0.0	-6
+0001326801-19-000009	Revenues	us-gaap/2018	20181231	4	USD	0x00000000	1	55838000000		0	0		0.0	0.0	-6
+0001326801-19-000009	CostOfRevenue	us-gaap/2018	20181231	4	USD	0x00000000	1	9355000000		0	0		0.0	0.0	-6
+0001326801-19-000009	NetIncomeLoss	us-gaap/2018	20181231	4	USD	0x00000000	1	22112000000		0	0		0.0	0.0	-6
+0001326801-19-000009	Assets	us-gaap/2018	20181231	0	USD	0x00000000	1	97334000000		0	0		0.0	0.0	-6
+0001326801-19-000009	AssetsCurrent	us-gaap/2018	20181231	0	USD	0x00000000	1	50480000000		0	0		0.0	0.0	-6
+0001326801-19-000009	Liabilities	us-gaap/2018	20181231	0	USD	0x00000000	1	13207000000		0	0		0.0	0.0	-6
+0001326801-19-000009	LiabilitiesCurrent	us-gaap/2018	20181231	0	USD	0x00000000	1	7017000000		0	0		0.0	0.0	-6
+0001326801-19-000009	StockholdersEquity	us-gaap/2018	20181231	0	USD	0x00000000	1	84127000000		0	0		0.0	0.0	-6
+0001326801-19-000009	LongTermDebt	us-gaap/2018	20181231	0	USD	0x00000000	1	0		0	0		0.0	0.0	-6
+0001326801-19-000009	NetCashProvidedByUsedInOperatingActivities	us-gaap/2018	20181231	4	USD	0x00000000	1	29274000000		0	0		0.0	0.0	-6
+0001326801-19-000009	PaymentsToAcquirePropertyPlantAndEquipment	us-gaap/2018	20181231	4	USD	0x00000000	1	13915000000		0	0		0.0	0.0	-6
+0001326801-19-000009	AdvertisingRevenue	0001326801-19-000009	20181231	4	USD	0x00000000	1	55013000000		0	0		0.0	0.0	-6
+0001326801-19-000009	Revenues	us-gaap/2018	20181231	4	USD	0xb962c3ba2680d0ffb075eb5a6a1c623e	1	55013000000		0	1		0.0	0.0	-6
+0001326801-19-000009	LiabilitiesAndStockholdersEquity	us-gaap/2018	20181231	0	USD	0x00000000	1	97334000000		0	0		0.0	0.0	-6
+0001326801-19-000030	Revenues	us-gaap/2018	20181231	4	USD	0x00000000	1	55900000000		0	0		0.0	0.0	-6
+0001326801-19-000020	Revenues	us-gaap/2018	20190331	1	USD	0x00000000	1	15077000000		0	0		0.0	0.0	-6
+0001326801-19-000020	NetIncomeLoss	us-gaap/2018	20190331	1	USD	0x00000000	1	2429000000		0	0		0.0	0.0	-6
+0001326801-19-000020	Assets	us-gaap/2018	20190331	0	USD	0x00000000	1	117000000000		0	0		0.0	0.0	-6
+0001326801-19-000020	StockholdersEquity	us-gaap/2018	20190331	0	USD	0x00000000	1	88000000000		0	0		0.0	0.0	-6
+0000078003-19-000015	Revenues	us-gaap/2018	20181231	4	USD	0x00000000	1	53647000000		0	0		0.0	0.0	-6
+0000078003-19-000015	NetIncomeLoss	us-gaap/2018	20181231	4	USD	0x00000000	1	11153000000		0	0		0.0	0.0	-6
+0000078003-19-000015	Assets	us-gaap/2018	20181231	0	USD	0x00000000	1	159422000000		0	0		0.0	0.0	-6
+0000078003-19-000015	StockholdersEquity	us-gaap/2018	20181231	0	USD	0x00000000	1	63407000000		0	0		0.0	0.0	-6
+0000078003-19-000015	Liabilities	us-gaap/2018	20181231	0	USD	0x00000000	1	95664000000		0	0		0.0	0.0	-6
+0000318154-19-000010	Revenues	us-gaap/2018	20181231	4	USD	0x00000000	1	23747000000		0	0		0.0	0.0	-6
+0000318154-19-000010	NetIncomeLoss	us-gaap/2018	20181231	4	USD	0x00000000	1	8394000000		0	0		0.0	0.0	-6
+0000318154-19-000010	Assets	us-gaap/2018	20181231	0	USD	0x00000000	1	66416000000		0	0		0.0	0.0	-6
+0000318154-19-000010	StockholdersEquity	us-gaap/2018	20181231	0	USD	0x00000000	1	12500000000		0	0		0.0	0.0	-6
+0000318154-19-000010	Liabilities	us-gaap/2018	20181231	0	USD	0x00000000	1	53916000000		0	0		0.0	0.0	-6
+0000875045-19-000008	Revenues	us-gaap/2018	20181231	4	USD	0x00000000	1	13452600000		0	0		0.0	0.0	-6
+0000875045-19-000008	NetIncomeLoss	us-gaap/2018	20181231	4	USD	0x00000000	1	4430700000		0	0		0.0	0.0	-6
+0000875045-19-000008	Assets	us-gaap/2018	20181231	0	USD	0x00000000	1	25288900000		0	0		0.0	0.0	-6
+0000875045-19-000008	StockholdersEquity	us-gaap/2018	20181231	0	USD	0x00000000	1	13039800000		0	0		0.0	0.0	-6
+0000875045-19-000008	Liabilities	us-gaap/2018	20181231	0	USD	0x00000000	1	12249100000		0	0		0.0	0.0	-6
+0001682852-19-000012	Revenues	us-gaap/2018	20181231	4	USD	0x00000000	1	135100000		0	0		0.0	0.0	-6
+0001682852-19-000012	NetIncomeLoss	us-gaap/2018	20181231	4	USD	0x00000000	1	-384700000		0	0		0.0	0.0	-6
+0001682852-19-000012	Assets	us-gaap/2018	20181231	0	USD	0x00000000	1	1962200000		0	0		0.0	0.0	-6
+0001682852-19-000012	StockholdersEquity	us-gaap/2018	20181231	0	USD	0x00000000	1	1529500000		0	0		0.0	0.0	-6
+0001682852-19-000012	Liabilities	us-gaap/2018	20181231	0	USD	0x00000000	1	432700000		0	0		0.0	0.0	-6
+`,
+	"txt.tsv": `adsh	tag	version	ddate	qtrs	iprx	lang	dcml	durp	datp	dimh	dimn	coreg	escaped	srclen	txtlen	footnote	footlen	context	value
+0001326801-19-000009	NatureOfOperations	us-gaap/2018	20181231	4	1	en-US	32767	0.0	0.0	0x00000000	0		1	200	180			FD2018Q4YTD	Organization and Description of Business. Facebook was incorporated in Delaware in July 2004. Our mission is to give people the power to build community.
+0001326801-19-000009	DocumentType	dei/2018	20181231	4	1	en-US	32767	0.0	0.0	0x00000000	0		0	4	4			FD2018Q4YTD	10-K
+`,
+	"ren.tsv": `adsh	report	rfile	menucat	shortname	longname	roleuri	parentroleuri	parentreport	ultparentrpt
+0001326801-19-000009	1	H	C	Document and Entity Information	0001000 - Document - Document and Entity Information	http://fb.com/role/DocumentAndEntityInformation			1
+0001326801-19-000009	2	H	S	CONSOLIDATED BALANCE SHEETS	1001000 - Statement - CONSOLIDATED BALANCE SHEETS	http://fb.com/role/BalanceSheets			2
+0001326801-19-000009	4	H	S	CONSOLIDATED STATEMENTS OF INCOME	1003000 - Statement - CONSOLIDATED STATEMENTS OF INCOME	http://fb.com/role/Income			4
+0001326801-19-000009	7	H	S	CONSOLIDATED STATEMENTS OF CASH FLOWS	1007000 - Statement - CONSOLIDATED STATEMENTS OF CASH FLOWS	http://fb.com/role/CashFlows			7
+0001326801-19-000009	8	H	N	Summary of Significant Accounting Policies	2101100 - Disclosure - Summary of Significant Accounting Policies	http://fb.com/role/Policies			8
+0001326801-19-000009	20	H	P	Summary of Significant Accounting Policies (Policies)	2201201 - Disclosure - Summary of Significant Accounting Policies (Policies)	http://fb.com/role/PoliciesPolicies	http://fb.com/role/Policies	8	8
+0001326801-19-000009	30	H	D	Revenue by product (Details)	2402402 - Disclosure - Revenue by product (Details)	http://fb.com/role/RevenueDetails	http://fb.com/role/Policies	8	8
+`,
+	"pre.tsv": `adsh	report	line	stmt	inpth	tag	version	prole	plabel	negating
+0001326801-19-000009	2	1	BS	0	AssetsCurrent	us-gaap/2018	terseLabel	Total current assets	0
+0001326801-19-000009	2	2	BS	0	Assets	us-gaap/2018	totalLabel	Total assets	0
+0001326801-19-000009	2	3	BS	0	LiabilitiesCurrent	us-gaap/2018	totalLabel	Total current liabilities	0
+0001326801-19-000009	2	4	BS	0	Liabilities	us-gaap/2018	totalLabel	Total liabilities	0
+0001326801-19-000009	2	5	BS	0	StockholdersEquity	us-gaap/2018	totalLabel	Total stockholders' equity	0
+0001326801-19-000009	2	6	BS	0	LiabilitiesAndStockholdersEquity	us-gaap/2018	totalLabel	Total liabilities and stockholders' equity	0
+0001326801-19-000009	4	1	IS	0	Revenues	us-gaap/2018	terseLabel	Revenue	0
+0001326801-19-000009	4	2	IS	0	AdvertisingRevenue	0001326801-19-000009	terseLabel	Advertising	0
+0001326801-19-000009	4	3	IS	0	CostOfRevenue	us-gaap/2018	terseLabel	Cost of revenue	0
+0001326801-19-000009	4	4	IS	0	NetIncomeLoss	us-gaap/2018	terseLabel	Net income	0
+0001326801-19-000009	7	1	CF	0	NetCashProvidedByUsedInOperatingActivities	us-gaap/2018	totalLabel	Net cash provided by operating activities	0
+0001326801-19-000009	7	2	CF	0	PaymentsToAcquirePropertyPlantAndEquipment	us-gaap/2018	negatedLabel	Purchases of property and equipment	1
+0001326801-19-000009	30	1	UN	0	Revenues	us-gaap/2018	terseLabel	Revenue	0
+`,
+	"cal.tsv": `adsh	grp	arc	negative	ptag	pversion	ctag	cversion
+0001326801-19-000009	1	1	0	Revenues	us-gaap/2018	AdvertisingRevenue	0001326801-19-000009
+0001326801-19-000009	2	1	0	LiabilitiesAndStockholdersEquity	us-gaap/2018	Liabilities	us-gaap/2018
+0001326801-19-000009	2	2	0	LiabilitiesAndStockholdersEquity	us-gaap/2018	StockholdersEquity	us-gaap/2018
+`,
+}
+
+// SampleTickers is a company_tickers.json of the companies of SampleFiles
+const SampleTickers = `{
+  "0": {"cik_str": 1326801, "ticker": "FB", "title": "Facebook Inc"},
+  "1": {"cik_str": 78003, "ticker": "PFE", "title": "Pfizer Inc"},
+  "2": {"cik_str": 318154, "ticker": "AMGN", "title": "Amgen Inc"},
+  "3": {"cik_str": 875045, "ticker": "BIIB", "title": "Biogen Inc"},
+  "4": {"cik_str": 1682852, "ticker": "MRNA", "title": "Moderna Inc"}
+}`
+
+// fileOrder is the order of the files in the archives of sec.gov
+var fileOrder = []string{"sub.tsv", "tag.tsv", "dim.tsv", "num.tsv", "txt.tsv", "ren.tsv", "pre.tsv", "cal.tsv"}
+
+// Zip returns a data set archive of files, a map of file name (num.tsv...)
+// to content
+func Zip(files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	others := []string{}
+	for name := range files {
+		if !contains(fileOrder, name) {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	names := append(append([]string{}, fileOrder...), others...)
+	for _, name := range names {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		f, err := w.Create(name)
+		if err != nil {
+			panic(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+// SampleArchive returns the archive of SampleFiles
+func SampleArchive() []byte {
+	return Zip(SampleFiles)
+}
+
+// statementsColumns are the columns of the files of the Financial Statement
+// data sets
+var statementsColumns = map[string][]string{
+	"sub.tsv": {"adsh", "cik", "name", "sic", "countryba", "stprba", "cityba", "zipba", "bas1", "bas2", "baph", "countryma", "stprma", "cityma", "zipma", "mas1", "mas2", "countryinc", "stprinc", "ein", "former", "changed", "afs", "wksi", "fye", "form", "period", "fy", "fp", "filed", "accepted", "prevrpt", "detail", "instance", "nciks", "aciks"},
+	"tag.tsv": {"tag", "version", "custom", "abstract", "datatype", "iord", "crdr", "tlabel", "doc"},
+	"num.tsv": {"adsh", "tag", "version", "coreg", "ddate", "qtrs", "uom", "value", "footnote"},
+	"pre.tsv": {"adsh", "report", "line", "stmt", "inpth", "rfile", "tag", "version", "plabel", "negating"},
+}
+
+// SampleStatementsFiles returns SampleFiles as a Financial Statement data set
+// (2019q1.zip) would have them: sub.tsv, tag.tsv, num.tsv and pre.tsv only,
+// with their columns, and the facts of the primary reports without
+// dimensions
+func SampleStatementsFiles() map[string]string {
+	files := map[string]string{}
+	for name, columns := range statementsColumns {
+		lines := strings.Split(strings.TrimSuffix(SampleFiles[name], "\n"), "\n")
+		header := strings.Split(lines[0], "\t")
+		out := []string{strings.Join(columns, "\t")}
+		for _, line := range lines[1:] {
+			fields := map[string]string{"rfile": "H"}
+			for i, field := range strings.Split(line, "\t") {
+				fields[header[i]] = field
+			}
+			if name == "num.tsv" && (fields["dimh"] != "0x00000000" || fields["iprx"] != "1") {
+				continue
+			}
+			values := []string{}
+			for _, column := range columns {
+				values = append(values, fields[column])
+			}
+			out = append(out, strings.Join(values, "\t"))
+		}
+		files[name] = strings.Join(out, "\n") + "\n"
+	}
+	return files
+}
+
+// SampleStatementsArchive returns the archive of SampleStatementsFiles
+func SampleStatementsArchive() []byte {
+	return Zip(SampleStatementsFiles())
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+// Package sectest provides a fake sec.gov for tests: a httptest server
+// publishing data set archives the way sec.gov does, with fixtures.
+//
+//	srv := sectest.NewServer(map[string][]byte{"2019q1_notes.zip": sectest.SampleArchive()})
+//	defer srv.Close()
+//	db, err := filingsdb.Open(file, filingsdb.WithClient(srv.Client()))
+//	archives, err := srv.Client().ListArchives(ctx)
+//	err = db.Ingest(ctx, archives[0].URL)
+package sectest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"eswiac.me/filingsdb"
+)
+
+const (
+	// IndexPath is the page listing the notes archives (*_notes.zip)
+	IndexPath = "/dera/data/financial-statement-and-notes-data-set.html"
+	// ArchivesPath is the directory of the notes archives
+	ArchivesPath = "/files/dera/data/financial-statement-and-notes-data-sets/"
+	// StatementsIndexPath is the page listing the other archives, the
+	// Financial Statement data sets
+	StatementsIndexPath = "/dera/data/financial-statement-data-sets.html"
+	// StatementsArchivesPath is the directory of the statements archives
+	StatementsArchivesPath = "/files/dera/data/financial-statement-data-sets/"
+	// TickersPath is the company tickers JSON
+	TickersPath = "/files/company_tickers.json"
+)
+
+// Server is a fake sec.gov. Archives are served with an ETag and a
+// Last-Modified date, answering conditional and range requests.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	archives map[string]archive
+	tickers  string
+	failures []int
+	requests []Request
+}
+
+type archive struct {
+	content  []byte
+	etag     string
+	modified time.Time
+}
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+}
+
+// NewServer starts a server publishing archives, a map of file name (e.g.
+// 2019q1_notes.zip) to content, and SampleTickers
+func NewServer(archives map[string][]byte) *Server {
+	s := &Server{archives: map[string]archive{}, tickers: SampleTickers}
+	for name, content := range archives {
+		s.SetArchive(name, content)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// SetArchive publishes or replaces an archive
+func (s *Server) SetArchive(name string, content []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.archives[name] = archive{
+		content:  content,
+		etag:     fmt.Sprintf(`"%x"`, sha256.Sum256(content)),
+		modified: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// SetTickers replaces the company tickers JSON
+func (s *Server) SetTickers(tickers string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tickers = tickers
+}
+
+// Fail answers the next requests with statuses, e.g. Fail(503, 429) to test
+// retries
+func (s *Server) Fail(statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, statuses...)
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+// ArchiveURL returns the URL of an archive
+func (s *Server) ArchiveURL(name string) string {
+	return s.URL + archivesPath(name) + name
+}
+
+// archivesPath returns the directory of an archive, depending on its data
+// sets
+func archivesPath(name string) string {
+	if strings.HasSuffix(name, "_notes.zip") {
+		return ArchivesPath
+	}
+	return StatementsArchivesPath
+}
+
+// Client returns a client of the server, without rate limit nor backoff delay
+func (s *Server) Client() *filingsdb.Client {
+	c := filingsdb.NewClient("sectest test@example.com")
+	c.BaseURL = s.URL
+	c.RateLimit = 0
+	c.Backoff = time.Millisecond
+	c.MaxBackoff = time.Millisecond
+	return c
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Header: r.Header.Clone()})
+	if len(s.failures) > 0 {
+		status := s.failures[0]
+		s.failures = s.failures[1:]
+		s.mu.Unlock()
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	archives := map[string]archive{}
+	for name, a := range s.archives {
+		archives[name] = a
+	}
+	tickers := s.tickers
+	s.mu.Unlock()
+
+	switch {
+	case r.URL.Path == IndexPath || r.URL.Path == StatementsIndexPath:
+		dir := ArchivesPath
+		if r.URL.Path == StatementsIndexPath {
+			dir = StatementsArchivesPath
+		}
+		names := []string{}
+		for name := range archives {
+			if archivesPath(name) == dir {
+				names = append(names, name)
+			}
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(names)))
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><body><table>\n")
+		for _, name := range names {
+			fmt.Fprintf(w, "<tr><td><a href=\"%s%s\">%s</a></td></tr>\n", dir, name, strings.TrimSuffix(name, ".zip"))
+		}
+		fmt.Fprint(w, "</table></body></html>\n")
+	case r.URL.Path == TickersPath:
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, tickers)
+	case strings.HasPrefix(r.URL.Path, ArchivesPath) || strings.HasPrefix(r.URL.Path, StatementsArchivesPath):
+		name := path.Base(r.URL.Path)
+		a, ok := archives[name]
+		if !ok || r.URL.Path != archivesPath(name)+name {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("ETag", a.etag)
+		http.ServeContent(w, r, r.URL.Path, a.modified, bytes.NewReader(a.content))
+	default:
+		http.NotFound(w, r)
+	}
+}
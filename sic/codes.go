@@ -0,0 +1,539 @@
+package sic
+
+// Codes maps the Standard Industrial Classification codes assigned by the
+// Commission to registrants to their industry title, as listed on
+// https://www.sec.gov/info/edgar/siccodes.htm
+var Codes = map[string]string{
+	"0100": "AGRICULTURAL PRODUCTION-CROPS",
+	"0200": "AGRICULTURAL PROD-LIVESTOCK & ANIMAL SPECIALTIES",
+	"0700": "AGRICULTURAL SERVICES",
+	"0800": "FORESTRY",
+	"0900": "FISHING, HUNTING AND TRAPPING",
+	"1000": "METAL MINING",
+	"1040": "GOLD AND SILVER ORES",
+	"1090": "MISCELLANEOUS METAL ORES",
+	"1220": "BITUMINOUS COAL & LIGNITE MINING",
+	"1221": "BITUMINOUS COAL & LIGNITE SURFACE MINING",
+	"1311": "CRUDE PETROLEUM & NATURAL GAS",
+	"1381": "DRILLING OIL & GAS WELLS",
+	"1382": "OIL & GAS FIELD EXPLORATION SERVICES",
+	"1389": "OIL & GAS FIELD SERVICES, NEC",
+	"1400": "MINING & QUARRYING OF NONMETALLIC MINERALS (NO FUELS)",
+	"1520": "GENERAL BLDG CONTRACTORS - RESIDENTIAL BLDGS",
+	"1531": "OPERATIVE BUILDERS",
+	"1540": "GENERAL BLDG CONTRACTORS - NONRESIDENTIAL BLDGS",
+	"1600": "HEAVY CONSTRUCTION OTHER THAN BLDG CONST - CONTRACTORS",
+	"1623": "WATER, SEWER, PIPELINE, COMM & POWER LINE CONSTRUCTION",
+	"1700": "CONSTRUCTION - SPECIAL TRADE CONTRACTORS",
+	"1731": "ELECTRICAL WORK",
+	"2000": "FOOD AND KINDRED PRODUCTS",
+	"2011": "MEAT PACKING PLANTS",
+	"2013": "SAUSAGES & OTHER PREPARED MEAT PRODUCTS",
+	"2015": "POULTRY SLAUGHTERING AND PROCESSING",
+	"2020": "DAIRY PRODUCTS",
+	"2024": "ICE CREAM & FROZEN DESSERTS",
+	"2030": "CANNED, FROZEN & PRESERVD FRUIT, VEG & FOOD SPECIALTIES",
+	"2033": "CANNED, FRUITS, VEG, PRESERVES, JAMS & JELLIES",
+	"2040": "GRAIN MILL PRODUCTS",
+	"2050": "BAKERY PRODUCTS",
+	"2052": "COOKIES & CRACKERS",
+	"2060": "SUGAR & CONFECTIONERY PRODUCTS",
+	"2070": "FATS & OILS",
+	"2080": "BEVERAGES",
+	"2082": "MALT BEVERAGES",
+	"2086": "BOTTLED & CANNED SOFT DRINKS & CARBONATED WATERS",
+	"2090": "MISCELLANEOUS FOOD PREPARATIONS & KINDRED PRODUCTS",
+	"2092": "PREPARED FRESH OR FROZEN FISH & SEAFOODS",
+	"2100": "TOBACCO PRODUCTS",
+	"2111": "CIGARETTES",
+	"2200": "TEXTILE MILL PRODUCTS",
+	"2211": "BROADWOVEN FABRIC MILLS, COTTON",
+	"2221": "BROADWOVEN FABRIC MILLS, MAN MADE FIBER & SILK",
+	"2250": "KNITTING MILLS",
+	"2253": "KNIT OUTERWEAR MILLS",
+	"2273": "CARPETS & RUGS",
+	"2300": "APPAREL & OTHER FINISHD PRODS OF FABRICS & SIMILAR MATL",
+	"2320": "MEN'S & BOYS' FURNISHGS, WORK CLOTHG, & ALLIED GARMENTS",
+	"2330": "WOMEN'S, MISSES', AND JUNIORS OUTERWEAR",
+	"2340": "WOMEN'S, MISSES', CHILDREN'S & INFANTS' UNDERGARMENTS",
+	"2390": "MISCELLANEOUS FABRICATED TEXTILE PRODUCTS",
+	"2400": "LUMBER & WOOD PRODUCTS (NO FURNITURE)",
+	"2421": "SAWMILLS & PLANTING MILLS, GENERAL",
+	"2430": "MILLWOOD, VENEER, PLYWOOD, & STRUCTURAL WOOD MEMBERS",
+	"2450": "WOOD BUILDINGS & MOBILE HOMES",
+	"2451": "MOBILE HOMES",
+	"2452": "PREFABRICATED WOOD BLDGS & COMPONENTS",
+	"2510": "HOUSEHOLD FURNITURE",
+	"2511": "WOOD HOUSEHOLD FURNITURE, (NO UPHOLSTERED)",
+	"2520": "OFFICE FURNITURE",
+	"2522": "OFFICE FURNITURE (NO WOOD)",
+	"2531": "PUBLIC BLDG & RELATED FURNITURE",
+	"2540": "PARTITIONS, SHELVG, LOCKERS, & OFFICE & STORE FIXTURES",
+	"2590": "MISCELLANEOUS FURNITURE & FIXTURES",
+	"2600": "PAPERS & ALLIED PRODUCTS",
+	"2611": "PULP MILLS",
+	"2621": "PAPER MILLS",
+	"2631": "PAPERBOARD MILLS",
+	"2650": "PAPERBOARD CONTAINERS & BOXES",
+	"2670": "CONVERTED PAPER & PAPERBOARD PRODS (NO CONTANERS/BOXES)",
+	"2673": "PLASTICS, FOIL & COATED PAPER BAGS",
+	"2711": "NEWSPAPERS: PUBLISHING OR PUBLISHING & PRINTING",
+	"2721": "PERIODICALS: PUBLISHING OR PUBLISHING & PRINTING",
+	"2731": "BOOKS: PUBLISHING OR PUBLISHING & PRINTING",
+	"2732": "BOOK PRINTING",
+	"2741": "MISCELLANEOUS PUBLISHING",
+	"2750": "COMMERCIAL PRINTING",
+	"2761": "MANIFOLD BUSINESS FORMS",
+	"2771": "GREETING CARDS",
+	"2780": "BLANKBOOKS, LOOSELEAF BINDERS & BOOKBINDG & RELATD WORK",
+	"2790": "SERVICE INDUSTRIES FOR THE PRINTING TRADE",
+	"2800": "CHEMICALS & ALLIED PRODUCTS",
+	"2810": "INDUSTRIAL INORGANIC CHEMICALS",
+	"2820": "PLASTIC MATERIAL, SYNTH RESIN/RUBBER, CELLULOS (NO GLASS)",
+	"2821": "PLASTIC MATERIALS, SYNTH RESINS & NONVULCAN ELASTOMERS",
+	"2833": "MEDICINAL CHEMICALS & BOTANICAL PRODUCTS",
+	"2834": "PHARMACEUTICAL PREPARATIONS",
+	"2835": "IN VITRO & IN VIVO DIAGNOSTIC SUBSTANCES",
+	"2836": "BIOLOGICAL PRODUCTS, (NO DIAGNOSTIC SUBSTANCES)",
+	"2840": "SOAP, DETERGENTS, CLEANG PREPARATIONS, PERFUMES, COSMETICS",
+	"2842": "SPECIALTY CLEANING, POLISHING AND SANITATION PREPARATIONS",
+	"2844": "PERFUMES, COSMETICS & OTHER TOILET PREPARATIONS",
+	"2851": "PAINTS, VARNISHES, LACQUERS, ENAMELS & ALLIED PRODS",
+	"2860": "INDUSTRIAL ORGANIC CHEMICALS",
+	"2870": "AGRICULTURAL CHEMICALS",
+	"2890": "MISCELLANEOUS CHEMICAL PRODUCTS",
+	"2891": "ADHESIVES & SEALANTS",
+	"2911": "PETROLEUM REFINING",
+	"2950": "ASPHALT PAVING & ROOFING MATERIALS",
+	"2990": "MISCELLANEOUS PRODUCTS OF PETROLEUM & COAL",
+	"3011": "TIRES & INNER TUBES",
+	"3021": "RUBBER & PLASTICS FOOTWEAR",
+	"3050": "GASKETS, PACKG & SEALG DEVICES & RUBBER & PLASTICS HOSE",
+	"3060": "FABRICATED RUBBER PRODUCTS, NEC",
+	"3080": "MISCELLANEOUS PLASTICS PRODUCTS",
+	"3081": "UNSUPPORTED PLASTICS FILM & SHEET",
+	"3086": "PLASTICS FOAM PRODUCTS",
+	"3089": "PLASTICS PRODUCTS, NEC",
+	"3100": "LEATHER & LEATHER PRODUCTS",
+	"3140": "FOOTWEAR, (NO RUBBER)",
+	"3211": "FLAT GLASS",
+	"3220": "GLASS & GLASSWARE, PRESSED OR BLOWN",
+	"3221": "GLASS CONTAINERS",
+	"3231": "GLASS PRODUCTS, MADE OF PURCHASED GLASS",
+	"3241": "CEMENT, HYDRAULIC",
+	"3250": "STRUCTURAL CLAY PRODUCTS",
+	"3260": "POTTERY & RELATED PRODUCTS",
+	"3270": "CONCRETE, GYPSUM & PLASTER PRODUCTS",
+	"3272": "CONCRETE PRODUCTS, EXCEPT BLOCK & BRICK",
+	"3281": "CUT STONE & STONE PRODUCTS",
+	"3290": "ABRASIVE, ASBESTOS & MISC NONMETALLIC MINERAL PRODS",
+	"3310": "STEEL WORKS, BLAST FURNACES & ROLLING & FINISHING MILLS",
+	"3312": "STEEL WORKS, BLAST FURNACES & ROLLING MILLS (COKE OVENS)",
+	"3317": "STEEL PIPE & TUBES",
+	"3320": "IRON & STEEL FOUNDRIES",
+	"3330": "PRIMARY SMELTING & REFINING OF NONFERROUS METALS",
+	"3334": "PRIMARY PRODUCTION OF ALUMINUM",
+	"3341": "SECONDARY SMELTING & REFINING OF NONFERROUS METALS",
+	"3350": "ROLLING DRAWING & EXTRUDING OF NONFERROUS METALS",
+	"3357": "DRAWING & INSULATING OF NONFERROUS WIRE",
+	"3360": "NONFERROUS FOUNDRIES (CASTINGS)",
+	"3390": "MISCELLANEOUS PRIMARY METAL PRODUCTS",
+	"3411": "METAL CANS",
+	"3412": "METAL SHIPPING BARRELS, DRUMS, KEGS & PAILS",
+	"3420": "CUTLERY, HANDTOOLS & GENERAL HARDWARE",
+	"3430": "HEATING EQUIP, EXCEPT ELEC & WARM AIR; & PLUMBING FIXTURES",
+	"3433": "HEATING EQUIPMENT, EXCEPT ELECTRIC & WARM AIR FURNACES",
+	"3440": "FABRICATED STRUCTURAL METAL PRODUCTS",
+	"3442": "METAL DOORS, SASH, FRAMES, MOLDINGS & TRIM",
+	"3443": "FABRICATED PLATE WORK (BOILER SHOPS)",
+	"3444": "SHEET METAL WORK",
+	"3448": "PREFABRICATED METAL BUILDINGS & COMPONENTS",
+	"3451": "SCREW MACHINE PRODUCTS",
+	"3452": "BOLTS, NUTS, SCREWS, RIVETS & WASHERS",
+	"3460": "METAL FORGINGS & STAMPINGS",
+	"3470": "COATING, ENGRAVING & ALLIED SERVICES",
+	"3480": "ORDNANCE & ACCESSORIES, (NO VEHICLES/GUIDED MISSILES)",
+	"3490": "MISCELLANEOUS FABRICATED METAL PRODUCTS",
+	"3510": "ENGINES & TURBINES",
+	"3523": "FARM MACHINERY & EQUIPMENT",
+	"3524": "LAWN & GARDEN TRACTORS & HOME LAWN & GARDENS EQUIP",
+	"3530": "CONSTRUCTION, MINING & MATERIALS HANDLING MACHINERY & EQUIP",
+	"3531": "CONSTRUCTION MACHINERY & EQUIP",
+	"3532": "MINING MACHINERY & EQUIP (NO OIL & GAS FIELD MACH & EQUIP)",
+	"3533": "OIL & GAS FIELD MACHINERY & EQUIPMENT",
+	"3537": "INDUSTRIAL TRUCKS, TRACTORS, TRAILORS & STACKERS",
+	"3540": "METALWORKG MACHINERY & EQUIPMENT",
+	"3541": "MACHINE TOOLS, METAL CUTTING TYPES",
+	"3550": "SPECIAL INDUSTRY MACHINERY (NO METALWORKING MACHINERY)",
+	"3555": "PRINTING TRADES MACHINERY & EQUIPMENT",
+	"3559": "SPECIAL INDUSTRY MACHINERY, NEC",
+	"3560": "GENERAL INDUSTRIAL MACHINERY & EQUIPMENT",
+	"3561": "PUMPS & PUMPING EQUIPMENT",
+	"3562": "BALL & ROLLER BEARINGS",
+	"3564": "INDUSTRIAL & COMMERCIAL FANS & BLOWERS & AIR PURIFING EQUIP",
+	"3567": "INDUSTRIAL PROCESS FURNACES & OVENS",
+	"3569": "GENERAL INDUSTRIAL MACHINERY & EQUIPMENT, NEC",
+	"3570": "COMPUTER & OFFICE EQUIPMENT",
+	"3571": "ELECTRONIC COMPUTERS",
+	"3572": "COMPUTER STORAGE DEVICES",
+	"3575": "COMPUTER TERMINALS",
+	"3576": "COMPUTER COMMUNICATIONS EQUIPMENT",
+	"3577": "COMPUTER PERIPHERAL EQUIPMENT, NEC",
+	"3578": "CALCULATING & ACCOUNTING MACHINES (NO ELECTRONIC COMPUTERS)",
+	"3579": "OFFICE MACHINES, NEC",
+	"3580": "REFRIGERATION & SERVICE INDUSTRY MACHINERY",
+	"3585": "AIR-COND & WARM AIR HEATG EQUIP & COMM & INDL REFRIG EQUIP",
+	"3590": "MISC INDUSTRIAL & COMMERCIAL MACHINERY & EQUIPMENT",
+	"3600": "ELECTRONIC & OTHER ELECTRICAL EQUIPMENT (NO COMPUTER EQUIP)",
+	"3612": "POWER, DISTRIBUTION & SPECIALTY TRANSFORMERS",
+	"3613": "SWITCHGEAR & SWITCHBOARD APPARATUS",
+	"3620": "ELECTRICAL INDUSTRIAL APPARATUS",
+	"3621": "MOTORS & GENERATORS",
+	"3630": "HOUSEHOLD APPLIANCES",
+	"3634": "ELECTRIC HOUSEWARES & FANS",
+	"3640": "ELECTRIC LIGHTING & WIRING EQUIPMENT",
+	"3651": "HOUSEHOLD AUDIO & VIDEO EQUIPMENT",
+	"3652": "PHONOGRAPH RECORDS & PRERECORDED AUDIO TAPES & DISKS",
+	"3661": "TELEPHONE & TELEGRAPH APPARATUS",
+	"3663": "RADIO & TV BROADCASTING & COMMUNICATIONS EQUIPMENT",
+	"3669": "COMMUNICATIONS EQUIPMENT, NEC",
+	"3670": "ELECTRONIC COMPONENTS & ACCESSORIES",
+	"3672": "PRINTED CIRCUIT BOARDS",
+	"3674": "SEMICONDUCTORS & RELATED DEVICES",
+	"3677": "ELECTRONIC COILS, TRANSFORMERS & OTHER INDUCTORS",
+	"3678": "ELECTRONIC CONNECTORS",
+	"3679": "ELECTRONIC COMPONENTS, NEC",
+	"3690": "MISCELLANEOUS ELECTRICAL MACHINERY, EQUIPMENT & SUPPLIES",
+	"3695": "MAGNETIC & OPTICAL RECORDING MEDIA",
+	"3711": "MOTOR VEHICLES & PASSENGER CAR BODIES",
+	"3713": "TRUCK & BUS BODIES",
+	"3714": "MOTOR VEHICLE PARTS & ACCESSORIES",
+	"3715": "TRUCK TRAILERS",
+	"3716": "MOTOR HOMES",
+	"3720": "AIRCRAFT & PARTS",
+	"3721": "AIRCRAFT",
+	"3724": "AIRCRAFT ENGINES & ENGINE PARTS",
+	"3728": "AIRCRAFT PARTS & AUXILIARY EQUIPMENT, NEC",
+	"3730": "SHIP & BOAT BUILDING & REPAIRING",
+	"3740": "RAILROAD EQUIPMENT",
+	"3751": "MOTORCYCLES, BICYCLES & PARTS",
+	"3760": "GUIDED MISSILES & SPACE VEHICLES & PARTS",
+	"3790": "MISCELLANEOUS TRANSPORTATION EQUIPMENT",
+	"3812": "SEARCH, DETECTION, NAVIGATION, GUIDANCE, AERONAUTICAL SYS",
+	"3821": "LABORATORY APPARATUS & FURNITURE",
+	"3822": "AUTO CONTROLS FOR REGULATING RESIDENTIAL & COMML ENVIRONMENTS",
+	"3823": "INDUSTRIAL INSTRUMENTS FOR MEASUREMENT, DISPLAY, AND CONTROL",
+	"3824": "TOTALIZING FLUID METERS & COUNTING DEVICES",
+	"3825": "INSTRUMENTS FOR MEAS & TESTING OF ELECTRICITY & ELEC SIGNALS",
+	"3826": "LABORATORY ANALYTICAL INSTRUMENTS",
+	"3827": "OPTICAL INSTRUMENTS & LENSES",
+	"3829": "MEASURING & CONTROLLING DEVICES, NEC",
+	"3841": "SURGICAL & MEDICAL INSTRUMENTS & APPARATUS",
+	"3842": "ORTHOPEDIC, PROSTHETIC & SURGICAL APPLIANCES & SUPPLIES",
+	"3843": "DENTAL EQUIPMENT & SUPPLIES",
+	"3844": "X-RAY APPARATUS & TUBES & RELATED IRRADIATION APPARATUS",
+	"3845": "ELECTROMEDICAL & ELECTROTHERAPEUTIC APPARATUS",
+	"3851": "OPHTHALMIC GOODS",
+	"3861": "PHOTOGRAPHIC EQUIPMENT & SUPPLIES",
+	"3873": "WATCHES, CLOCKS, CLOCKWORK OPERATED DEVICES/PARTS",
+	"3910": "JEWELRY, SILVERWARE & PLATED WARE",
+	"3911": "JEWELRY, PRECIOUS METAL",
+	"3940": "DOLLS & STUFFED TOYS",
+	"3942": "DOLLS & STUFFED TOYS",
+	"3944": "GAMES, TOYS & CHILDREN'S VEHICLES (NO DOLLS & BICYCLES)",
+	"3949": "SPORTING & ATHLETIC GOODS, NEC",
+	"3950": "PENS, PENCILS & OTHER ARTISTS' MATERIALS",
+	"3960": "COSTUME JEWELRY & NOVELTIES",
+	"3990": "MISCELLANEOUS MANUFACTURING INDUSTRIES",
+	"4011": "RAILROADS, LINE-HAUL OPERATING",
+	"4013": "RAILROAD SWITCHING & TERMINAL ESTABLISHMENTS",
+	"4100": "LOCAL & SUBURBAN TRANSIT & INTERURBAN HWY PASSENGER TRANS",
+	"4210": "TRUCKING & COURIER SERVICES (NO AIR)",
+	"4213": "TRUCKING (NO LOCAL)",
+	"4220": "PUBLIC WAREHOUSING & STORAGE",
+	"4231": "TERMINAL MAINTENANCE FACILITIES FOR MOTOR FREIGHT TRANSPORT",
+	"4400": "WATER TRANSPORTATION",
+	"4412": "DEEP SEA FOREIGN TRANSPORTATION OF FREIGHT",
+	"4512": "AIR TRANSPORTATION, SCHEDULED",
+	"4513": "AIR COURIER SERVICES",
+	"4522": "AIR TRANSPORTATION, NONSCHEDULED",
+	"4581": "AIRPORTS, FLYING FIELDS & AIRPORT TERMINAL SERVICES",
+	"4610": "PIPE LINES (NO NATURAL GAS)",
+	"4700": "TRANSPORTATION SERVICES",
+	"4731": "ARRANGEMENT OF TRANSPORTATION OF FREIGHT & CARGO",
+	"4812": "RADIOTELEPHONE COMMUNICATIONS",
+	"4813": "TELEPHONE COMMUNICATIONS (NO RADIOTELEPHONE)",
+	"4822": "TELEGRAPH & OTHER MESSAGE COMMUNICATIONS",
+	"4832": "RADIO BROADCASTING STATIONS",
+	"4833": "TELEVISION BROADCASTING STATIONS",
+	"4841": "CABLE & OTHER PAY TELEVISION SERVICES",
+	"4899": "COMMUNICATIONS SERVICES, NEC",
+	"4900": "ELECTRIC, GAS & SANITARY SERVICES",
+	"4911": "ELECTRIC SERVICES",
+	"4922": "NATURAL GAS TRANSMISSION",
+	"4923": "NATURAL GAS TRANSMISISON & DISTRIBUTION",
+	"4924": "NATURAL GAS DISTRIBUTION",
+	"4931": "ELECTRIC & OTHER SERVICES COMBINED",
+	"4932": "GAS & OTHER SERVICES COMBINED",
+	"4940": "WATER SUPPLY",
+	"4950": "SANITARY SERVICES",
+	"4953": "REFUSE SYSTEMS",
+	"4955": "HAZARDOUS WASTE MANAGEMENT",
+	"4961": "STEAM & AIR-CONDITIONING SUPPLY",
+	"4991": "COGENERATION SERVICES & SMALL POWER PRODUCERS",
+	"5000": "WHOLESALE-DURABLE GOODS",
+	"5010": "WHOLESALE-MOTOR VEHICLES & MOTOR VEHICLE PARTS & SUPPLIES",
+	"5013": "WHOLESALE-MOTOR VEHICLE SUPPLIES & NEW PARTS",
+	"5020": "WHOLESALE-FURNITURE & HOME FURNISHINGS",
+	"5030": "WHOLESALE-LUMBER & OTHER CONSTRUCTION MATERIALS",
+	"5031": "WHOLESALE-LUMBER, PLYWOOD, MILLWORK & WOOD PANELS",
+	"5040": "WHOLESALE-PROFESSIONAL & COMMERCIAL EQUIPMENT & SUPPLIES",
+	"5045": "WHOLESALE-COMPUTERS & PERIPHERAL EQUIPMENT & SOFTWARE",
+	"5047": "WHOLESALE-MEDICAL, DENTAL & HOSPITAL EQUIPMENT & SUPPLIES",
+	"5050": "WHOLESALE-METALS SERVICE CENTERS & OFFICES",
+	"5051": "WHOLESALE-METALS SERVICE CENTERS & OFFICES",
+	"5063": "WHOLESALE-ELECTRICAL APPARATUS & EQUIPMENT, WIRING SUPPLIES",
+	"5064": "WHOLESALE-ELECTRICAL APPLIANCES, TV & RADIO SETS",
+	"5065": "WHOLESALE-ELECTRONIC PARTS & EQUIPMENT, NEC",
+	"5070": "WHOLESALE-HARDWARE & PLUMBING & HEATING EQUIPMENT & SUPPLIES",
+	"5072": "WHOLESALE-HARDWARE",
+	"5080": "WHOLESALE-MACHINERY, EQUIPMENT & SUPPLIES",
+	"5082": "WHOLESALE-CONSTRUCTION & MINING (NO PETRO) MACHINERY & EQUIP",
+	"5084": "WHOLESALE-INDUSTRIAL MACHINERY & EQUIPMENT",
+	"5090": "WHOLESALE-MISC DURABLE GOODS",
+	"5094": "WHOLESALE-JEWELRY, WATCHES, PRECIOUS STONES & METALS",
+	"5099": "WHOLESALE-DURABLE GOODS, NEC",
+	"5110": "WHOLESALE-PAPER AND PAPER PRODUCTS",
+	"5122": "WHOLESALE-DRUGS PROPRIETARIES & DRUGGISTS' SUNDRIES",
+	"5130": "WHOLESALE-APPAREL, PIECE GOODS & NOTIONS",
+	"5140": "WHOLESALE-GROCERIES & RELATED PRODUCTS",
+	"5141": "WHOLESALE-GROCERIES, GENERAL LINE",
+	"5150": "WHOLESALE-FARM PRODUCT RAW MATERIALS",
+	"5160": "WHOLESALE-CHEMICALS & ALLIED PRODUCTS",
+	"5171": "WHOLESALE-PETROLEUM BULK STATIONS & TERMINALS",
+	"5172": "WHOLESALE-PETROLEUM & PETROLEUM PRODUCTS (NO BULK STATIONS)",
+	"5180": "WHOLESALE-BEER, WINE & DISTILLED ALCOHOLIC BEVERAGES",
+	"5190": "WHOLESALE-MISCELLANEOUS NONDURABLE GOODS",
+	"5200": "RETAIL-BUILDING MATERIALS, HARDWARE, GARDEN SUPPLY",
+	"5211": "RETAIL-LUMBER & OTHER BUILDING MATERIALS DEALERS",
+	"5271": "RETAIL-MOBILE HOME DEALERS",
+	"5311": "RETAIL-DEPARTMENT STORES",
+	"5331": "RETAIL-VARIETY STORES",
+	"5399": "RETAIL-MISC GENERAL MERCHANDISE STORES",
+	"5400": "RETAIL-FOOD STORES",
+	"5411": "RETAIL-GROCERY STORES",
+	"5412": "RETAIL-CONVENIENCE STORES",
+	"5500": "RETAIL-AUTO DEALERS & GASOLINE STATIONS",
+	"5531": "RETAIL-AUTO & HOME SUPPLY STORES",
+	"5600": "RETAIL-APPAREL & ACCESSORY STORES",
+	"5621": "RETAIL-WOMEN'S CLOTHING STORES",
+	"5651": "RETAIL-FAMILY CLOTHING STORES",
+	"5661": "RETAIL-SHOE STORES",
+	"5700": "RETAIL-HOME FURNITURE, FURNISHINGS & EQUIPMENT STORES",
+	"5712": "RETAIL-FURNITURE STORES",
+	"5731": "RETAIL-RADIO, TV & CONSUMER ELECTRONICS STORES",
+	"5734": "RETAIL-COMPUTER & COMPUTER SOFTWARE STORES",
+	"5735": "RETAIL-RECORD & PRERECORDED TAPE STORES",
+	"5810": "RETAIL-EATING & DRINKING PLACES",
+	"5812": "RETAIL-EATING PLACES",
+	"5900": "RETAIL-MISCELLANEOUS RETAIL",
+	"5912": "RETAIL-DRUG STORES AND PROPRIETARY STORES",
+	"5940": "RETAIL-MISCELLANEOUS SHOPPING GOODS STORES",
+	"5944": "RETAIL-JEWELRY STORES",
+	"5945": "RETAIL-HOBBY, TOY & GAME SHOPS",
+	"5960": "RETAIL-NONSTORE RETAILERS",
+	"5961": "RETAIL-CATALOG & MAIL-ORDER HOUSES",
+	"5990": "RETAIL-RETAIL STORES, NEC",
+	"6021": "NATIONAL COMMERCIAL BANKS",
+	"6022": "STATE COMMERCIAL BANKS",
+	"6029": "COMMERCIAL BANKS, NEC",
+	"6035": "SAVINGS INSTITUTION, FEDERALLY CHARTERED",
+	"6036": "SAVINGS INSTITUTIONS, NOT FEDERALLY CHARTERED",
+	"6099": "FUNCTIONS RELATED TO DEPOSITORY BANKING, NEC",
+	"6111": "FEDERAL & FEDERALLY-SPONSORED CREDIT AGENCIES",
+	"6141": "PERSONAL CREDIT INSTITUTIONS",
+	"6153": "SHORT-TERM BUSINESS CREDIT INSTITUTIONS",
+	"6159": "MISCELLANEOUS BUSINESS CREDIT INSTITUTION",
+	"6162": "MORTGAGE BANKERS & LOAN CORRESPONDENTS",
+	"6163": "LOAN BROKERS",
+	"6172": "FINANCE LESSORS",
+	"6189": "ASSET-BACKED SECURITIES",
+	"6199": "FINANCE SERVICES",
+	"6200": "SECURITY & COMMODITY BROKERS, DEALERS, EXCHANGES & SERVICES",
+	"6211": "SECURITY BROKERS, DEALERS & FLOTATION COMPANIES",
+	"6221": "COMMODITY CONTRACTS BROKERS & DEALERS",
+	"6282": "INVESTMENT ADVICE",
+	"6311": "LIFE INSURANCE",
+	"6321": "ACCIDENT & HEALTH INSURANCE",
+	"6324": "HOSPITAL & MEDICAL SERVICE PLANS",
+	"6331": "FIRE, MARINE & CASUALTY INSURANCE",
+	"6351": "SURETY INSURANCE",
+	"6361": "TITLE INSURANCE",
+	"6399": "INSURANCE CARRIERS, NEC",
+	"6411": "INSURANCE AGENTS, BROKERS & SERVICE",
+	"6500": "REAL ESTATE",
+	"6510": "REAL ESTATE OPERATORS (NO DEVELOPERS) & LESSORS",
+	"6512": "OPERATORS OF NONRESIDENTIAL BUILDINGS",
+	"6513": "OPERATORS OF APARTMENT BUILDINGS",
+	"6519": "LESSORS OF REAL PROPERTY, NEC",
+	"6531": "REAL ESTATE AGENTS & MANAGERS (FOR OTHERS)",
+	"6532": "REAL ESTATE DEALERS (FOR THEIR OWN ACCOUNT)",
+	"6552": "LAND SUBDIVIDERS & DEVELOPERS (NO CEMETERIES)",
+	"6770": "BLANK CHECKS",
+	"6792": "OIL ROYALTY TRADERS",
+	"6794": "PATENT OWNERS & LESSORS",
+	"6795": "MINERAL ROYALTY TRADERS",
+	"6798": "REAL ESTATE INVESTMENT TRUSTS",
+	"6799": "INVESTORS, NEC",
+	"7000": "HOTELS, ROOMING HOUSES, CAMPS & OTHER LODGING PLACES",
+	"7011": "HOTELS & MOTELS",
+	"7200": "SERVICES-PERSONAL SERVICES",
+	"7310": "SERVICES-ADVERTISING",
+	"7311": "SERVICES-ADVERTISING AGENCIES",
+	"7320": "SERVICES-CONSUMER CREDIT REPORTING, COLLECTION AGENCIES",
+	"7330": "SERVICES-MAILING, REPRODUCTION, COMMERCIAL ART & PHOTOGRAPHY",
+	"7331": "SERVICES-DIRECT MAIL ADVERTISING SERVICES",
+	"7340": "SERVICES-TO DWELLINGS & OTHER BUILDINGS",
+	"7350": "SERVICES-MISCELLANEOUS EQUIPMENT RENTAL & LEASING",
+	"7359": "SERVICES-EQUIPMENT RENTAL & LEASING, NEC",
+	"7361": "SERVICES-EMPLOYMENT AGENCIES",
+	"7363": "SERVICES-HELP SUPPLY SERVICES",
+	"7370": "SERVICES-COMPUTER PROGRAMMING, DATA PROCESSING, ETC.",
+	"7371": "SERVICES-COMPUTER PROGRAMMING SERVICES",
+	"7372": "SERVICES-PREPACKAGED SOFTWARE",
+	"7373": "SERVICES-COMPUTER INTEGRATED SYSTEMS DESIGN",
+	"7374": "SERVICES-COMPUTER PROCESSING & DATA PREPARATION",
+	"7377": "SERVICES-COMPUTER RENTAL & LEASING",
+	"7380": "SERVICES-MISCELLANEOUS BUSINESS SERVICES",
+	"7381": "SERVICES-DETECTIVE, GUARD & ARMORED CAR SERVICES",
+	"7384": "SERVICES-PHOTOFINISHING LABORATORIES",
+	"7385": "SERVICES-TELEPHONE INTERCONNECT SYSTEMS",
+	"7389": "SERVICES-BUSINESS SERVICES, NEC",
+	"7500": "SERVICES-AUTOMOTIVE REPAIR, SERVICES & PARKING",
+	"7510": "SERVICES-AUTO RENTAL & LEASING (NO DRIVERS)",
+	"7600": "SERVICES-MISCELLANEOUS REPAIR SERVICES",
+	"7812": "SERVICES-MOTION PICTURE & VIDEO TAPE PRODUCTION",
+	"7819": "SERVICES-ALLIED TO MOTION PICTURE PRODUCTION",
+	"7822": "SERVICES-MOTION PICTURE & VIDEO TAPE DISTRIBUTION",
+	"7829": "SERVICES-ALLIED TO MOTION PICTURE DISTRIBUTION",
+	"7830": "SERVICES-MOTION PICTURE THEATERS",
+	"7841": "SERVICES-VIDEO TAPE RENTAL",
+	"7900": "SERVICES-AMUSEMENT & RECREATION SERVICES",
+	"7948": "SERVICES-RACING, INCLUDING TRACK OPERATION",
+	"7990": "SERVICES-MISCELLANEOUS AMUSEMENT & RECREATION",
+	"7997": "SERVICES-MEMBERSHIP SPORTS & RECREATION CLUBS",
+	"8000": "SERVICES-HEALTH SERVICES",
+	"8011": "SERVICES-OFFICES & CLINICS OF DOCTORS OF MEDICINE",
+	"8050": "SERVICES-NURSING & PERSONAL CARE FACILITIES",
+	"8051": "SERVICES-SKILLED NURSING CARE FACILITIES",
+	"8060": "SERVICES-HOSPITALS",
+	"8062": "SERVICES-GENERAL MEDICAL & SURGICAL HOSPITALS, NEC",
+	"8071": "SERVICES-MEDICAL LABORATORIES",
+	"8082": "SERVICES-HOME HEALTH CARE SERVICES",
+	"8090": "SERVICES-MISC HEALTH & ALLIED SERVICES, NEC",
+	"8093": "SERVICES-SPECIALTY OUTPATIENT FACILITIES, NEC",
+	"8111": "SERVICES-LEGAL SERVICES",
+	"8200": "SERVICES-EDUCATIONAL SERVICES",
+	"8300": "SERVICES-SOCIAL SERVICES",
+	"8351": "SERVICES-CHILD DAY CARE SERVICES",
+	"8600": "SERVICES-MEMBERSHIP ORGANIZATIONS",
+	"8700": "SERVICES-ENGINEERING, ACCOUNTING, RESEARCH, MANAGEMENT",
+	"8711": "SERVICES-ENGINEERING SERVICES",
+	"8731": "SERVICES-COMMERCIAL PHYSICAL & BIOLOGICAL RESEARCH",
+	"8734": "SERVICES-TESTING LABORATORIES",
+	"8741": "SERVICES-MANAGEMENT SERVICES",
+	"8742": "SERVICES-MANAGEMENT CONSULTING SERVICES",
+	"8744": "SERVICES-FACILITIES SUPPORT MANAGEMENT SERVICES",
+	"8880": "AMERICAN DEPOSITARY RECEIPTS",
+	"8888": "FOREIGN GOVERNMENTS",
+	"8900": "SERVICES-SERVICES, NEC",
+	"9721": "INTERNATIONAL AFFAIRS",
+	"9995": "NON-OPERATING ESTABLISHMENTS",
+}
+
+// MajorGroups maps the two-digit SIC major groups to their title
+var MajorGroups = map[string]string{
+	"01": "Agricultural Production - Crops",
+	"02": "Agricultural Production - Livestock and Animal Specialties",
+	"07": "Agricultural Services",
+	"08": "Forestry",
+	"09": "Fishing, Hunting and Trapping",
+	"10": "Metal Mining",
+	"12": "Coal Mining",
+	"13": "Oil and Gas Extraction",
+	"14": "Mining and Quarrying of Nonmetallic Minerals, Except Fuels",
+	"15": "Building Construction - General Contractors and Operative Builders",
+	"16": "Heavy Construction Other Than Building Construction - Contractors",
+	"17": "Construction - Special Trade Contractors",
+	"20": "Food and Kindred Products",
+	"21": "Tobacco Products",
+	"22": "Textile Mill Products",
+	"23": "Apparel and Other Finished Products Made From Fabrics and Similar Materials",
+	"24": "Lumber and Wood Products, Except Furniture",
+	"25": "Furniture and Fixtures",
+	"26": "Paper and Allied Products",
+	"27": "Printing, Publishing, and Allied Industries",
+	"28": "Chemicals and Allied Products",
+	"29": "Petroleum Refining and Related Industries",
+	"30": "Rubber and Miscellaneous Plastics Products",
+	"31": "Leather and Leather Products",
+	"32": "Stone, Clay, Glass, and Concrete Products",
+	"33": "Primary Metal Industries",
+	"34": "Fabricated Metal Products, Except Machinery and Transportation Equipment",
+	"35": "Industrial and Commercial Machinery and Computer Equipment",
+	"36": "Electronic and Other Electrical Equipment and Components, Except Computer Equipment",
+	"37": "Transportation Equipment",
+	"38": "Measuring, Analyzing, and Controlling Instruments; Photographic, Medical and Optical Goods; Watches and Clocks",
+	"39": "Miscellaneous Manufacturing Industries",
+	"40": "Railroad Transportation",
+	"41": "Local and Suburban Transit and Interurban Highway Passenger Transportation",
+	"42": "Motor Freight Transportation and Warehousing",
+	"43": "United States Postal Service",
+	"44": "Water Transportation",
+	"45": "Transportation by Air",
+	"46": "Pipelines, Except Natural Gas",
+	"47": "Transportation Services",
+	"48": "Communications",
+	"49": "Electric, Gas, and Sanitary Services",
+	"50": "Wholesale Trade - Durable Goods",
+	"51": "Wholesale Trade - Nondurable Goods",
+	"52": "Building Materials, Hardware, Garden Supply, and Mobile Home Dealers",
+	"53": "General Merchandise Stores",
+	"54": "Food Stores",
+	"55": "Automotive Dealers and Gasoline Service Stations",
+	"56": "Apparel and Accessory Stores",
+	"57": "Home Furniture, Furnishings, and Equipment Stores",
+	"58": "Eating and Drinking Places",
+	"59": "Miscellaneous Retail",
+	"60": "Depository Institutions",
+	"61": "Nondepository Credit Institutions",
+	"62": "Security and Commodity Brokers, Dealers, Exchanges, and Services",
+	"63": "Insurance Carriers",
+	"64": "Insurance Agents, Brokers, and Service",
+	"65": "Real Estate",
+	"67": "Holding and Other Investment Offices",
+	"70": "Hotels, Rooming Houses, Camps, and Other Lodging Places",
+	"72": "Personal Services",
+	"73": "Business Services",
+	"75": "Automotive Repair, Services, and Parking",
+	"76": "Miscellaneous Repair Services",
+	"78": "Motion Pictures",
+	"79": "Amusement and Recreation Services",
+	"80": "Health Services",
+	"81": "Legal Services",
+	"82": "Educational Services",
+	"83": "Social Services",
+	"84": "Museums, Art Galleries, and Botanical and Zoological Gardens",
+	"86": "Membership Organizations",
+	"87": "Engineering, Accounting, Research, Management, and Related Services",
+	"88": "Private Households",
+	"89": "Services, Not Elsewhere Classified",
+	"91": "Executive, Legislative, and General Government, Except Finance",
+	"92": "Justice, Public Order, and Safety",
+	"93": "Public Finance, Taxation, and Monetary Policy",
+	"94": "Administration of Human Resource Programs",
+	"95": "Administration of Environmental Quality and Housing Programs",
+	"96": "Administration of Economic Programs",
+	"97": "National Security and International Affairs",
+	"99": "Nonclassifiable Establishments",
+}
@@ -0,0 +1,18 @@
+package sic
+
+// MajorGroup returns the two-digit major group of a SIC code, e.g. 28 for 2834
+func MajorGroup(code string) string {
+	if len(code) < 2 {
+		return code
+	}
+	return code[:2]
+}
+
+// Description returns the industry title of a SIC code, falling back to the
+// title of its major group. It's empty for unknown codes.
+func Description(code string) string {
+	if title, ok := Codes[code]; ok {
+		return title
+	}
+	return MajorGroups[MajorGroup(code)]
+}
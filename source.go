@@ -0,0 +1,202 @@
+package filingsdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ArchiveSource is where data set archives are listed and fetched from:
+// sec.gov, a manifest, a local directory or an S3 bucket
+type ArchiveSource interface {
+	// ListArchives lists the archives of the source
+	ListArchives(ctx context.Context) ([]Archive, error)
+
+	/**
+	Fetch makes an archive listed by the source available as a local
+	file, teeing the downloaded content into progress if not nil. It
+	returns the path of the file and a release func to call once done
+	with it, removing temporary copies.
+	*/
+	Fetch(ctx context.Context, archive Archive, progress io.Writer) (string, func(), error)
+}
+
+// IngestFrom fetches archive from src and loads it into the database
+func (db *DB) IngestFrom(ctx context.Context, src ArchiveSource, archive Archive) error {
+	db.logger.Printf("Fetching %s", archive.Name)
+	file, release, err := src.Fetch(ctx, archive, db.progress)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return db.ExtractFromZip(ctx, file)
+}
+
+// SECSource lists the archives published on sec.gov and downloads them
+// through a cache if not nil
+type SECSource struct {
+	Client *Client
+	Cache  *Cache
+
+	// models.DatasetNotes (the default if empty) or models.DatasetStatements
+	Dataset string
+}
+
+// ListArchives lists the archives linked from the data sets page of sec.gov
+func (s *SECSource) ListArchives(ctx context.Context) ([]Archive, error) {
+	if s.Dataset == "" {
+		return s.client().ListArchives(ctx)
+	}
+	return s.client().ListDatasetArchives(ctx, s.Dataset)
+}
+
+// Fetch downloads the archive, to the cache if any or to a temporary file
+func (s *SECSource) Fetch(ctx context.Context, archive Archive, progress io.Writer) (string, func(), error) {
+	return fetchURL(ctx, s.client(), s.Cache, archive.URL, progress)
+}
+
+func (s *SECSource) client() *Client {
+	if s.Client == nil {
+		return DefaultClient
+	}
+	return s.Client
+}
+
+// fetchURL downloads url with client, through cache if not nil. Without a
+// cache, the archive is downloaded to a temporary file named after url, so
+// that a download interrupted by the user resumes on the next run.
+func fetchURL(ctx context.Context, client *Client, cache *Cache, url string, progress io.Writer) (string, func(), error) {
+	if cache != nil {
+		file, err := cache.Fetch(ctx, url, progress)
+		return file, func() {}, err
+	}
+	name := filepath.Join(os.TempDir(), "filingsdb-"+hash([]byte(url))+".zip")
+	if err := client.Download(ctx, url, name, progress); err != nil {
+		if ctx.Err() == nil {
+			os.Remove(name + ".tmp")
+		}
+		return "", nil, err
+	}
+	return name, func() { os.Remove(name) }, nil
+}
+
+// ManifestSource lists the archives of a JSON manifest, a local file or a
+// URL, e.g. a mirror of the data sets:
+//
+//	[
+//	  {"name": "2019q1_notes.zip", "url": "https://mirror.example.com/2019q1_notes.zip"},
+//	  {"name": "2019q2_notes.zip", "url": "2019q2_notes.zip"}
+//	]
+//
+// Relative URLs are resolved against the location of the manifest. Archives
+// are downloaded with Client (DefaultClient if nil) through Cache if not nil.
+type ManifestSource struct {
+	Location string
+	Client   *Client
+	Cache    *Cache
+}
+
+// ListArchives reads the manifest
+func (s *ManifestSource) ListArchives(ctx context.Context) ([]Archive, error) {
+	var r io.ReadCloser
+	if isURL(s.Location) {
+		resp, err := s.client().Get(ctx, s.Location, nil)
+		if err != nil {
+			return nil, err
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(s.Location)
+		if err != nil {
+			return nil, err
+		}
+		r = f
+	}
+	defer r.Close()
+
+	archives := []Archive{}
+	if err := json.NewDecoder(r).Decode(&archives); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.Location, err)
+	}
+	for i, a := range archives {
+		var err error
+		if archives[i].URL, err = s.resolve(a.URL); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Location, err)
+		}
+		if a.Name == "" {
+			archives[i].Name = filepath.Base(archives[i].URL)
+		}
+	}
+	return archives, nil
+}
+
+// resolve resolves a URL of the manifest against its location
+func (s *ManifestSource) resolve(ref string) (string, error) {
+	if isURL(ref) || filepath.IsAbs(ref) {
+		return ref, nil
+	}
+	if !isURL(s.Location) {
+		return filepath.Join(filepath.Dir(s.Location), ref), nil
+	}
+	base, err := url.Parse(s.Location)
+	if err != nil {
+		return "", err
+	}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// Fetch downloads the archive, or uses it as is if it's a local file
+func (s *ManifestSource) Fetch(ctx context.Context, archive Archive, progress io.Writer) (string, func(), error) {
+	if !isURL(archive.URL) {
+		return archive.URL, func() {}, nil
+	}
+	return fetchURL(ctx, s.client(), s.Cache, archive.URL, progress)
+}
+
+func (s *ManifestSource) client() *Client {
+	if s.Client == nil {
+		return DefaultClient
+	}
+	return s.Client
+}
+
+// DirSource lists the archives of a local directory, e.g. downloaded by hand
+type DirSource struct {
+	Dir string
+}
+
+// ListArchives lists the zip files of the directory, sorted by name
+func (s *DirSource) ListArchives(ctx context.Context) ([]Archive, error) {
+	files, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+	archives := []Archive{}
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), ".zip") {
+			archives = append(archives, Archive{Name: f.Name(), URL: filepath.Join(s.Dir, f.Name())})
+		}
+	}
+	sort.Slice(archives, func(i, j int) bool { return archives[i].Name < archives[j].Name })
+	return archives, nil
+}
+
+// Fetch returns the path of the archive, the file being used in place
+func (s *DirSource) Fetch(ctx context.Context, archive Archive, progress io.Writer) (string, func(), error) {
+	return archive.URL, func() {}, nil
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
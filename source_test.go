@@ -0,0 +1,185 @@
+package filingsdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"eswiac.me/filingsdb"
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/sectest"
+)
+
+// openTemp opens a new database in a temporary directory, returned to be
+// removed once done
+func openTemp(t *testing.T, opts ...filingsdb.Option) (*filingsdb.DB, string) {
+	dir, err := ioutil.TempDir("", "filingsdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := filingsdb.Open(filepath.Join(dir, "filings.db"), opts...)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return db, dir
+}
+
+// ingestAll ingests the archives listed by src
+func ingestAll(t *testing.T, db *filingsdb.DB, src filingsdb.ArchiveSource) {
+	ctx := context.Background()
+	archives, err := src.ListArchives(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archives) != 1 {
+		t.Fatalf("listed %+v, expected a single archive", archives)
+	}
+	if err := db.IngestFrom(ctx, src, archives[0]); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fixtureRows returns the count of rows of a fixture file, its header aside
+func fixtureRows(files map[string]string, name string) int64 {
+	return int64(strings.Count(files[name], "\n") - 1)
+}
+
+// checkCounts checks the rows ingested against the fixture files
+func checkCounts(t *testing.T, db *filingsdb.DB, files map[string]string) {
+	tables := map[string]interface{}{
+		"sub.tsv": &models.DataSUB{},
+		"tag.tsv": &models.DataTAG{},
+		"dim.tsv": &models.DataDIM{},
+		"num.tsv": &models.DataNUM{},
+		"pre.tsv": &models.DataPRE{},
+		"cal.tsv": &models.DataCAL{},
+	}
+	for name, model := range tables {
+		var count int64
+		if err := db.Gorm().Model(model).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		if expected := fixtureRows(files, name); files[name] != "" && count != expected {
+			t.Errorf("%s: %d rows, expected %d", name, count, expected)
+		}
+	}
+}
+
+func TestSECSource(t *testing.T) {
+	srv := sectest.NewServer(map[string][]byte{"2019q1_notes.zip": sectest.SampleArchive()})
+	defer srv.Close()
+	db, dir := openTemp(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	srv.Fail(503, 429)
+	ingestAll(t, db, &filingsdb.SECSource{Client: srv.Client()})
+	checkCounts(t, db, sectest.SampleFiles)
+
+	// the archive is only downloaded once the failures are retried
+	downloads := 0
+	for _, r := range srv.Requests() {
+		if strings.HasSuffix(r.Path, ".zip") {
+			downloads++
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("%d requests of the archive, expected 1", downloads)
+	}
+}
+
+func TestSECSourceStatements(t *testing.T) {
+	srv := sectest.NewServer(map[string][]byte{"2019q1.zip": sectest.SampleStatementsArchive()})
+	defer srv.Close()
+	db, dir := openTemp(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	ingestAll(t, db, &filingsdb.SECSource{Client: srv.Client(), Dataset: models.DatasetStatements})
+	checkCounts(t, db, sectest.SampleStatementsFiles())
+
+	var datasets []string
+	if err := db.Gorm().Model(&models.DataSUB{}).Distinct().Pluck("dataset", &datasets).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(datasets) != 1 || datasets[0] != models.DatasetStatements {
+		t.Errorf("submissions of the data sets %q", datasets)
+	}
+}
+
+func TestManifestSource(t *testing.T) {
+	srv := sectest.NewServer(map[string][]byte{"2019q1_notes.zip": sectest.SampleArchive()})
+	defer srv.Close()
+	db, dir := openTemp(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	manifest := filepath.Join(dir, "manifest.json")
+	b, err := json.Marshal([]filingsdb.Archive{{URL: srv.ArchiveURL("2019q1_notes.zip")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(manifest, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	srv.Fail(503)
+	ingestAll(t, db, &filingsdb.ManifestSource{Location: manifest, Client: srv.Client()})
+	checkCounts(t, db, sectest.SampleFiles)
+}
+
+func TestDirSource(t *testing.T) {
+	db, dir := openTemp(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	archives := filepath.Join(dir, "archives")
+	if err := os.Mkdir(archives, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(archives, "2019q1_notes.zip"), sectest.SampleArchive(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(archives, "README.txt"), []byte("not an archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ingestAll(t, db, &filingsdb.DirSource{Dir: archives})
+	checkCounts(t, db, sectest.SampleFiles)
+}
+
+func TestSampleDimHash(t *testing.T) {
+	db, dir := openTemp(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	file := filepath.Join(dir, "2019q1_notes.zip")
+	if err := ioutil.WriteFile(file, sectest.SampleArchive(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ExtractFromZip(context.Background(), file); err != nil {
+		t.Fatal(err)
+	}
+	dims := []models.DataDIM{}
+	if err := db.Gorm().Find(&dims).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, dim := range dims {
+		if dim.Dimh != models.DimHash(dim.Segments) {
+			t.Errorf("dimh %s of %q, expected %s", dim.Dimh, dim.Segments, models.DimHash(dim.Segments))
+		}
+	}
+	var segmented int64
+	err := db.Gorm().Model(&models.DataNUM{}).
+		Joins("JOIN data_dims ON data_dims.dimh = data_nums.dimh").
+		Where("data_dims.segments <> ''").Count(&segmented).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if segmented != 1 {
+		t.Errorf("%d facts with segments, expected 1", segmented)
+	}
+}
@@ -0,0 +1,162 @@
+package filingsdb
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"gorm.io/gorm"
+)
+
+// IngestSubmissions loads submissions.zip, the bulk download of the EDGAR
+// submissions of every company, or a single CIK##########.json of it: the
+// current metadata of the companies to companies, their tickers and
+// exchanges to company_tickers, their former names to company_former_names
+// and the index of all their filings, XBRL or not, to company_filings.
+// Loading a company again replaces what was loaded before. The CIKs of the
+// filter of the DB apply.
+func (db *DB) IngestSubmissions(ctx context.Context, file string) error {
+	if strings.HasSuffix(file, ".json") {
+		s := models.Submissions{}
+		if err := decodeJSONFile(file, &s); err != nil {
+			return err
+		}
+		// the files of the older filings are looked for next to it
+		older := []models.SubmissionsFilings{}
+		for _, listed := range s.Filings.Files {
+			filings := models.SubmissionsFilings{}
+			err := decodeJSONFile(filepath.Join(filepath.Dir(file), listed.Name), &filings)
+			if os.IsNotExist(err) {
+				db.logger.Printf("No %s next to %s, skipped", listed.Name, filepath.Base(file))
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			older = append(older, filings)
+		}
+		return db.gorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return loadSubmissions(tx, s, older)
+		})
+	}
+
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return fmt.Errorf("%v: %w", file, err)
+	}
+	defer zr.Close()
+	db.logger.Printf("Contents of %s:", path.Base(file))
+
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	for _, f := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// CIK0000320193.json, the older filings being listed in
+		// CIK0000320193-submissions-001.json...
+		if !strings.HasPrefix(f.Name, "CIK") || !strings.HasSuffix(f.Name, ".json") || strings.Contains(f.Name, "-") {
+			continue
+		}
+		cik := strings.TrimLeft(strings.TrimSuffix(strings.TrimPrefix(f.Name, "CIK"), ".json"), "0")
+		if db.filter != nil && !in(db.filter.ciks, cik) {
+			continue
+		}
+		s := models.Submissions{}
+		if err := decodeJSONZip(f, &s); err != nil {
+			return err
+		}
+		older := []models.SubmissionsFilings{}
+		for _, listed := range s.Filings.Files {
+			olderFile, ok := files[listed.Name]
+			if !ok {
+				db.logger.Printf("No %s in %s, skipped", listed.Name, path.Base(file))
+				continue
+			}
+			filings := models.SubmissionsFilings{}
+			if err := decodeJSONZip(olderFile, &filings); err != nil {
+				return err
+			}
+			older = append(older, filings)
+		}
+		err := db.gorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return loadSubmissions(tx, s, older)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// loadSubmissions replaces the rows of the company of s, with the older
+// filings of the other files of the company
+func loadSubmissions(tx *gorm.DB, s models.Submissions, older []models.SubmissionsFilings) error {
+	company := s.Company()
+	for _, model := range []interface{}{&models.Company{}, &models.CompanyTicker{}, &models.CompanyFormerName{}, &models.CompanyFiling{}} {
+		if err := tx.Where("cik = ?", company.Cik).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Create(&company).Error; err != nil {
+		return err
+	}
+	tickers, names, filings := &batch{tx: tx}, &batch{tx: tx}, &batch{tx: tx}
+	for _, t := range s.CompanyTickers() {
+		if err := tickers.add(t); err != nil {
+			return err
+		}
+	}
+	for _, n := range s.CompanyFormerNames() {
+		if err := names.add(n); err != nil {
+			return err
+		}
+	}
+	for _, index := range append([]models.SubmissionsFilings{s.Filings.Recent}, older...) {
+		for _, f := range index.CompanyFilings(company.Cik) {
+			if err := filings.add(f); err != nil {
+				return err
+			}
+		}
+	}
+	for _, b := range []*batch{tickers, names, filings} {
+		if err := b.flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeJSONFile(file string, v interface{}) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return decodeJSON(filepath.Base(file), f, v)
+}
+
+func decodeJSONZip(f *zip.File, v interface{}) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return decodeJSON(f.Name, r, v)
+}
+
+func decodeJSON(name string, r io.Reader, v interface{}) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
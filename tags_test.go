@@ -0,0 +1,80 @@
+package filingsdb_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eswiac.me/filingsdb/query"
+	"eswiac.me/filingsdb/sectest"
+)
+
+// revenuesFacts is a CIK##########.json of companyfacts.zip, of a filing
+// missing from the sample archive
+const revenuesFacts = `{"cik": 320193, "entityName": "Apple Inc.", "facts": {"us-gaap": {"Revenues": {"label": "Revenues", "units": {"USD": [
+  {"start": "2017-10-01", "end": "2018-09-29", "val": 265595000000, "accn": "0000320193-18-000145", "fy": 2018, "fp": "FY", "form": "10-K", "filed": "2018-11-05"}
+]}}}}}`
+
+func TestTags(t *testing.T) {
+	db, dir := openTemp(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+	ctx := context.Background()
+	archive, facts := filepath.Join(dir, "2019q1_notes.zip"), filepath.Join(dir, "CIK0000320193.json")
+	if err := ioutil.WriteFile(archive, sectest.SampleArchive(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(facts, []byte(revenuesFacts), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.ExtractFromZip(ctx, archive); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.IngestCompanyFacts(ctx, facts); err != nil {
+		t.Fatal(err)
+	}
+
+	tags := func(q query.TagQuery) ([]string, int) {
+		result, err := db.Tags(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := []string{}
+		for _, tag := range result.Tags {
+			names = append(names, tag.Tag+" "+tag.Version)
+		}
+		return names, result.Total
+	}
+	check := func(q query.TagQuery, expected []string, total int) {
+		names, n := tags(q)
+		if len(names) != len(expected) || n != total {
+			t.Errorf("%+v: %q of %d, expected %q of %d", q, names, n, expected, total)
+			return
+		}
+		for i := range names {
+			if names[i] != expected[i] {
+				t.Errorf("%+v: %q of %d, expected %q of %d", q, names, n, expected, total)
+				return
+			}
+		}
+	}
+
+	// the tags of companyfacts.zip are versioned by taxonomy only
+	check(query.TagQuery{Text: "revenues", Version: "us-gaap"}, []string{"Revenues us-gaap", "Revenues us-gaap/2018"}, 2)
+	check(query.TagQuery{Text: "revenues", Version: "us-gaap/2018"}, []string{"Revenues us-gaap/2018"}, 1)
+	check(query.TagQuery{Version: "dei"}, []string{"DocumentType dei/2018"}, 1)
+
+	// pages by name
+	all, total := tags(query.TagQuery{})
+	if total != len(all) || total != 17 {
+		t.Fatalf("%d tags of %d, expected 17", len(all), total)
+	}
+	check(query.TagQuery{Limit: 3, Offset: 2}, all[2:5], total)
+	check(query.TagQuery{Offset: 15}, all[15:], total)
+	check(query.TagQuery{Offset: 20}, []string{}, total)
+
+	// by usage, Revenues of the data sets being reported by 5 companies
+	check(query.TagQuery{Text: "revenue", Sort: "usage", Limit: 1}, []string{"Revenues us-gaap/2018"}, 4)
+}
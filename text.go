@@ -0,0 +1,59 @@
+package filingsdb
+
+import (
+	"fmt"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+)
+
+// TextPolicy decides how the text facts of txt.tsv are stored, most of the
+// size of a database being their values
+type TextPolicy string
+
+const (
+	// TextKeep stores the text facts as is
+	TextKeep TextPolicy = "keep"
+	// TextSkip doesn't store text facts
+	TextSkip TextPolicy = "skip"
+	// TextNoBlocks skips the text blocks (tags ending with TextBlock),
+	// keeping short text facts such as the document type
+	TextNoBlocks TextPolicy = "no-blocks"
+	// TextCompress stores the values zstd compressed in data_txt_values,
+	// query.TextValues reading them back
+	TextCompress TextPolicy = "compress"
+)
+
+// ParseTextPolicy parses the name of a text policy
+func ParseTextPolicy(name string) (TextPolicy, error) {
+	switch p := TextPolicy(name); p {
+	case TextKeep, TextSkip, TextNoBlocks, TextCompress:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown text policy `%s`, expected keep, skip, no-blocks or compress", name)
+}
+
+// WithTextPolicy sets how text facts are stored, TextKeep by default
+func WithTextPolicy(p TextPolicy) Option {
+	return func(db *DB) {
+		db.textPolicy = p
+	}
+}
+
+// text applies the text policy to txt, returning the fact to store, its
+// compressed value if any and whether to store it at all
+func (db *DB) text(txt models.DataTXT) (models.DataTXT, *models.DataTXTValue, bool) {
+	switch db.textPolicy {
+	case TextSkip:
+		return txt, nil, false
+	case TextNoBlocks:
+		return txt, nil, !strings.HasSuffix(txt.Tag, "TextBlock")
+	case TextCompress:
+		if txt.Value == nil {
+			return txt, nil, true
+		}
+		txt, value := models.CompressValue(txt)
+		return txt, &value, true
+	}
+	return txt, nil, true
+}
@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd
+
+package tui
+
+import (
+	"errors"
+	"os"
+)
+
+var errUnsupported = errors.New("the terminal UI needs a Unix terminal")
+
+func makeRaw(f *os.File) (func() error, error) {
+	return nil, errUnsupported
+}
+
+func size(f *os.File) (int, int, error) {
+	return 0, 0, errUnsupported
+}
+
+func notifyResize(c chan<- os.Signal) {}
@@ -0,0 +1,438 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// Run browses the filings database db in the terminal of in and out until
+// the user quits: a company search, the filings of a company, the reports of
+// a filing, their facts and text blocks. It only relies on the escape
+// sequences of a VT100, so it works over SSH.
+func Run(db *gorm.DB, in *os.File, out *os.File) error {
+	restore, err := makeRaw(in)
+	if err != nil {
+		return fmt.Errorf("the terminal UI needs a terminal: %w", err)
+	}
+	defer restore()
+
+	w := bufio.NewWriter(out)
+	// alternate screen, hidden cursor
+	fmt.Fprint(w, "\x1b[?1049h\x1b[?25l")
+	w.Flush()
+	defer func() {
+		fmt.Fprint(w, "\x1b[0m\x1b[?25h\x1b[?1049l")
+		w.Flush()
+	}()
+
+	a := &app{}
+	search, err := newSearchView(db)
+	if err != nil {
+		return err
+	}
+	a.push(search)
+
+	keys := make(chan []key)
+	errs := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				errs <- err
+				return
+			}
+			keys <- parseKeys(buf[:n])
+		}
+	}()
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+
+	for len(a.views) > 0 {
+		width, height, err := size(out)
+		if err != nil {
+			return err
+		}
+		a.draw(w, width, height)
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		select {
+		case <-resize:
+		case err := <-errs:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case ks := <-keys:
+			for _, k := range ks {
+				if k.code == keyCtrlC {
+					return nil
+				}
+				a.status = ""
+				if len(a.views) == 0 {
+					break
+				}
+				if err := a.top().key(a, k); err != nil {
+					a.status = err.Error()
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// app is the stack of the views, the top one being displayed
+type app struct {
+	views  []view
+	status string
+}
+
+// view is a screen of the terminal UI
+type view interface {
+	// title is the first line of the screen
+	title() string
+
+	// help is the last line of the screen, the keys of the view
+	help() string
+
+	// draw adds the lines of the view to s
+	draw(s *screen)
+
+	// key handles k
+	key(a *app, k key) error
+}
+
+func (a *app) push(v view) {
+	a.views = append(a.views, v)
+}
+
+// pop goes back to the previous view, quitting on the first one
+func (a *app) pop() {
+	a.views = a.views[:len(a.views)-1]
+}
+
+func (a *app) top() view {
+	return a.views[len(a.views)-1]
+}
+
+func (a *app) draw(w io.Writer, width int, height int) {
+	if width < 20 || height < 5 {
+		fmt.Fprint(w, "\x1b[H\x1b[2J")
+		return
+	}
+	v := a.top()
+	s := &screen{width: width, height: height - 2}
+	v.draw(s)
+	status := v.help()
+	if a.status != "" {
+		status = a.status
+	}
+
+	fmt.Fprint(w, "\x1b[H")
+	writeLine(w, v.title(), styleTitle, width)
+	for i := 0; i < s.height; i++ {
+		if i < len(s.lines) {
+			writeLine(w, s.lines[i].text, s.lines[i].style, width)
+		} else {
+			writeLine(w, "", styleNormal, width)
+		}
+	}
+	fmt.Fprintf(w, "\x1b[%d;1H", height)
+	text := []rune(status)
+	if len(text) >= width {
+		text = text[:width-1]
+	}
+	fmt.Fprint(w, styleStatus, string(text), "\x1b[K\x1b[0m")
+}
+
+// writeLine writes text cut to width, followed by a new line
+func writeLine(w io.Writer, text string, style string, width int) {
+	fmt.Fprint(w, style, cut(text, width), "\x1b[K\x1b[0m\r\n")
+}
+
+// Styles of the lines, as SGR escape sequences
+const (
+	styleNormal   = ""
+	styleTitle    = "\x1b[1;7m"
+	styleStatus   = "\x1b[7m"
+	styleSelected = "\x1b[7m"
+	styleHeader   = "\x1b[1m"
+	styleDim      = "\x1b[2m"
+)
+
+// screen is the lines of a view, between its title and help lines
+type screen struct {
+	width  int
+	height int
+	lines  []screenLine
+}
+
+type screenLine struct {
+	text  string
+	style string
+}
+
+func (s *screen) add(text string, style string) {
+	s.lines = append(s.lines, screenLine{text, style})
+}
+
+// cut returns text cut to width runes, its tabs and control characters
+// replaced by spaces
+func cut(text string, width int) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range text {
+		if n >= width {
+			break
+		}
+		if r < ' ' || r == 0x7f {
+			r = ' '
+		}
+		b.WriteRune(r)
+		n++
+	}
+	return b.String()
+}
+
+// pad returns text cut or padded with spaces to width runes, aligned right
+// if right
+func pad(text string, width int, right bool) string {
+	n := utf8.RuneCountInString(text)
+	if n > width {
+		return string([]rune(text)[:width])
+	}
+	if right {
+		return strings.Repeat(" ", width-n) + text
+	}
+	return text + strings.Repeat(" ", width-n)
+}
+
+// wrap returns the lines of text wrapped at width, its lines kept
+func wrap(text string, width int) []string {
+	lines := []string{}
+	for _, paragraph := range strings.Split(strings.Replace(text, "\r", "", -1), "\n") {
+		line, n := "", 0
+		for _, word := range strings.Fields(paragraph) {
+			l := utf8.RuneCountInString(word)
+			for l > width {
+				if n > 0 {
+					lines = append(lines, line)
+					line, n = "", 0
+				}
+				lines = append(lines, string([]rune(word)[:width]))
+				word = string([]rune(word)[width:])
+				l -= width
+			}
+			if n > 0 && n+1+l > width {
+				lines = append(lines, line)
+				line, n = "", 0
+			}
+			if n > 0 {
+				line += " "
+				n++
+			}
+			line += word
+			n += l
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// list is the selection of an item of a list of n items, scrolled to
+// keep it visible
+type list struct {
+	n      int
+	cursor int
+	offset int
+}
+
+// move handles the keys moving the cursor of a list of height visible
+// items, telling whether k was one of them
+func (l *list) move(k key, height int) bool {
+	switch k.code {
+	case keyUp:
+		l.cursor--
+	case keyDown:
+		l.cursor++
+	case keyPgUp:
+		l.cursor -= height
+	case keyPgDn:
+		l.cursor += height
+	case keyHome:
+		l.cursor = 0
+	case keyEnd:
+		l.cursor = l.n - 1
+	default:
+		return false
+	}
+	l.clamp(height)
+	return true
+}
+
+// clamp keeps the cursor within the items, and visible
+func (l *list) clamp(height int) {
+	if l.cursor >= l.n {
+		l.cursor = l.n - 1
+	}
+	if l.cursor < 0 {
+		l.cursor = 0
+	}
+	if l.cursor < l.offset {
+		l.offset = l.cursor
+	}
+	if height > 0 && l.cursor >= l.offset+height {
+		l.offset = l.cursor - height + 1
+	}
+}
+
+// visible returns the range of the items shown in height lines
+func (l *list) visible(height int) (int, int) {
+	l.clamp(height)
+	end := l.offset + height
+	if end > l.n {
+		end = l.n
+	}
+	return l.offset, end
+}
+
+// pager scrolls lines of text
+type pager struct {
+	offset int
+}
+
+// scroll handles the keys scrolling lines shown height at a time, telling
+// whether k was one of them
+func (p *pager) scroll(k key, height int) bool {
+	switch {
+	case k.code == keyUp || k.r == 'k':
+		p.offset--
+	case k.code == keyDown || k.r == 'j':
+		p.offset++
+	case k.code == keyPgUp || k.r == 'b':
+		p.offset -= height
+	case k.code == keyPgDn || k.r == ' ':
+		p.offset += height
+	case k.code == keyHome || k.r == 'g':
+		p.offset = 0
+	case k.code == keyEnd || k.r == 'G':
+		p.offset = math.MaxInt32 // show stops at the last line
+	default:
+		return false
+	}
+	return true
+}
+
+// show adds the lines scrolled to s
+func (p *pager) show(s *screen, lines []screenLine) {
+	if p.offset > len(lines)-s.height {
+		p.offset = len(lines) - s.height
+	}
+	if p.offset < 0 {
+		p.offset = 0
+	}
+	end := p.offset + s.height
+	if end > len(lines) {
+		end = len(lines)
+	}
+	s.lines = append(s.lines, lines[p.offset:end]...)
+}
+
+// key is a key typed: a character, or a special key
+type key struct {
+	r    rune
+	code int
+}
+
+// The special keys
+const (
+	keyRune = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPgUp
+	keyPgDn
+	keyHome
+	keyEnd
+	keyEnter
+	keyBackspace
+	keyTab
+	keyEsc
+	keyCtrlC
+)
+
+// parseKeys returns the keys of the bytes read from a terminal in raw mode,
+// a lone ESC being the escape key
+func parseKeys(b []byte) []key {
+	keys := []key{}
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b && len(b) > 2 && (b[1] == '[' || b[1] == 'O'):
+			i := 2
+			for i < len(b) && (b[i] >= '0' && b[i] <= '9' || b[i] == ';') {
+				i++
+			}
+			if i == len(b) {
+				return keys
+			}
+			params := string(b[2:i])
+			switch b[i] {
+			case 'A':
+				keys = append(keys, key{code: keyUp})
+			case 'B':
+				keys = append(keys, key{code: keyDown})
+			case 'C':
+				keys = append(keys, key{code: keyRight})
+			case 'D':
+				keys = append(keys, key{code: keyLeft})
+			case 'H':
+				keys = append(keys, key{code: keyHome})
+			case 'F':
+				keys = append(keys, key{code: keyEnd})
+			case '~':
+				switch params {
+				case "1", "7":
+					keys = append(keys, key{code: keyHome})
+				case "4", "8":
+					keys = append(keys, key{code: keyEnd})
+				case "5":
+					keys = append(keys, key{code: keyPgUp})
+				case "6":
+					keys = append(keys, key{code: keyPgDn})
+				}
+			}
+			b = b[i+1:]
+		case c == 0x1b:
+			keys = append(keys, key{code: keyEsc})
+			b = b[1:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, key{code: keyEnter})
+			b = b[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, key{code: keyBackspace})
+			b = b[1:]
+		case c == '\t':
+			keys = append(keys, key{code: keyTab})
+			b = b[1:]
+		case c == 0x03:
+			keys = append(keys, key{code: keyCtrlC})
+			b = b[1:]
+		case c < ' ':
+			b = b[1:]
+		default:
+			r, n := utf8.DecodeRune(b)
+			keys = append(keys, key{r: r})
+			b = b[n:]
+		}
+	}
+	return keys
+}
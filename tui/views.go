@@ -0,0 +1,611 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// searchLimit is the count of companies listed by the search
+const searchLimit = 200
+
+// searchView finds a company by name, ticker or CIK as it is typed
+type searchView struct {
+	db      *gorm.DB
+	input   string
+	matches []query.CompanyMatch
+	list    list
+	height  int
+}
+
+func newSearchView(db *gorm.DB) (view, error) {
+	v := &searchView{db: db}
+	return v, v.search()
+}
+
+func (v *searchView) search() error {
+	matches, err := query.SearchCompanies(v.db, v.input, searchLimit)
+	if err != nil {
+		return err
+	}
+	v.matches, v.list = matches, list{n: len(matches)}
+	return nil
+}
+
+func (v *searchView) title() string {
+	return "filingsdb - companies"
+}
+
+func (v *searchView) help() string {
+	return "type a name, ticker or CIK  Up/Down select  Enter filings  Esc quit"
+}
+
+func (v *searchView) draw(s *screen) {
+	s.add("Search: "+v.input+"_", styleNormal)
+	s.add(fmt.Sprintf("%d companies", len(v.matches)), styleDim)
+	s.add(pad("CIK", 11, false)+pad("Tickers", 16, false)+pad("Filings", 8, true)+"  Name", styleHeader)
+	v.height = s.height - 3
+	start, end := v.list.visible(v.height)
+	for i := start; i < end; i++ {
+		m := v.matches[i]
+		style := styleNormal
+		if i == v.list.cursor {
+			style = styleSelected
+		}
+		s.add(pad(m.Cik, 11, false)+pad(strings.Join(m.Tickers, " "), 16, false)+pad(fmt.Sprint(m.Filings), 8, true)+"  "+pad(m.Name, s.width, false), style)
+	}
+}
+
+func (v *searchView) key(a *app, k key) error {
+	if v.list.move(k, v.height) {
+		return nil
+	}
+	switch k.code {
+	case keyEsc:
+		a.pop()
+	case keyEnter:
+		if len(v.matches) == 0 {
+			return nil
+		}
+		filings, err := newFilingsView(v.db, v.matches[v.list.cursor])
+		if err != nil {
+			return err
+		}
+		a.push(filings)
+	case keyBackspace:
+		if v.input == "" {
+			return nil
+		}
+		r := []rune(v.input)
+		v.input = string(r[:len(r)-1])
+		return v.search()
+	case keyRune:
+		v.input += string(k.r)
+		return v.search()
+	}
+	return nil
+}
+
+// filingsView lists the filings of a company, filtered by form and period
+type filingsView struct {
+	db      *gorm.DB
+	company query.CompanyMatch
+	subs    []models.DataSUB
+
+	// The forms of the filings, the filter being forms[form] unless 0
+	forms []string
+	form  int
+
+	// The prefix of the periods or fiscal years of the filings, edited if
+	// editing
+	period  string
+	editing bool
+
+	shown  []models.DataSUB
+	list   list
+	height int
+}
+
+func newFilingsView(db *gorm.DB, company query.CompanyMatch) (view, error) {
+	v := &filingsView{db: db, company: company, forms: []string{"all"}}
+	if err := db.Where("cik = ?", company.Cik).Order("accepted DESC").Find(&v.subs).Error; err != nil {
+		return nil, err
+	}
+	forms := map[string]bool{}
+	for _, sub := range v.subs {
+		if !forms[sub.Form] {
+			forms[sub.Form] = true
+			v.forms = append(v.forms, sub.Form)
+		}
+	}
+	sort.Strings(v.forms[1:])
+	v.filter()
+	return v, nil
+}
+
+// filter updates the filings shown
+func (v *filingsView) filter() {
+	v.shown = nil
+	for _, sub := range v.subs {
+		if v.form > 0 && sub.Form != v.forms[v.form] {
+			continue
+		}
+		if !strings.HasPrefix(sub.Period, v.period) && !strings.HasPrefix(sub.Fy, v.period) {
+			continue
+		}
+		v.shown = append(v.shown, sub)
+	}
+	v.list = list{n: len(v.shown)}
+}
+
+func (v *filingsView) title() string {
+	return fmt.Sprintf("%s (CIK %s) - filings", v.company.Name, v.company.Cik)
+}
+
+func (v *filingsView) help() string {
+	if v.editing {
+		return "type a period (20181231) or fiscal year prefix  Enter done"
+	}
+	return "Up/Down select  Enter reports  f form  p period  Esc back"
+}
+
+func (v *filingsView) draw(s *screen) {
+	period := v.period
+	if v.editing {
+		period += "_"
+	}
+	s.add(fmt.Sprintf("Form: %s  Period: %s  (%d of %d filings)", v.forms[v.form], period, len(v.shown), len(v.subs)), styleNormal)
+	s.add(pad("Form", 10, false)+pad("Period", 10, false)+pad("FY", 6, false)+pad("FP", 4, false)+pad("Filed", 10, false)+"Accession number", styleHeader)
+	v.height = s.height - 2
+	start, end := v.list.visible(v.height)
+	for i := start; i < end; i++ {
+		sub := v.shown[i]
+		style := styleNormal
+		if i == v.list.cursor {
+			style = styleSelected
+		}
+		s.add(pad(sub.Form, 10, false)+pad(sub.Period, 10, false)+pad(sub.Fy, 6, false)+pad(sub.Fp, 4, false)+pad(sub.Filed, 10, false)+pad(sub.Adsh, s.width, false), style)
+	}
+}
+
+func (v *filingsView) key(a *app, k key) error {
+	if v.editing {
+		switch k.code {
+		case keyEnter, keyEsc:
+			v.editing = false
+		case keyBackspace:
+			if v.period != "" {
+				v.period = v.period[:len(v.period)-1]
+			}
+		case keyRune:
+			if k.r >= '0' && k.r <= '9' {
+				v.period += string(k.r)
+			}
+		}
+		v.filter()
+		return nil
+	}
+	if v.list.move(k, v.height) {
+		return nil
+	}
+	switch {
+	case k.code == keyEsc || k.r == 'q':
+		a.pop()
+	case k.r == 'f':
+		v.form = (v.form + 1) % len(v.forms)
+		v.filter()
+	case k.r == 'p':
+		v.editing = true
+	case k.code == keyEnter:
+		if len(v.shown) == 0 {
+			return nil
+		}
+		reports, err := newReportsView(v.db, v.shown[v.list.cursor])
+		if err != nil {
+			return err
+		}
+		a.push(reports)
+	}
+	return nil
+}
+
+// reportsView lists the reports of a filing, by menu category
+type reportsView struct {
+	db     *gorm.DB
+	sub    models.DataSUB
+	rows   []reportRow
+	list   list
+	height int
+}
+
+// reportRow is a menu category, or a report under it
+type reportRow struct {
+	text string
+
+	// The number of the report, empty for a category
+	report string
+}
+
+func newReportsView(db *gorm.DB, sub models.DataSUB) (view, error) {
+	tree, err := query.Reports(db, sub.Adsh)
+	if err != nil {
+		return nil, err
+	}
+	v := &reportsView{db: db, sub: sub}
+	var add func(node *query.ReportNode, depth int)
+	add = func(node *query.ReportNode, depth int) {
+		v.rows = append(v.rows, reportRow{fmt.Sprintf("%sR%-4s %s", strings.Repeat("  ", depth), node.Report, node.Shortname), node.Report})
+		for _, child := range node.Children {
+			add(child, depth+1)
+		}
+	}
+	for _, c := range tree.Categories {
+		v.rows = append(v.rows, reportRow{text: c.Name})
+		for _, node := range c.Reports {
+			add(node, 1)
+		}
+	}
+	if len(v.rows) == 0 {
+		return nil, fmt.Errorf("no reports in submission %s", sub.Adsh)
+	}
+	v.list = list{n: len(v.rows), cursor: 1}
+	return v, nil
+}
+
+func (v *reportsView) title() string {
+	return fmt.Sprintf("%s %s, period %s - %s", v.sub.Name, v.sub.Form, v.sub.Period, v.sub.Adsh)
+}
+
+func (v *reportsView) help() string {
+	return "Up/Down select  Enter open the report  Esc back"
+}
+
+func (v *reportsView) draw(s *screen) {
+	v.height = s.height
+	start, end := v.list.visible(v.height)
+	for i := start; i < end; i++ {
+		row := v.rows[i]
+		style := styleNormal
+		if row.report == "" {
+			style = styleHeader
+		}
+		if i == v.list.cursor {
+			style = styleSelected
+		}
+		s.add(pad(row.text, s.width, false), style)
+	}
+}
+
+func (v *reportsView) key(a *app, k key) error {
+	if v.list.move(k, v.height) {
+		return nil
+	}
+	switch {
+	case k.code == keyEsc || k.r == 'q':
+		a.pop()
+	case k.code == keyEnter:
+		row := v.rows[v.list.cursor]
+		if row.report == "" {
+			return nil
+		}
+		r, err := query.FilingReport(v.db, v.sub.Adsh, row.report)
+		if err != nil {
+			return err
+		}
+		a.push(&reportView{db: v.db, r: r, list: list{n: len(r.Lines)}})
+	}
+	return nil
+}
+
+// reportView shows a report as a table, the lines of the report in rows and
+// its periods in columns
+type reportView struct {
+	db *gorm.DB
+	r  *query.Report
+
+	// The first column shown
+	column int
+
+	// The count of columns shown
+	columns int
+
+	list   list
+	height int
+}
+
+// valueWidth is the width of the columns of values
+const valueWidth = 18
+
+func (v *reportView) title() string {
+	return fmt.Sprintf("%s %s - R%s %s", v.r.Sub.Name, v.r.Sub.Form, v.r.Ren.Report, v.r.Ren.Shortname)
+}
+
+func (v *reportView) help() string {
+	return "Up/Down select  Left/Right columns  Enter fact detail  t text block  Esc back"
+}
+
+func (v *reportView) draw(s *screen) {
+	labelWidth := s.width * 2 / 5
+	if labelWidth < 20 {
+		labelWidth = 20
+	}
+	v.columns = (s.width - labelWidth) / (valueWidth + 1)
+	if v.columns < 1 {
+		v.columns = 1
+	}
+	if v.column > len(v.r.Columns)-v.columns {
+		v.column = len(v.r.Columns) - v.columns
+	}
+	if v.column < 0 {
+		v.column = 0
+	}
+	end := v.column + v.columns
+	if end > len(v.r.Columns) {
+		end = len(v.r.Columns)
+	}
+
+	// the periods on two lines as the EDGAR viewer puts them: 12 Months
+	// Ended, then Dec. 31, 2018
+	durations, dates, segments, dimensional := "", "", "", false
+	for _, c := range v.r.Columns[v.column:end] {
+		duration, date := "", c.Period()
+		if i := strings.Index(date, " Ended "); i >= 0 {
+			duration, date = date[:i+len(" Ended")], date[i+len(" Ended "):]
+		}
+		durations += " " + pad(duration, valueWidth, true)
+		dates += " " + pad(date, valueWidth, true)
+		segments += " " + pad(strings.TrimSuffix(c.Segments, ";"), valueWidth, true)
+		dimensional = dimensional || c.Segments != ""
+	}
+	scrolled := ""
+	if v.column > 0 || end < len(v.r.Columns) {
+		scrolled = fmt.Sprintf("columns %d-%d of %d", v.column+1, end, len(v.r.Columns))
+	}
+	header := 0
+	if len(v.r.Columns) > 0 {
+		s.add(pad(scrolled, labelWidth, false)+durations, styleHeader)
+		s.add(pad("", labelWidth, false)+dates, styleHeader)
+		header += 2
+	}
+	if len(v.r.Lines) == 0 {
+		s.add("The report has no presentation lines.", styleDim)
+	}
+	if dimensional {
+		s.add(pad("", labelWidth, false)+segments, styleHeader)
+		header++
+	}
+
+	v.height = s.height - header
+	start, stop := v.list.visible(v.height)
+	for i := start; i < stop; i++ {
+		line := v.r.Lines[i]
+		label := line.Plabel
+		if len(line.Texts) > 0 {
+			label += " [text]"
+		}
+		text := pad(label, labelWidth, false)
+		for _, value := range line.Values[v.column:end] {
+			cell := ""
+			if value != nil && value.Value != nil {
+				number := *value.Value
+				if line.Negating {
+					number = number.Neg()
+				}
+				cell = formatValue(number, value.Uom)
+			}
+			text += " " + pad(cell, valueWidth, true)
+		}
+		style := styleNormal
+		if line.Inpth {
+			style = styleDim
+		}
+		if i == v.list.cursor {
+			style = styleSelected
+		}
+		s.add(pad(text, s.width, false), style)
+	}
+}
+
+func (v *reportView) key(a *app, k key) error {
+	if v.list.move(k, v.height) {
+		return nil
+	}
+	if len(v.r.Lines) == 0 {
+		if k.code == keyEsc || k.r == 'q' {
+			a.pop()
+		}
+		return nil
+	}
+	line := v.r.Lines[v.list.cursor]
+	switch {
+	case k.code == keyEsc || k.r == 'q':
+		a.pop()
+	case k.code == keyLeft:
+		v.column--
+	case k.code == keyRight:
+		v.column++
+	case k.code == keyEnter:
+		detail, err := query.GetFactDetail(v.db, v.r.Sub.Adsh, line.Tag, line.Version)
+		if err != nil {
+			return err
+		}
+		a.push(&detailView{line: line, detail: detail})
+	case k.r == 't':
+		if len(line.Texts) == 0 {
+			return fmt.Errorf("%s has no text block", line.Tag)
+		}
+		a.push(&textView{heading: line.Plabel, text: *line.Texts[0].Value})
+	}
+	return nil
+}
+
+// detailView shows a tag of a report: its definition and its facts
+type detailView struct {
+	line   query.ReportLine
+	detail *query.FactDetail
+	pager  pager
+	height int
+}
+
+func (v *detailView) title() string {
+	return fmt.Sprintf("%s - %s", v.line.Plabel, v.line.Tag)
+}
+
+func (v *detailView) help() string {
+	if len(v.detail.Txts) > 0 {
+		return "Up/Down/PgUp/PgDn scroll  t text block  Esc back"
+	}
+	return "Up/Down/PgUp/PgDn scroll  Esc back"
+}
+
+func (v *detailView) draw(s *screen) {
+	lines := []screenLine{}
+	add := func(text string, style string) {
+		for _, l := range wrap(text, s.width) {
+			lines = append(lines, screenLine{l, style})
+		}
+	}
+
+	add("Tag", styleHeader)
+	add(fmt.Sprintf("%s (%s)", v.line.Tag, v.line.Version), styleNormal)
+	if t := v.detail.Tag; t != nil {
+		if t.Tlabel != nil {
+			add("Label: "+*t.Tlabel, styleNormal)
+		}
+		kind := []string{}
+		for _, p := range []*string{t.Datatype, t.Iord, t.Crdr} {
+			if p != nil && *p != "" {
+				kind = append(kind, *p)
+			}
+		}
+		if t.Custom {
+			kind = append(kind, "custom")
+		}
+		if len(kind) > 0 {
+			add("Type: "+strings.Join(kind, ", "), styleNormal)
+		}
+		if t.Doc != nil {
+			add("", styleNormal)
+			add(*t.Doc, styleNormal)
+		}
+	}
+
+	add("", styleNormal)
+	add(fmt.Sprintf("Facts (%d)", len(v.detail.Nums)), styleHeader)
+	for _, n := range v.detail.Nums {
+		value := ""
+		if n.Value != nil {
+			value = formatValue(*n.Value, n.Uom)
+		}
+		c := query.ReportColumn{Ddate: n.Ddate, Qtrs: n.Qtrs}
+		add(pad(c.Period(), 30, false)+" "+pad(value, valueWidth, true), styleNormal)
+		if n.Segments != "" {
+			add("  "+strings.TrimSuffix(n.Segments, ";"), styleDim)
+		}
+		if n.Coreg != nil {
+			add("  co-registrant "+*n.Coreg, styleDim)
+		}
+		if n.Footnote != nil {
+			add("  footnote: "+*n.Footnote, styleDim)
+		}
+	}
+
+	if len(v.detail.Txts) > 0 {
+		add("", styleNormal)
+		add(fmt.Sprintf("Text facts (%d)", len(v.detail.Txts)), styleHeader)
+		for _, t := range v.detail.Txts {
+			c := query.ReportColumn{Ddate: t.Ddate, Qtrs: t.Qtrs}
+			length := 0
+			if t.Value != nil {
+				length = len(*t.Value)
+			}
+			add(fmt.Sprintf("%s, %d characters", c.Period(), length), styleNormal)
+			if t.Footnote != nil {
+				add("  footnote: "+*t.Footnote, styleDim)
+			}
+		}
+	}
+
+	v.height = s.height
+	v.pager.show(s, lines)
+}
+
+func (v *detailView) key(a *app, k key) error {
+	if v.pager.scroll(k, v.height) {
+		return nil
+	}
+	switch {
+	case k.code == keyEsc || k.r == 'q':
+		a.pop()
+	case k.r == 't' || k.code == keyEnter:
+		for _, t := range v.detail.Txts {
+			if t.Value != nil {
+				a.push(&textView{heading: v.line.Plabel, text: *t.Value})
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// textView reads a text block
+type textView struct {
+	heading string
+	text    string
+	pager   pager
+	height  int
+}
+
+func (v *textView) title() string {
+	return v.heading
+}
+
+func (v *textView) help() string {
+	return "Up/Down/PgUp/PgDn scroll  Home/End top/bottom  Esc back"
+}
+
+func (v *textView) draw(s *screen) {
+	lines := []screenLine{}
+	for _, l := range wrap(v.text, s.width) {
+		lines = append(lines, screenLine{l, styleNormal})
+	}
+	v.height = s.height
+	v.pager.show(s, lines)
+}
+
+func (v *textView) key(a *app, k key) error {
+	if v.pager.scroll(k, v.height) {
+		return nil
+	}
+	if k.code == keyEsc || k.r == 'q' {
+		a.pop()
+	}
+	return nil
+}
+
+// formatValue returns v with thousands separators, followed by its unit
+// unless USD
+func formatValue(v decimal.Decimal, uom string) string {
+	s := v.String()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	integer, fraction := s, ""
+	if i := strings.Index(s, "."); i >= 0 {
+		integer, fraction = s[:i], s[i:]
+	}
+	for i := len(integer) - 3; i > 0; i -= 3 {
+		integer = integer[:i] + "," + integer[i:]
+	}
+	s = sign + integer + fraction
+	if uom != "USD" && uom != "" {
+		s += " " + uom
+	}
+	return s
+}
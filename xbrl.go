@@ -0,0 +1,178 @@
+package filingsdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eswiac.me/filingsdb/models"
+	"eswiac.me/filingsdb/query"
+	"eswiac.me/filingsdb/xbrl"
+	"gorm.io/gorm"
+)
+
+// IngestXBRL loads a filing from its XBRL instance (.xml) or inline XBRL
+// document (.htm) file and the taxonomy extension next to it, the way the
+// data sets would publish it: a submission (with the dataset "xbrl"), its
+// custom tags, dimensions, facts, reports, presentation and calculations.
+// adsh is its accession number and accepted its acceptance time, which the
+// filing doesn't tell, recorded in Eastern time as EDGAR does. The SIC code
+// and business address come from companies, when loaded. Loading a filing
+// again replaces it, unless the filter of the DB leaves it out.
+func (db *DB) IngestXBRL(ctx context.Context, file string, adsh string, accepted time.Time) error {
+	filing, err := xbrl.Load(file)
+	if err != nil {
+		return err
+	}
+	rows := filing.Rows(adsh)
+	accepted = accepted.In(query.Eastern())
+	rows.Sub.Filed = accepted.Format("20060102")
+	rows.Sub.Accepted = accepted.Format("2006-01-02 15:04:05.0")
+	if err := db.completeSub(&rows.Sub); err != nil {
+		return err
+	}
+	db.logger.Printf("%s: %s %s of %s, %d facts, %d text facts, %d reports",
+		adsh, rows.Sub.Form, rows.Sub.Period, rows.Sub.Name, len(rows.Nums), len(rows.Txts), len(rows.Rens))
+
+	// a filing filtered out leaves the one loaded before, if any
+	if db.filter != nil {
+		db.filter.reset()
+		if !db.filter.keep(rows.Sub) {
+			db.logger.Printf("%s: filtered out", adsh)
+			return nil
+		}
+	}
+
+	return db.gorm.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.DataSUB{}, &models.DataNUM{}, &models.DataTXT{}, &models.DataTXTValue{}, &models.DataREN{}, &models.DataPRE{}, &models.DataCAL{}} {
+			if err := tx.Where("adsh = ?", adsh).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("version = ?", adsh).Delete(&models.DataTAG{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&rows.Sub).Error; err != nil {
+			return err
+		}
+
+		// dimensions are shared by the submissions
+		dimhs := []string{}
+		for _, dim := range rows.Dims {
+			dimhs = append(dimhs, dim.Dimh)
+		}
+		existing := map[string]bool{}
+		for i := 0; i < len(dimhs); i += BATCH_SIZE {
+			end := i + BATCH_SIZE
+			if end > len(dimhs) {
+				end = len(dimhs)
+			}
+			found := []string{}
+			if err := tx.Model(&models.DataDIM{}).Where("dimh IN ?", dimhs[i:end]).Pluck("dimh", &found).Error; err != nil {
+				return err
+			}
+			for _, dimh := range found {
+				existing[dimh] = true
+			}
+		}
+
+		records := []interface{}{}
+		for _, r := range rows.Tags {
+			records = append(records, r)
+		}
+		for _, r := range rows.Dims {
+			if !existing[r.Dimh] {
+				records = append(records, r)
+			}
+		}
+		for _, r := range rows.Nums {
+			records = append(records, r)
+		}
+		if db.textPolicy != TextSkip {
+			for _, r := range rows.Txts {
+				records = append(records, r)
+			}
+		}
+		for _, r := range rows.Rens {
+			records = append(records, r)
+		}
+		for _, r := range rows.Pres {
+			records = append(records, r)
+		}
+		for _, r := range rows.Cals {
+			records = append(records, r)
+		}
+
+		batches := map[string]*batch{}
+		add := func(record interface{}) error {
+			model := fmt.Sprintf("%T", record)
+			if batches[model] == nil {
+				batches[model] = &batch{tx: tx}
+			}
+			return batches[model].add(record)
+		}
+		for _, record := range records {
+			if db.filter != nil && !db.filter.keep(record) {
+				continue
+			}
+			if txt, ok := record.(models.DataTXT); ok {
+				txt, value, keep := db.text(txt)
+				if !keep {
+					continue
+				}
+				if value != nil {
+					if err := add(*value); err != nil {
+						return err
+					}
+				}
+				record = txt
+			}
+			if err := add(record); err != nil {
+				return err
+			}
+		}
+		for _, b := range batches {
+			if err := b.flush(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// completeSub adds to sub what EDGAR knows of its company: its SIC code, and
+// its name and business address if the filing doesn't tell them
+func (db *DB) completeSub(sub *models.DataSUB) error {
+	if sub.Cik == "" || !db.gorm.Migrator().HasTable(&models.Company{}) {
+		return nil
+	}
+	companies := []models.Company{}
+	if err := db.gorm.Where("cik = ?", sub.Cik).Limit(1).Find(&companies).Error; err != nil {
+		return err
+	}
+	if len(companies) == 0 {
+		return nil
+	}
+	c := companies[0]
+	if c.Sic != nil {
+		sub.Sic = *c.Sic
+	}
+	if sub.Name == "" {
+		sub.Name = c.Name
+	}
+	if sub.Cityba == "" && c.BusinessCity != nil {
+		sub.Cityba = *c.BusinessCity
+		sub.Stprba = c.BusinessStateOrCountry
+		sub.Zipba = c.BusinessZipCode
+		sub.Bas1 = c.BusinessStreet1
+		sub.Bas2 = c.BusinessStreet2
+	}
+	if sub.Baph == nil {
+		sub.Baph = c.Phone
+	}
+	if sub.Ein == nil {
+		sub.Ein = c.Ein
+	}
+	return nil
+}
@@ -0,0 +1,536 @@
+package xbrl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Instance is an XBRL instance document, or the XBRL data of an inline XBRL
+// (iXBRL) document: the facts it reports in their contexts and units
+type Instance struct {
+	// File name of the instance document
+	File string
+
+	// Whether it's an inline XBRL document
+	Inline bool
+
+	// The taxonomy extension (.xsd) the instance refers to, relative to it
+	SchemaRef string
+
+	Contexts map[string]*Context
+	Units    map[string]string
+	Facts    []*Fact
+
+	// Namespaces by prefix, declared in the document
+	Namespaces map[string]string
+}
+
+// Context is the entity, period and dimensions of facts
+type Context struct {
+	ID string
+
+	// The identifier of the entity, its CIK
+	Entity string
+
+	// Start and end dates of a duration, end date of an instant (Start nil)
+	Start *time.Time
+	End   time.Time
+
+	// Whether the period is forever, without dates
+	Forever bool
+
+	// The dimensions of the segment and scenario of the context
+	Members []Member
+}
+
+// Member is a dimension of a context: an explicit member of an axis, or the
+// value of a typed dimension
+type Member struct {
+	Axis   string
+	Member string
+	Typed  bool
+}
+
+// Fact is a value reported by an instance
+type Fact struct {
+	ID string
+
+	// Namespace and local name of the concept (tag)
+	Namespace string
+	Name      string
+
+	ContextRef string
+
+	// Unit of a numeric fact, e.g. USD or USD/shares, empty for a
+	// non-numeric fact
+	Unit string
+
+	// decimals attribute: INF or an integer, empty if none
+	Decimals string
+
+	// Value as reported: text, stripped of the markup for Markup true,
+	// scaled and signed for an iXBRL numeric value
+	Value  string
+	Markup bool
+	Nil    bool
+
+	// Size of the value in the document, markup included
+	Srclen int
+
+	// xml:lang of the fact or of its closest element
+	Lang string
+
+	// Footnotes of the fact, as plain text
+	Footnotes []string
+
+	// ids of the ix:footnote of an iXBRL 1.0 fact
+	footnoteRefs string
+}
+
+// Numeric reports whether f is a numeric fact
+func (f *Fact) Numeric() bool {
+	return f.Unit != ""
+}
+
+// Number returns the value of a numeric fact, nil if nil or not a number
+func (f *Fact) Number() *decimal.Decimal {
+	if f.Nil {
+		return nil
+	}
+	v, err := decimal.NewFromString(strings.TrimSpace(f.Value))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// ParseInstance parses an XBRL instance (.xml) or inline XBRL (.htm) document
+func ParseInstance(file string) (*Instance, error) {
+	doc, err := parseFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	instance := &Instance{
+		File:       file,
+		Contexts:   map[string]*Context{},
+		Units:      map[string]string{},
+		Namespaces: doc.namespaces,
+	}
+	doc.root.walk(func(n *node) bool {
+		switch {
+		case n.is(nsLinkbase, "schemaRef") && instance.SchemaRef == "":
+			instance.SchemaRef = n.attrNS(nsXlink, "href")
+		case n.is(nsInstance, "context"):
+			c, err := parseContext(n)
+			if err == nil {
+				instance.Contexts[c.ID] = c
+			}
+			return false
+		case n.is(nsInstance, "unit"):
+			instance.Units[n.attr("id")] = parseUnit(n)
+			return false
+		case isInline(n.name.Space):
+			instance.Inline = true
+		}
+		return true
+	})
+	if instance.Inline {
+		instance.Facts = inlineFacts(doc)
+	} else {
+		instance.Facts = instanceFacts(doc)
+	}
+	return instance, nil
+}
+
+// parseContext parses an xbrli:context
+func parseContext(n *node) (*Context, error) {
+	c := &Context{ID: n.attr("id")}
+	if entity := n.child(nsInstance, "entity"); entity != nil {
+		if id := entity.child(nsInstance, "identifier"); id != nil {
+			c.Entity = id.trimmedText()
+		}
+		if segment := entity.child(nsInstance, "segment"); segment != nil {
+			c.Members = append(c.Members, members(segment)...)
+		}
+	}
+	if scenario := n.child(nsInstance, "scenario"); scenario != nil {
+		c.Members = append(c.Members, members(scenario)...)
+	}
+	period := n.child(nsInstance, "period")
+	if period == nil {
+		return nil, fmt.Errorf("context %s: no period", c.ID)
+	}
+	if period.child(nsInstance, "forever") != nil {
+		c.Forever = true
+		return c, nil
+	}
+	end := period.child(nsInstance, "instant")
+	if end == nil {
+		end = period.child(nsInstance, "endDate")
+		start := period.child(nsInstance, "startDate")
+		if end == nil || start == nil {
+			return nil, fmt.Errorf("context %s: no dates", c.ID)
+		}
+		s, err := parseDate(start.trimmedText())
+		if err != nil {
+			return nil, err
+		}
+		c.Start = &s
+	}
+	e, err := parseDate(end.trimmedText())
+	if err != nil {
+		return nil, err
+	}
+	c.End = e
+	return c, nil
+}
+
+// parseDate parses an xs:date or xs:dateTime. A date time of midnight is
+// the end of the day before, as XBRL specifies for end dates.
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02T15:04:05", strings.TrimSuffix(s, "Z"))
+	if err != nil {
+		return t, err
+	}
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		t = t.AddDate(0, 0, -1)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// members returns the dimensions of a segment or scenario
+func members(n *node) []Member {
+	members := []Member{}
+	for _, m := range n.elements() {
+		switch {
+		case m.is(nsDimension, "explicitMember"):
+			members = append(members, Member{Axis: m.attr("dimension"), Member: m.trimmedText()})
+		case m.is(nsDimension, "typedMember"):
+			members = append(members, Member{Axis: m.attr("dimension"), Member: normalizeSpace(m.trimmedText()), Typed: true})
+		}
+	}
+	return members
+}
+
+// parseUnit returns the unit of measure of an xbrli:unit the way the data
+// sets write it: the local names of the measures, e.g. USD, shares or
+// USD/shares for a divide
+func parseUnit(n *node) string {
+	measures := func(n *node) string {
+		names := []string{}
+		for _, m := range n.elements() {
+			if m.is(nsInstance, "measure") {
+				names = append(names, localName(m.trimmedText()))
+			}
+		}
+		return strings.Join(names, "*")
+	}
+	if divide := n.child(nsInstance, "divide"); divide != nil {
+		numerator, denominator := divide.child(nsInstance, "unitNumerator"), divide.child(nsInstance, "unitDenominator")
+		if numerator != nil && denominator != nil {
+			return measures(numerator) + "/" + measures(denominator)
+		}
+	}
+	return measures(n)
+}
+
+// instanceFacts returns the facts of an XBRL instance: the elements with a
+// contextRef, those of tuples included, and their footnotes
+func instanceFacts(doc *document) []*Fact {
+	facts := []*Fact{}
+	ids := map[string]*Fact{}
+	for _, n := range doc.root.elements() {
+		n.walk(func(n *node) bool {
+			if n.name.Space == nsInstance || n.name.Space == nsLinkbase {
+				return false
+			}
+			if n.attr("contextRef") == "" {
+				return true // tuple
+			}
+			content, markup := n.content()
+			value := content
+			if strings.Contains(content, "<") {
+				// escaped markup of a text block
+				value, markup = stripMarkup(content), true
+			}
+			f := &Fact{
+				ID:         n.attr("id"),
+				Namespace:  n.name.Space,
+				Name:       n.name.Local,
+				ContextRef: n.attr("contextRef"),
+				Unit:       n.attr("unitRef"),
+				Decimals:   strings.TrimSpace(n.attr("decimals")),
+				Value:      strings.TrimSpace(value),
+				Markup:     markup,
+				Nil:        n.attrNS(nsXsi, "nil") == "true",
+				Srclen:     len(content),
+				Lang:       lang(n, doc.root),
+			}
+			facts = append(facts, f)
+			if f.ID != "" {
+				ids[f.ID] = f
+			}
+			return false
+		})
+	}
+	for _, n := range doc.root.elements() {
+		if n.is(nsLinkbase, "footnoteLink") {
+			linkFootnotes(n, ids)
+		}
+	}
+	return facts
+}
+
+// stripMarkup returns the text of the escaped XHTML s, s itself if it's not
+// well formed enough
+func stripMarkup(s string) string {
+	doc, err := parse(strings.NewReader("<div>" + s + "</div>"))
+	if err != nil {
+		return s
+	}
+	text, _ := doc.root.content()
+	return text
+}
+
+// linkFootnotes adds the footnotes of a link:footnoteLink to the facts
+// they're attached to
+func linkFootnotes(link *node, ids map[string]*Fact) {
+	locs, notes := map[string][]string{}, map[string][]string{}
+	for _, n := range link.elements() {
+		label := n.attrNS(nsXlink, "label")
+		switch {
+		case n.is(nsLinkbase, "loc"):
+			href := n.attrNS(nsXlink, "href")
+			locs[label] = append(locs[label], href[strings.Index(href, "#")+1:])
+		case n.is(nsLinkbase, "footnote"):
+			notes[label] = append(notes[label], normalizeSpace(n.trimmedText()))
+		}
+	}
+	for _, n := range link.elements() {
+		if !n.is(nsLinkbase, "footnoteArc") {
+			continue
+		}
+		for _, id := range locs[n.attrNS(nsXlink, "from")] {
+			if f, ok := ids[id]; ok {
+				f.Footnotes = append(f.Footnotes, notes[n.attrNS(nsXlink, "to")]...)
+			}
+		}
+	}
+}
+
+// lang returns the xml:lang of n, inherited from its ancestors
+func lang(n *node, root *node) string {
+	if l := n.attr("lang"); l != "" {
+		return l
+	}
+	return root.attr("lang")
+}
+
+// inlineFacts returns the facts of an inline XBRL document: its
+// ix:nonFraction and ix:nonNumeric elements, the continuations of the
+// latter, and their footnotes
+func inlineFacts(doc *document) []*Fact {
+	facts := []*Fact{}
+	ids := map[string]*Fact{}
+	continuedAt := map[*Fact]string{}
+	continuations := map[string]*node{}
+	footnotes := map[string]string{}
+	relationships := []*node{}
+
+	var visit func(n *node, lang string)
+	visit = func(n *node, lang string) {
+		if l := n.attr("lang"); l != "" {
+			lang = l
+		}
+		if isInline(n.name.Space) {
+			switch n.name.Local {
+			case "nonFraction", "nonNumeric":
+				f := inlineFact(n, doc.namespaces, lang)
+				facts = append(facts, f)
+				if f.ID != "" {
+					ids[f.ID] = f
+				}
+				if c := n.attr("continuedAt"); c != "" {
+					continuedAt[f] = c
+				}
+			case "continuation":
+				continuations[n.attr("id")] = n
+			case "footnote":
+				footnotes[n.attr("id")] = normalizeSpace(n.trimmedText())
+			case "relationship":
+				relationships = append(relationships, n)
+			}
+		}
+		for _, c := range n.children {
+			if c.name.Local != "" {
+				visit(c, lang)
+			}
+		}
+	}
+	visit(doc.root, "")
+
+	for f, id := range continuedAt {
+		for seen := map[string]bool{}; id != "" && !seen[id]; {
+			seen[id] = true
+			c, ok := continuations[id]
+			if !ok {
+				break
+			}
+			content, markup := c.content()
+			f.Value = strings.TrimSpace(f.Value + " " + content)
+			f.Markup = f.Markup || markup
+			f.Srclen += len(content)
+			id = c.attr("continuedAt")
+		}
+	}
+	for _, r := range relationships {
+		for _, from := range strings.Fields(r.attr("fromRefs")) {
+			for _, to := range strings.Fields(r.attr("toRefs")) {
+				if f, ok := ids[from]; ok && footnotes[to] != "" {
+					f.Footnotes = append(f.Footnotes, footnotes[to])
+				}
+			}
+		}
+	}
+	// iXBRL 1.0 footnotes refer to facts with footnoteRefs
+	for _, f := range facts {
+		for _, id := range strings.Fields(f.footnoteRefs) {
+			if footnotes[id] != "" {
+				f.Footnotes = append(f.Footnotes, footnotes[id])
+			}
+		}
+	}
+	return facts
+}
+
+// inlineFact returns the fact of an ix:nonFraction or ix:nonNumeric, its
+// value transformed by its format
+func inlineFact(n *node, namespaces map[string]string, lang string) *Fact {
+	name := n.attr("name")
+	content, markup := n.content()
+	f := &Fact{
+		ID:           n.attr("id"),
+		Namespace:    namespaces[prefix(name)],
+		Name:         localName(name),
+		ContextRef:   n.attr("contextRef"),
+		Unit:         n.attr("unitRef"),
+		Decimals:     strings.TrimSpace(n.attr("decimals")),
+		Markup:       markup,
+		Nil:          n.attrNS(nsXsi, "nil") == "true",
+		Srclen:       len(content),
+		Lang:         lang,
+		footnoteRefs: n.attr("footnoteRefs"),
+	}
+	format := localName(n.attr("format"))
+	if n.name.Local == "nonNumeric" {
+		f.Value = strings.TrimSpace(content)
+		if format != "" {
+			f.Value = transformText(format, normalizeSpace(content))
+		}
+		return f
+	}
+	if f.Nil {
+		return f
+	}
+	f.Value = transformNumber(format, normalizeSpace(content), n.attr("scale"))
+	if n.attr("sign") == "-" && f.Value != "" && f.Value != "0" {
+		f.Value = "-" + f.Value
+	}
+	return f
+}
+
+// transformNumber returns the number of an ix:nonFraction, written in the
+// format of the document and scaled by 10^scale
+func transformNumber(format string, text string, scale string) string {
+	format = strings.Replace(format, "-", "", -1)
+	if strings.HasPrefix(format, "zerodash") || strings.HasPrefix(format, "fixedzero") || strings.HasPrefix(format, "numdash") {
+		return "0"
+	}
+	decimalSeparator := '.'
+	if strings.Contains(format, "commadecimal") || strings.Contains(format, "numcomma") && !strings.Contains(format, "dot") {
+		decimalSeparator = ','
+	}
+	digits := strings.Builder{}
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == decimalSeparator:
+			digits.WriteRune('.')
+		}
+	}
+	v, err := decimal.NewFromString(digits.String())
+	if err != nil {
+		return ""
+	}
+	if s, err := decimal.NewFromString(strings.TrimSpace(scale)); err == nil && !s.IsZero() {
+		v = v.Shift(int32(s.IntPart()))
+	}
+	return v.String()
+}
+
+// transformText returns the value of an ix:nonNumeric written in a format
+// of the document, the dates as YYYY-MM-DD (--MM-DD for a day of the year)
+func transformText(format string, text string) string {
+	format = strings.Replace(format, "-", "", -1)
+	switch {
+	case strings.HasPrefix(format, "booleantrue"):
+		return "true"
+	case strings.HasPrefix(format, "booleanfalse"):
+		return "false"
+	case strings.HasPrefix(format, "date"):
+		return transformDate(text)
+	}
+	return text
+}
+
+// layouts of the dates of inline XBRL documents, with and without year
+var (
+	dateLayouts = []string{
+		"January 2, 2006", "January 2 2006", "Jan. 2, 2006", "Jan 2, 2006", "Jan 2 2006",
+		"2 January 2006", "2 Jan 2006", "1/2/2006", "01/02/2006", "1/2/06", "2006-01-02", "January 2,2006",
+	}
+	dayLayouts = []string{"January 2", "Jan 2", "Jan. 2", "2 January", "01/02", "1/2", "--01-02"}
+)
+
+// transformDate returns text as YYYY-MM-DD, or --MM-DD for a date without
+// year, text unchanged if it's not a date
+func transformDate(text string) string {
+	text = strings.TrimSpace(strings.Replace(text, " ,", ",", -1))
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+	for _, layout := range dayLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t.Format("--01-02")
+		}
+	}
+	return text
+}
+
+// Fact returns the value of the fact local name without dimensions in the
+// latest context, empty if none
+func (i *Instance) Fact(name string) string {
+	candidates := []*Fact{}
+	for _, f := range i.Facts {
+		if c, ok := i.Contexts[f.ContextRef]; ok && f.Name == name && len(c.Members) == 0 && !f.Nil {
+			candidates = append(candidates, f)
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return i.Contexts[candidates[a].ContextRef].End.After(i.Contexts[candidates[b].ContextRef].End)
+	})
+	if len(candidates) == 0 {
+		return ""
+	}
+	return normalizeSpace(candidates[0].Value)
+}
@@ -0,0 +1,224 @@
+package xbrl
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+)
+
+// Namespaces of the XBRL specifications
+const (
+	nsInstance  = "http://www.xbrl.org/2003/instance"
+	nsLinkbase  = "http://www.xbrl.org/2003/linkbase"
+	nsXlink     = "http://www.w3.org/1999/xlink"
+	nsDimension = "http://xbrl.org/2006/xbrldi"
+	nsSchema    = "http://www.w3.org/2001/XMLSchema"
+	nsXsi       = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+// node is an element of an XML document, or a text between elements if its
+// name is empty
+type node struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*node
+	text     string
+}
+
+// document is a parsed XML or XHTML file
+type document struct {
+	root *node
+
+	// namespaces declared in the document, by prefix
+	namespaces map[string]string
+}
+
+// parseFile parses the XML or XHTML file
+func parseFile(file string) (*document, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parse(f)
+}
+
+// parse parses an XML document, leniently enough for the XHTML of inline
+// XBRL (HTML entities, unclosed void elements)
+func parse(r io.Reader) (*document, error) {
+	d := xml.NewDecoder(r)
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+	doc := &document{namespaces: map[string]string{}}
+	root := &node{}
+	stack := []*node{root}
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name, attrs: t.Attr}
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" {
+					doc.namespaces[a.Name.Local] = a.Value
+				}
+			}
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			parent.children = append(parent.children, &node{text: string(t)})
+		}
+	}
+	for _, n := range root.children {
+		if n.name.Local != "" {
+			doc.root = n
+			break
+		}
+	}
+	if doc.root == nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return doc, nil
+}
+
+// attr returns the value of the attribute local, whatever its namespace
+func (n *node) attr(local string) string {
+	for _, a := range n.attrs {
+		if a.Name.Local == local && a.Name.Space != "xmlns" {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// attrNS returns the value of the attribute space:local
+func (n *node) attrNS(space string, local string) string {
+	for _, a := range n.attrs {
+		if a.Name.Local == local && a.Name.Space == space {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// is reports whether n is the element space:local
+func (n *node) is(space string, local string) bool {
+	return n.name.Space == space && n.name.Local == local
+}
+
+// elements returns the child elements of n
+func (n *node) elements() []*node {
+	elements := []*node{}
+	for _, c := range n.children {
+		if c.name.Local != "" {
+			elements = append(elements, c)
+		}
+	}
+	return elements
+}
+
+// child returns the first child element space:local of n, nil if none
+func (n *node) child(space string, local string) *node {
+	for _, c := range n.children {
+		if c.is(space, local) {
+			return c
+		}
+	}
+	return nil
+}
+
+// walk calls fn with n and its descendant elements in document order,
+// skipping the descendants of the elements fn returns false for
+func (n *node) walk(fn func(*node) bool) {
+	if n.name.Local == "" || !fn(n) {
+		return
+	}
+	for _, c := range n.children {
+		c.walk(fn)
+	}
+}
+
+// inlineElements are the XHTML elements not separating the text around
+// them, and the inline XBRL facts
+var inlineElements = map[string]bool{
+	"a": true, "b": true, "i": true, "u": true, "em": true, "strong": true, "span": true, "font": true,
+	"sup": true, "sub": true, "small": true, "big": true, "nonFraction": true, "nonNumeric": true,
+}
+
+// content returns the text of n and its descendants, and whether n has
+// child elements (markup). Elements but inline ones separate the texts of
+// their siblings by a space, the texts of ix:exclude elements being left out.
+func (n *node) content() (string, bool) {
+	b := &strings.Builder{}
+	markup := false
+	var write func(n *node)
+	write = func(n *node) {
+		for _, c := range n.children {
+			if c.name.Local == "" {
+				b.WriteString(c.text)
+				continue
+			}
+			markup = true
+			if isInline(c.name.Space) && c.name.Local == "exclude" {
+				continue
+			}
+			if inlineElements[c.name.Local] {
+				write(c)
+				continue
+			}
+			b.WriteString(" ")
+			write(c)
+			b.WriteString(" ")
+		}
+	}
+	write(n)
+	return b.String(), markup
+}
+
+// trimmedText returns the text of n, trimmed
+func (n *node) trimmedText() string {
+	text, _ := n.content()
+	return strings.TrimSpace(text)
+}
+
+// localName returns the local part of a QName, e.g. Assets for us-gaap:Assets
+func localName(qname string) string {
+	if i := strings.LastIndex(qname, ":"); i >= 0 {
+		return qname[i+1:]
+	}
+	return qname
+}
+
+// prefix returns the prefix of a QName, e.g. us-gaap for us-gaap:Assets
+func prefix(qname string) string {
+	if i := strings.LastIndex(qname, ":"); i >= 0 {
+		return qname[:i]
+	}
+	return ""
+}
+
+// isInline reports whether space is a namespace of inline XBRL, 1.0 or 1.1
+func isInline(space string) bool {
+	return strings.HasPrefix(space, "http://www.xbrl.org/") && strings.HasSuffix(space, "/inlineXBRL")
+}
+
+// normalizeSpace collapses the sequences of white space (non-breaking
+// spaces included) of s to a single space, without leading nor trailing
+// spaces
+func normalizeSpace(s string) string {
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == ' ' || r == '\f' || r == '\v'
+	}), " ")
+}
@@ -0,0 +1,625 @@
+package xbrl
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"eswiac.me/filingsdb/models"
+)
+
+// txtMaxLen is the maximum size in bytes of the values of txt.tsv
+const txtMaxLen = 2048
+
+// Filing is an instance and its taxonomy extension
+type Filing struct {
+	Instance *Instance
+	Taxonomy *Taxonomy
+}
+
+// Rows are the rows of the data sets of a filing
+type Rows struct {
+	Sub  models.DataSUB
+	Tags []models.DataTAG
+	Dims []models.DataDIM
+	Nums []models.DataNUM
+	Txts []models.DataTXT
+	Rens []models.DataREN
+	Pres []models.DataPRE
+	Cals []models.DataCAL
+}
+
+// Load parses the instance document (.xml) or inline XBRL document (.htm)
+// file and the taxonomy extension it refers to, next to it
+func Load(file string) (*Filing, error) {
+	instance, err := ParseInstance(file)
+	if err != nil {
+		return nil, err
+	}
+	filing := &Filing{Instance: instance, Taxonomy: &Taxonomy{
+		Elements:     map[string]*Element{},
+		Labels:       map[string]map[string]string{},
+		Presentation: map[string][]*Arc{},
+		Calculation:  map[string][]*Arc{},
+	}}
+	if instance.SchemaRef != "" && !isURL(instance.SchemaRef) {
+		t, err := ParseTaxonomy(filepath.Join(filepath.Dir(file), instance.SchemaRef))
+		if err != nil {
+			return nil, err
+		}
+		filing.Taxonomy = t
+	}
+	return filing, nil
+}
+
+// Rows returns the rows of the filing as the data sets would have them,
+// adsh being its accession number. The submission misses what only EDGAR
+// knows: sic, filing and acceptance dates, and the mailing address.
+func (f *Filing) Rows(adsh string) Rows {
+	rows := Rows{}
+	rows.Rens = f.rens(adsh)
+	rows.Pres = f.pres(adsh, rows.Rens)
+	rows.Cals = f.cals(adsh)
+	rows.Tags = f.tags(adsh)
+	rows.Nums, rows.Txts, rows.Dims = f.facts(adsh)
+	rows.Sub = f.sub(adsh, rows)
+	return rows
+}
+
+// versionOf returns the version of the tags of namespace, adsh for the
+// custom ones
+func (f *Filing) versionOf(namespace string, adsh string) string {
+	if namespace == f.Taxonomy.Namespace {
+		return adsh
+	}
+	if v := version(namespace); v != "" {
+		return v
+	}
+	return namespace
+}
+
+// hrefTag returns the tag and version of the element of a locator href
+func (f *Filing) hrefTag(href string, adsh string) (string, string) {
+	id := fragment(href)
+	if e, ok := f.Taxonomy.Elements[id]; ok && !isURL(href) {
+		return e.Name, adsh
+	}
+	name := id
+	if i := strings.Index(id, "_"); i >= 0 {
+		name = id[i+1:]
+	}
+	if !isURL(href) {
+		return name, adsh
+	}
+	return name, version(href)
+}
+
+// facts returns the numeric and text facts of the instance, and the
+// dimensions of their contexts. The same fact reported several times
+// (e.g. in a statement and a note of an iXBRL document) is kept once.
+func (f *Filing) facts(adsh string) ([]models.DataNUM, []models.DataTXT, []models.DataDIM) {
+	nums, txts, dims := []models.DataNUM{}, []models.DataTXT{}, []models.DataDIM{}
+	seenFacts, seenDims := map[string]bool{}, map[string]bool{}
+	for _, fact := range f.Instance.Facts {
+		c, ok := f.Instance.Contexts[fact.ContextRef]
+		if !ok || c.Forever {
+			continue
+		}
+		key := strings.Join([]string{fact.Namespace, fact.Name, fact.ContextRef, fact.Unit, fact.Decimals, fact.Value}, "\t")
+		if seenFacts[key] {
+			continue
+		}
+		seenFacts[key] = true
+
+		segments, coreg := Segments(c.Members)
+		dimh := models.DimHash(segments)
+		if !seenDims[dimh] {
+			seenDims[dimh] = true
+			dim := models.DataDIM{Dimh: dimh, Segments: segments}
+			if len(segments) > 1024 {
+				dim.Segments, dim.Segt = segments[:1024], true
+			}
+			dims = append(dims, dim)
+		}
+		ddate, qtrs, durp, datp := models.RoundPeriod(c.Start, c.End)
+		footnote := strings.Join(fact.Footnotes, " ")
+		version := f.versionOf(fact.Namespace, adsh)
+
+		if fact.Numeric() {
+			nums = append(nums, models.DataNUM{
+				Adsh:     adsh,
+				Tag:      fact.Name,
+				Version:  version,
+				Ddate:    ddate,
+				Qtrs:     qtrs,
+				Uom:      f.Instance.Units[fact.Unit],
+				Dimh:     dimh,
+				Value:    fact.Number(),
+				Footnote: strOrNil(footnote),
+				Footlen:  len(footnote),
+				Dimn:     len(c.Members),
+				Coreg:    coreg,
+				Durp:     &durp,
+				Datp:     &datp,
+				Dcml:     decimals(fact.Decimals),
+			})
+			continue
+		}
+		dimn, txtlen, footlen := len(c.Members), 0, len(footnote)
+		txt := models.DataTXT{
+			Adsh:     adsh,
+			Tag:      fact.Name,
+			Version:  version,
+			Ddate:    ddate,
+			Qtrs:     qtrs,
+			Lang:     fact.Lang,
+			Dcml:     langPriority(fact.Lang),
+			Durp:     durp,
+			Datp:     datp,
+			Dimh:     dimh,
+			Dimn:     &dimn,
+			Coreg:    coreg,
+			Escaped:  fact.Markup,
+			Srclen:   fact.Srclen,
+			Txtlen:   &txtlen,
+			Footnote: strOrNil(footnote),
+			Footlen:  &footlen,
+			Context:  fact.ContextRef,
+		}
+		if !fact.Nil {
+			value := normalizeSpace(fact.Value)
+			txtlen = len(value)
+			txt.Value = &value
+			if len(value) > txtMaxLen {
+				truncated := truncate(value, txtMaxLen)
+				txt.Value = &truncated
+			}
+		}
+		txts = append(txts, txt)
+	}
+	prioritizeNums(nums)
+	prioritizeTxts(txts)
+	return nums, txts, dims
+}
+
+// prioritizeNums numbers (iprx) the facts of the same tag, period, unit and
+// dimensions by priority: higher precision first, then closer to the
+// period end, then closer to the duration
+func prioritizeNums(nums []models.DataNUM) {
+	groups := map[string][]int{}
+	for i, n := range nums {
+		key := strings.Join([]string{n.Tag, n.Version, n.Ddate, strconv.Itoa(n.Qtrs), n.Uom, n.Dimh}, "\t")
+		groups[key] = append(groups[key], i)
+	}
+	for _, indexes := range groups {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			x, y := nums[indexes[a]], nums[indexes[b]]
+			if x.Dcml != y.Dcml {
+				return x.Dcml > y.Dcml
+			}
+			if !x.Datp.Abs().Equal(y.Datp.Abs()) {
+				return x.Datp.Abs().LessThan(y.Datp.Abs())
+			}
+			return x.Durp.Abs().LessThan(y.Durp.Abs())
+		})
+		for p, i := range indexes {
+			nums[i].Iprx = p + 1
+		}
+	}
+}
+
+// prioritizeTxts numbers (iprx) the text facts of the same tag, period and
+// dimensions by priority: language first, then closer to the period
+func prioritizeTxts(txts []models.DataTXT) {
+	groups := map[string][]int{}
+	for i, t := range txts {
+		key := strings.Join([]string{t.Tag, t.Version, t.Ddate, strconv.Itoa(t.Qtrs), t.Dimh}, "\t")
+		groups[key] = append(groups[key], i)
+	}
+	for _, indexes := range groups {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			x, y := txts[indexes[a]], txts[indexes[b]]
+			if x.Dcml != y.Dcml {
+				return x.Dcml > y.Dcml
+			}
+			if !x.Datp.Abs().Equal(y.Datp.Abs()) {
+				return x.Datp.Abs().LessThan(y.Datp.Abs())
+			}
+			return x.Durp.Abs().LessThan(y.Durp.Abs())
+		})
+		for p, i := range indexes {
+			txts[i].Iprx = p + 1
+		}
+	}
+}
+
+// Segments returns the segments of dim.tsv of the dimensions of a context,
+// e.g. "ProductOrService=Advertising;", and its co-registrant, the member of
+// its LegalEntityAxis (nil if none). Tag names lose their prefix, first
+// characters "Statement" and last characters "Axis", "Member" or "Domain";
+// the pairs are sorted.
+func Segments(members []Member) (string, *string) {
+	pairs := []string{}
+	var coreg *string
+	for _, m := range members {
+		axis, member := segmentName(localName(m.Axis)), m.Member
+		if !m.Typed {
+			member = segmentName(localName(member))
+		}
+		if axis == "LegalEntity" {
+			legalEntity := member
+			coreg = &legalEntity
+		}
+		pairs = append(pairs, axis+"="+member+";")
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ""), coreg
+}
+
+// segmentName shortens an axis or member name the way dim.tsv does
+func segmentName(name string) string {
+	name = strings.TrimPrefix(name, "Statement")
+	for _, suffix := range []string{"Axis", "Member", "Domain"} {
+		if strings.HasSuffix(name, suffix) {
+			return strings.TrimSuffix(name, suffix)
+		}
+	}
+	return name
+}
+
+// decimals returns the dcml of num.tsv of a decimals attribute: INF, or no
+// attribute, being models.DcmlINF
+func decimals(d string) int {
+	n, err := strconv.Atoi(d)
+	if err != nil {
+		return models.DcmlINF
+	}
+	return n
+}
+
+// langPriority returns the dcml of txt.tsv of a language: en-US first,
+// then the other English dialects, then the other languages
+func langPriority(lang string) int {
+	switch {
+	case lang == "" || strings.EqualFold(lang, "en-US"):
+		return 32767
+	case strings.EqualFold(lang, "en") || strings.HasPrefix(strings.ToLower(lang), "en-"):
+		return 32766
+	}
+	return 0
+}
+
+// truncate returns the first bytes of s, up to max, without cutting a UTF-8
+// encoded character
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	cut := max
+	for cut > 0 && s[cut]&0xC0 == 0x80 {
+		cut--
+	}
+	return s[:cut]
+}
+
+// rens returns the reports of the roles of the taxonomy, numbered in the
+// order of their definitions (R1.htm, R2.htm...)
+func (f *Filing) rens(adsh string) []models.DataREN {
+	rfile := "X"
+	if f.Instance.Inline {
+		rfile = "H"
+	}
+	rens := []models.DataREN{}
+	notes := map[string]int{} // index of the notes in rens, by short name
+	for i, role := range f.Taxonomy.Roles {
+		category, shortname := roleCategory(role.Definition)
+		uri := role.URI
+		ren := models.DataREN{
+			Adsh:      adsh,
+			Report:    strconv.Itoa(i + 1),
+			Rfile:     rfile,
+			Menucat:   strOrNil(category),
+			Shortname: shortname,
+			Longname:  role.Definition,
+			Roleuri:   &uri,
+		}
+		if category == "N" {
+			notes[strings.ToLower(shortname)] = len(rens)
+			ren.Ultparentrpt = strOrNil(ren.Report)
+		}
+		rens = append(rens, ren)
+	}
+	// policies, tables and details belong to the note they start like
+	for i, ren := range rens {
+		if ren.Menucat == nil || (*ren.Menucat != "P" && *ren.Menucat != "T" && *ren.Menucat != "D") {
+			continue
+		}
+		name := strings.ToLower(ren.Shortname)
+		if j := strings.Index(name, " ("); j >= 0 {
+			name = name[:j]
+		}
+		best := -1
+		for note, n := range notes {
+			if strings.HasPrefix(name, note) && (best < 0 || len(note) > len(strings.ToLower(rens[best].Shortname))) {
+				best = n
+			}
+		}
+		if best >= 0 {
+			rens[i].Parentroleuri = rens[best].Roleuri
+			rens[i].Parentreport = strOrNil(rens[best].Report)
+			rens[i].Ultparentrpt = strOrNil(rens[best].Report)
+		}
+	}
+	return rens
+}
+
+// roleCategory returns the menu category of a role definition, e.g. S for
+// "0002000 - Statement - CONSOLIDATED BALANCE SHEETS", and its short name
+func roleCategory(definition string) (string, string) {
+	parts := strings.SplitN(definition, " - ", 3)
+	if len(parts) < 3 {
+		return "U", definition
+	}
+	shortname := strings.TrimSpace(parts[2])
+	switch strings.ToLower(strings.TrimSpace(parts[1])) {
+	case "document":
+		return "C", shortname
+	case "statement":
+		return "S", shortname
+	case "disclosure":
+		switch {
+		case strings.HasSuffix(shortname, "(Policies)"):
+			return "P", shortname
+		case strings.HasSuffix(shortname, "(Tables)"):
+			return "T", shortname
+		case strings.HasSuffix(shortname, "(Details)") || strings.HasSuffix(shortname, "(Details Narrative)") || strings.HasSuffix(shortname, "(Narrative)"):
+			return "D", shortname
+		}
+		return "N", shortname
+	case "schedule":
+		return "O", shortname
+	}
+	return "U", shortname
+}
+
+// statement returns the stmt of pre.tsv of a report
+func statement(ren models.DataREN) string {
+	if ren.Menucat != nil && *ren.Menucat == "C" {
+		return "CP"
+	}
+	name := strings.ToLower(ren.Shortname)
+	switch {
+	case strings.Contains(name, "comprehensive"):
+		return "CI"
+	case strings.Contains(name, "balance sheet") || strings.Contains(name, "financial position") || strings.Contains(name, "financial condition"):
+		return "BS"
+	case strings.Contains(name, "cash flow"):
+		return "CF"
+	case strings.Contains(name, "equity") || strings.Contains(name, "stockholders") || strings.Contains(name, "shareholders") || strings.Contains(name, "partners"):
+		return "EQ"
+	case strings.Contains(name, "income") || strings.Contains(name, "operations") || strings.Contains(name, "earnings"):
+		return "IS"
+	}
+	return "UN"
+}
+
+// pres returns the presentation lines of the reports, the tree of each role
+// walked depth first
+func (f *Filing) pres(adsh string, rens []models.DataREN) []models.DataPRE {
+	pres := []models.DataPRE{}
+	for i, role := range f.Taxonomy.Roles {
+		arcs := f.Taxonomy.Presentation[role.URI]
+		if len(arcs) == 0 {
+			continue
+		}
+		children, isChild, roots := map[string][]*Arc{}, map[string]bool{}, []string{}
+		for _, arc := range arcs {
+			children[arc.From] = append(children[arc.From], arc)
+			isChild[arc.To] = true
+		}
+		for _, arc := range arcs {
+			if !isChild[arc.From] && !in(roots, arc.From) {
+				roots = append(roots, arc.From)
+			}
+		}
+		stmt, inpth := statement(rens[i]), "0"
+		if strings.Contains(role.Definition, "(Parenthetical)") {
+			inpth = "1"
+		}
+		line := 0
+		visited := map[string]bool{}
+		var visit func(href string, preferredLabel string)
+		visit = func(href string, preferredLabel string) {
+			if visited[href] {
+				return
+			}
+			visited[href] = true
+			defer delete(visited, href)
+			line++
+			tag, version := f.hrefTag(href, adsh)
+			prole := "label"
+			if preferredLabel != "" {
+				prole = localName(preferredLabel[strings.LastIndex(preferredLabel, "/")+1:])
+			}
+			plabel := f.Taxonomy.Label(href, preferredLabel)
+			if plabel == "" {
+				plabel = tag
+			}
+			pres = append(pres, models.DataPRE{
+				Adsh:     adsh,
+				Report:   i + 1,
+				Line:     line,
+				Stmt:     stmt,
+				Inpth:    inpth,
+				Tag:      tag,
+				Version:  version,
+				Prole:    prole,
+				Plabel:   plabel,
+				Negating: strings.Contains(strings.ToLower(prole), "negated"),
+			})
+			for _, arc := range children[href] {
+				visit(arc.To, arc.PreferredLabel)
+			}
+		}
+		for _, root := range roots {
+			visit(root, "")
+		}
+	}
+	return pres
+}
+
+// cals returns the calculation arcs, a group by role
+func (f *Filing) cals(adsh string) []models.DataCAL {
+	cals := []models.DataCAL{}
+	grp := 0
+	for _, role := range f.Taxonomy.Roles {
+		arcs := f.Taxonomy.Calculation[role.URI]
+		if len(arcs) == 0 {
+			continue
+		}
+		grp++
+		for i, arc := range arcs {
+			ptag, pversion := f.hrefTag(arc.From, adsh)
+			ctag, cversion := f.hrefTag(arc.To, adsh)
+			cals = append(cals, models.DataCAL{
+				Adsh:     adsh,
+				Grp:      grp,
+				Arc:      i + 1,
+				Negative: arc.Weight < 0,
+				Ptag:     ptag,
+				Pversion: pversion,
+				Ctag:     ctag,
+				Cversion: cversion,
+			})
+		}
+	}
+	return cals
+}
+
+// tags returns the custom tags of the taxonomy extension
+func (f *Filing) tags(adsh string) []models.DataTAG {
+	ids := []string{}
+	for id := range f.Taxonomy.Elements {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	tags := []models.DataTAG{}
+	for _, id := range ids {
+		e := f.Taxonomy.Elements[id]
+		tag := models.DataTAG{
+			Tag:      e.Name,
+			Version:  adsh,
+			Custom:   true,
+			Abstract: e.Abstract,
+			Tlabel:   strOrNil(f.Taxonomy.Label(id, "")),
+			Doc:      strOrNil(truncate(f.Taxonomy.Documentation(id), 2048)),
+		}
+		if !e.Abstract {
+			tag.Datatype = strOrNil(strings.TrimSuffix(localName(e.Type), "ItemType"))
+			switch e.PeriodType {
+			case "instant":
+				tag.Iord = strOrNil("I")
+			case "duration":
+				tag.Iord = strOrNil("D")
+			}
+			switch e.Balance {
+			case "debit":
+				tag.Crdr = strOrNil("D")
+			case "credit":
+				tag.Crdr = strOrNil("C")
+			}
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// filerCategories are the afs of sub.tsv of the dei:EntityFilerCategory
+var filerCategories = map[string]string{
+	"large accelerated filer":             "1-LAF",
+	"accelerated filer":                   "2-ACC",
+	"smaller reporting accelerated filer": "3-SRA",
+	"non-accelerated filer":               "4-NON",
+	"smaller reporting company":           "5-SML",
+}
+
+// sub returns the submission as far as the facts of its cover page (dei)
+// tell
+func (f *Filing) sub(adsh string, rows Rows) models.DataSUB {
+	i := f.Instance
+	sub := models.DataSUB{
+		Adsh:     adsh,
+		Cik:      strings.TrimLeft(i.Fact("EntityCentralIndexKey"), "0"),
+		Name:     i.Fact("EntityRegistrantName"),
+		Form:     i.Fact("DocumentType"),
+		Fy:       i.Fact("DocumentFiscalYearFocus"),
+		Fp:       i.Fact("DocumentFiscalPeriodFocus"),
+		Fye:      strings.Replace(strings.TrimPrefix(i.Fact("CurrentFiscalYearEndDate"), "--"), "-", "", -1),
+		Cityba:   i.Fact("EntityAddressCityOrTown"),
+		Stprba:   strOrNil(i.Fact("EntityAddressStateOrProvince")),
+		Zipba:    strOrNil(i.Fact("EntityAddressPostalZipCode")),
+		Bas1:     strOrNil(i.Fact("EntityAddressAddressLine1")),
+		Bas2:     strOrNil(i.Fact("EntityAddressAddressLine2")),
+		Baph:     strOrNil(strings.TrimSpace(i.Fact("CityAreaCode") + " " + i.Fact("LocalPhoneNumber"))),
+		Stprinc:  strOrNil(i.Fact("EntityIncorporationStateCountryCode")),
+		Ein:      strOrNil(i.Fact("EntityTaxIdentificationNumber")),
+		Afs:      strOrNil(filerCategories[strings.ToLower(i.Fact("EntityFilerCategory"))]),
+		Wksi:     strings.EqualFold(i.Fact("EntityWellKnownSeasonedIssuer"), "Yes"),
+		Instance: filepath.Base(i.File),
+		Dataset:  models.DatasetXBRL,
+	}
+	sub.Countryba = i.Fact("EntityAddressCountry")
+	if end, err := parseDate(i.Fact("DocumentPeriodEndDate")); err == nil {
+		sub.Period, _, _, _ = models.RoundPeriod(nil, end)
+	}
+
+	// the registrant and the co-registrants
+	ciks := []string{}
+	for _, c := range i.Contexts {
+		if cik := strings.TrimLeft(c.Entity, "0"); cik != "" && !in(ciks, cik) {
+			ciks = append(ciks, cik)
+		}
+	}
+	sort.Strings(ciks)
+	if sub.Cik == "" && len(ciks) > 0 {
+		sub.Cik = ciks[0]
+	}
+	aciks := []string{}
+	for _, cik := range ciks {
+		if cik != sub.Cik {
+			aciks = append(aciks, cik)
+		}
+	}
+	sub.Nciks = len(aciks) + 1
+	sub.Aciks = strOrNil(strings.Join(aciks, " "))
+
+	for _, num := range rows.Nums {
+		if num.Tag == "EntityPublicFloat" && num.Dimh == models.DimHash("") && num.Iprx == 1 && num.Value != nil && num.Uom == "USD" {
+			value, date := *num.Value, num.Ddate
+			sub.Pubfloatusd, sub.Floatdate = &value, &date
+		}
+	}
+	for _, ren := range rows.Rens {
+		if ren.Menucat != nil && *ren.Menucat == "D" {
+			sub.Detail = true
+		}
+	}
+	return sub
+}
+
+func strOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func in(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
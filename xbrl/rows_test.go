@@ -0,0 +1,212 @@
+package xbrl
+
+import (
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"eswiac.me/filingsdb/models"
+	"github.com/shopspring/decimal"
+)
+
+const testAdsh = "0000012345-19-000001"
+
+// readTSV reads a file of the data sets as maps of column to value
+func readTSV(t *testing.T, file string) []map[string]string {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	header := strings.Split(lines[0], "\t")
+	rows := []map[string]string{}
+	for _, line := range lines[1:] {
+		row := map[string]string{}
+		for i, field := range strings.Split(line, "\t") {
+			row[header[i]] = field
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// numKey is a fact of num.tsv with its numbers normalized, the fractions of
+// periods to 4 decimals
+func numKey(adsh, tag, version, ddate, qtrs, uom, dimh, iprx, value, footlen, dimn, durp, datp, dcml string) string {
+	norm := func(s string, places int32) string {
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			return s
+		}
+		if places >= 0 {
+			d = d.Round(places)
+		}
+		return d.String()
+	}
+	return strings.Join([]string{adsh, tag, version, ddate, qtrs, uom, dimh, iprx, norm(value, -1),
+		footlen, dimn, norm(durp, 4), norm(datp, 4), dcml}, " ")
+}
+
+func TestRowsInstance(t *testing.T) {
+	filing, err := Load("testdata/ex-20181231.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := filing.Rows(testAdsh)
+
+	expected, got := []string{}, []string{}
+	for _, r := range readTSV(t, "testdata/num.tsv") {
+		expected = append(expected, numKey(r["adsh"], r["tag"], r["version"], r["ddate"], r["qtrs"], r["uom"], r["dimh"],
+			r["iprx"], r["value"], r["footlen"], r["dimn"], r["durp"], r["datp"], r["dcml"]))
+	}
+	for _, n := range rows.Nums {
+		got = append(got, numKey(n.Adsh, n.Tag, n.Version, n.Ddate, strconv.Itoa(n.Qtrs), n.Uom, n.Dimh,
+			strconv.Itoa(n.Iprx), n.Value.String(), strconv.Itoa(n.Footlen), strconv.Itoa(n.Dimn),
+			n.Durp.String(), n.Datp.String(), strconv.Itoa(n.Dcml)))
+	}
+	compareRows(t, "num.tsv", expected, got)
+
+	expected, got = []string{}, []string{}
+	for _, r := range readTSV(t, "testdata/dim.tsv") {
+		expected = append(expected, r["dimh"]+" "+r["segments"]+" "+r["segt"])
+		if dimh := models.DimHash(r["segments"]); dimh != r["dimh"] {
+			t.Errorf("DimHash(%q) = %s, expected %s", r["segments"], dimh, r["dimh"])
+		}
+	}
+	for _, d := range rows.Dims {
+		segt := "0"
+		if d.Segt {
+			segt = "1"
+		}
+		got = append(got, d.Dimh+" "+d.Segments+" "+segt)
+	}
+	compareRows(t, "dim.tsv", expected, got)
+
+	if len(rows.Tags) != 2 || rows.Tags[1].Tag != "WidgetAssets" || rows.Tags[1].Version != testAdsh || *rows.Tags[1].Crdr != "D" {
+		t.Errorf("custom tags %+v", rows.Tags)
+	}
+	if len(rows.Cals) != 1 || rows.Cals[0].Ptag != "Assets" || rows.Cals[0].Ctag != "WidgetAssets" || !rows.Cals[0].Negative {
+		t.Errorf("calculations %+v", rows.Cals)
+	}
+	if rows.Sub.Cik != "12345" || rows.Sub.Form != "10-K" || rows.Sub.Period != "20181231" || *rows.Sub.Afs != "1-LAF" || !rows.Sub.Detail {
+		t.Errorf("submission %+v", rows.Sub)
+	}
+}
+
+// compareRows reports the rows missing from got and those not expected
+func compareRows(t *testing.T, file string, expected []string, got []string) {
+	sort.Strings(expected)
+	sort.Strings(got)
+	if strings.Join(expected, "\n") != strings.Join(got, "\n") {
+		t.Errorf("%s rows\n%s\nexpected\n%s", file, strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestRowsInline(t *testing.T) {
+	filing, err := Load("testdata/ex-20181231.htm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := filing.Rows(testAdsh)
+	values := map[string]string{}
+	for _, n := range rows.Nums {
+		values[n.Tag] = n.Value.String()
+	}
+	expected := map[string]string{"Assets": "1000000000", "NetIncomeLoss": "-12500000", "WidgetAssets": "0"}
+	for tag, value := range expected {
+		if values[tag] != value {
+			t.Errorf("%s = %s, expected %s", tag, values[tag], value)
+		}
+	}
+	for _, txt := range rows.Txts {
+		if txt.Tag == "SegmentReportingDisclosureTextBlock" && *txt.Value != "We have one segment. It sells ads." {
+			t.Errorf("text block continued to %q", *txt.Value)
+		}
+	}
+	if rows.Sub.Name != "Example Corp" || rows.Sub.Period != "20181231" {
+		t.Errorf("submission %+v", rows.Sub)
+	}
+	if len(rows.Rens) != 4 || rows.Rens[0].Rfile != "H" {
+		t.Errorf("reports %+v", rows.Rens)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	tests := []struct {
+		members  []Member
+		segments string
+		coreg    string
+	}{
+		{nil, "", ""},
+		{
+			[]Member{{Axis: "us-gaap:StatementBusinessSegmentsAxis", Member: "ex:AdvertisingMember"}},
+			"BusinessSegments=Advertising;", "",
+		},
+		{
+			[]Member{
+				{Axis: "srt:ProductOrServiceAxis", Member: "us-gaap:ServiceDomain"},
+				{Axis: "dei:LegalEntityAxis", Member: "ex:SubsidiaryMember"},
+			},
+			"LegalEntity=Subsidiary;ProductOrService=Service;", "Subsidiary",
+		},
+		{
+			[]Member{{Axis: "ex:CustomerAxis", Member: "ACME Member", Typed: true}},
+			"Customer=ACME Member;", "",
+		},
+	}
+	for _, test := range tests {
+		segments, coreg := Segments(test.members)
+		if segments != test.segments {
+			t.Errorf("segments of %+v: %q, expected %q", test.members, segments, test.segments)
+		}
+		if (coreg == nil && test.coreg != "") || (coreg != nil && *coreg != test.coreg) {
+			t.Errorf("co-registrant of %+v: %v, expected %q", test.members, coreg, test.coreg)
+		}
+	}
+}
+
+func TestIprx(t *testing.T) {
+	filing, err := Load("testdata/ex-20181231.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the most precise fact first, whatever the order of the instance
+	for _, n := range filing.Rows(testAdsh).Nums {
+		if n.Tag == "Assets" && n.Dimh == models.DimHash("") && (n.Iprx == 1) != (n.Dcml == -3) {
+			t.Errorf("iprx %d for decimals %d", n.Iprx, n.Dcml)
+		}
+	}
+}
+
+func TestRoleCategory(t *testing.T) {
+	tests := []struct {
+		definition, category, shortname string
+	}{
+		{"0001000 - Document - Document and Entity Information", "C", "Document and Entity Information"},
+		{"0002000 - Statement - CONSOLIDATED BALANCE SHEETS", "S", "CONSOLIDATED BALANCE SHEETS"},
+		{"0003000 - Disclosure - Segment Information", "N", "Segment Information"},
+		{"0003100 - Disclosure - Segment Information (Policies)", "P", "Segment Information (Policies)"},
+		{"0003200 - Disclosure - Segment Information (Tables)", "T", "Segment Information (Tables)"},
+		{"0003300 - Disclosure - Segment Information (Details)", "D", "Segment Information (Details)"},
+		{"0003400 - Disclosure - Segment Information (Details Narrative)", "D", "Segment Information (Details Narrative)"},
+		{"0004000 - Schedule - Valuation and Qualifying Accounts", "O", "Valuation and Qualifying Accounts"},
+		{"Balance Sheet", "U", "Balance Sheet"},
+	}
+	for _, test := range tests {
+		category, shortname := roleCategory(test.definition)
+		if category != test.category || shortname != test.shortname {
+			t.Errorf("roleCategory(%q) = %s, %q, expected %s, %q", test.definition, category, shortname, test.category, test.shortname)
+		}
+	}
+
+	filing, err := Load("testdata/ex-20181231.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rens := filing.Rows(testAdsh).Rens
+	if details := rens[3]; details.Parentreport == nil || *details.Parentreport != "3" {
+		t.Errorf("details %q not under the note %q", details.Shortname, rens[2].Shortname)
+	}
+}
@@ -0,0 +1,268 @@
+package xbrl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Roles of the labels and linkbases
+const (
+	roleLabel         = "http://www.xbrl.org/2003/role/label"
+	roleDocumentation = "http://www.xbrl.org/2003/role/documentation"
+	rolePresentation  = "http://www.xbrl.org/2003/role/presentationLinkbaseRef"
+	roleCalculation   = "http://www.xbrl.org/2003/role/calculationLinkbaseRef"
+	roleLabelLinkbase = "http://www.xbrl.org/2003/role/labelLinkbaseRef"
+)
+
+// Taxonomy is the taxonomy extension of a filing: the schema (.xsd) of its
+// custom elements and roles, and its linkbases
+type Taxonomy struct {
+	// Target namespace of the custom elements
+	Namespace string
+
+	// Custom elements by id (e.g. fb_AdvertisingMember)
+	Elements map[string]*Element
+
+	// Roles (reports) of the filing, sorted by their definitions
+	Roles []*Role
+
+	// Labels by element id and label role
+	Labels map[string]map[string]string
+
+	// Presentation and calculation arcs by role URI
+	Presentation map[string][]*Arc
+	Calculation  map[string][]*Arc
+}
+
+// Element is a concept declared by a schema
+type Element struct {
+	ID       string
+	Name     string
+	Type     string
+	Abstract bool
+
+	// instant or duration
+	PeriodType string
+
+	// debit or credit, for monetary elements
+	Balance string
+}
+
+// Role is a role type of a schema, a report of the filing, e.g.
+// "0002000 - Statement - CONSOLIDATED BALANCE SHEETS"
+type Role struct {
+	URI        string
+	Definition string
+}
+
+// Arc is a relationship between two elements of a linkbase, identified by
+// the href of their locators (e.g. https://xbrl.fasb.org/us-gaap/2018/elts/us-gaap-2018-01-31.xsd#us-gaap_Assets)
+type Arc struct {
+	From  string
+	To    string
+	Order float64
+
+	// The preferred label role of a presentation arc
+	PreferredLabel string
+
+	// The weight of a calculation arc
+	Weight float64
+}
+
+// ParseTaxonomy parses the taxonomy extension schema and the linkbases it
+// refers to. The linkbases not referred to are looked for next to the schema
+// under the names EDGAR gives them (*_pre.xml, *_cal.xml, *_lab.xml).
+func ParseTaxonomy(schema string) (*Taxonomy, error) {
+	doc, err := parseFile(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", schema, err)
+	}
+	t := &Taxonomy{
+		Namespace:    doc.root.attr("targetNamespace"),
+		Elements:     map[string]*Element{},
+		Labels:       map[string]map[string]string{},
+		Presentation: map[string][]*Arc{},
+		Calculation:  map[string][]*Arc{},
+	}
+	linkbases := map[string]string{}
+	doc.root.walk(func(n *node) bool {
+		switch {
+		case n.is(nsSchema, "element"):
+			t.Elements[n.attr("id")] = &Element{
+				ID:         n.attr("id"),
+				Name:       n.attr("name"),
+				Type:       n.attr("type"),
+				Abstract:   n.attr("abstract") == "true",
+				PeriodType: n.attr("periodType"),
+				Balance:    n.attr("balance"),
+			}
+			return false
+		case n.is(nsLinkbase, "roleType"):
+			role := &Role{URI: n.attr("roleURI")}
+			if d := n.child(nsLinkbase, "definition"); d != nil {
+				role.Definition = normalizeSpace(d.trimmedText())
+			}
+			t.Roles = append(t.Roles, role)
+			return false
+		case n.is(nsLinkbase, "linkbaseRef"):
+			linkbases[n.attrNS(nsXlink, "role")] = n.attrNS(nsXlink, "href")
+			return false
+		}
+		return true
+	})
+	sort.SliceStable(t.Roles, func(i, j int) bool {
+		return t.Roles[i].Definition < t.Roles[j].Definition
+	})
+
+	base := strings.TrimSuffix(schema, filepath.Ext(schema))
+	for role, suffix := range map[string]string{rolePresentation: "_pre.xml", roleCalculation: "_cal.xml", roleLabelLinkbase: "_lab.xml"} {
+		file := base + suffix
+		if href, ok := linkbases[role]; ok && !isURL(href) {
+			file = filepath.Join(filepath.Dir(schema), href)
+		}
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+		if err := t.parseLinkbase(file); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// parseLinkbase adds the labels, presentation and calculation arcs of the
+// linkbase file
+func (t *Taxonomy) parseLinkbase(file string) error {
+	doc, err := parseFile(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	for _, link := range doc.root.elements() {
+		// the locators of the link, by label
+		locs := map[string][]string{}
+		for _, n := range link.elements() {
+			if n.is(nsLinkbase, "loc") {
+				label := n.attrNS(nsXlink, "label")
+				locs[label] = append(locs[label], n.attrNS(nsXlink, "href"))
+			}
+		}
+		role := link.attrNS(nsXlink, "role")
+		switch {
+		case link.is(nsLinkbase, "labelLink"):
+			labels := map[string][]*node{}
+			for _, n := range link.elements() {
+				if n.is(nsLinkbase, "label") && (n.attr("lang") == "" || strings.HasPrefix(n.attr("lang"), "en")) {
+					label := n.attrNS(nsXlink, "label")
+					labels[label] = append(labels[label], n)
+				}
+			}
+			for _, arc := range link.elements() {
+				if !arc.is(nsLinkbase, "labelArc") {
+					continue
+				}
+				for _, href := range locs[arc.attrNS(nsXlink, "from")] {
+					id := fragment(href)
+					if t.Labels[id] == nil {
+						t.Labels[id] = map[string]string{}
+					}
+					for _, l := range labels[arc.attrNS(nsXlink, "to")] {
+						t.Labels[id][l.attrNS(nsXlink, "role")] = normalizeSpace(l.trimmedText())
+					}
+				}
+			}
+		case link.is(nsLinkbase, "presentationLink"):
+			t.Presentation[role] = append(t.Presentation[role], arcs(link, "presentationArc", locs)...)
+		case link.is(nsLinkbase, "calculationLink"):
+			t.Calculation[role] = append(t.Calculation[role], arcs(link, "calculationArc", locs)...)
+		}
+	}
+	return nil
+}
+
+// arcs returns the arcs local of link between the locators locs
+func arcs(link *node, local string, locs map[string][]string) []*Arc {
+	arcs := []*Arc{}
+	for _, n := range link.elements() {
+		if !n.is(nsLinkbase, local) {
+			continue
+		}
+		order, err := strconv.ParseFloat(n.attr("order"), 64)
+		if err != nil {
+			order = 1
+		}
+		weight, err := strconv.ParseFloat(n.attr("weight"), 64)
+		if err != nil {
+			weight = 1
+		}
+		for _, from := range locs[n.attrNS(nsXlink, "from")] {
+			for _, to := range locs[n.attrNS(nsXlink, "to")] {
+				arcs = append(arcs, &Arc{
+					From:           from,
+					To:             to,
+					Order:          order,
+					PreferredLabel: n.attr("preferredLabel"),
+					Weight:         weight,
+				})
+			}
+		}
+	}
+	sort.SliceStable(arcs, func(i, j int) bool {
+		return arcs[i].Order < arcs[j].Order
+	})
+	return arcs
+}
+
+// Label returns the label of role of the element of a locator href, falling
+// back on its standard label, empty if none
+func (t *Taxonomy) Label(href string, role string) string {
+	labels := t.Labels[fragment(href)]
+	if role != "" && labels[role] != "" {
+		return labels[role]
+	}
+	return labels[roleLabel]
+}
+
+// Documentation returns the documentation label of the element of href
+func (t *Taxonomy) Documentation(href string) string {
+	return t.Labels[fragment(href)][roleDocumentation]
+}
+
+// fragment returns the element id of a locator href
+func fragment(href string) string {
+	if i := strings.Index(href, "#"); i >= 0 {
+		return href[i+1:]
+	}
+	return href
+}
+
+// isURL reports whether href is absolute, out of the filing
+func isURL(href string) bool {
+	return strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://")
+}
+
+// taxonomyVersion matches the taxonomy and year of a standard namespace or
+// schema, e.g. us-gaap and 2018 of http://fasb.org/us-gaap/2018-01-31 or
+// https://xbrl.fasb.org/us-gaap/2018/elts/us-gaap-2018-01-31.xsd
+var taxonomyVersion = regexp.MustCompile(`([a-z][a-z-]*[a-z])[/-]((?:19|20)\d\d)(?:-\d\d-\d\d)?(?:\.xsd)?$`)
+
+// taxonomyVersionAfter matches the namespaces naming the taxonomy after its
+// date, e.g. http://xbrl.ifrs.org/taxonomy/2018-03-16/ifrs-full
+var taxonomyVersionAfter = regexp.MustCompile(`/((?:19|20)\d\d)-\d\d-\d\d/([a-z][a-z-]*[a-z])$`)
+
+// version returns the version of the data sets of a namespace or schema
+// (us-gaap/2018, dei/2018...), empty if it's not a standard taxonomy
+func version(namespaceOrSchema string) string {
+	s := strings.SplitN(namespaceOrSchema, "#", 2)[0]
+	if m := taxonomyVersion.FindStringSubmatch(s); m != nil {
+		return m[1] + "/" + m[2]
+	}
+	if m := taxonomyVersionAfter.FindStringSubmatch(s); m != nil {
+		return m[2] + "/" + m[1]
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package filingsdb_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"eswiac.me/filingsdb"
+	"eswiac.me/filingsdb/models"
+)
+
+func TestIngestXBRL(t *testing.T) {
+	db, dir := openTemp(t)
+	defer os.RemoveAll(dir)
+	const adsh = "0000012345-19-000001"
+	accepted := time.Date(2019, 1, 31, 21, 7, 21, 0, time.UTC)
+	if err := db.IngestXBRL(context.Background(), "xbrl/testdata/ex-20181231.xml", adsh, accepted); err != nil {
+		t.Fatal(err)
+	}
+	sub := models.DataSUB{}
+	if err := db.Gorm().Where("adsh = ?", adsh).First(&sub).Error; err != nil {
+		t.Fatal(err)
+	}
+	if sub.Accepted != "2019-01-31 16:07:21.0" || sub.Filed != "20190131" || sub.Dataset != models.DatasetXBRL {
+		t.Errorf("accepted %s, filed %s, dataset %s", sub.Accepted, sub.Filed, sub.Dataset)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// loading the filing again with a filter leaving it out keeps it
+	db, err := filingsdb.Open(filepath.Join(dir, "filings.db"), filingsdb.WithFilter(filingsdb.IngestFilter{Ciks: []string{"1326801"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.IngestXBRL(context.Background(), "xbrl/testdata/ex-20181231.xml", adsh, accepted); err != nil {
+		t.Fatal(err)
+	}
+	var nums int64
+	if err := db.Gorm().Model(&models.DataNUM{}).Where("adsh = ?", adsh).Count(&nums).Error; err != nil {
+		t.Fatal(err)
+	}
+	if nums != 5 {
+		t.Errorf("%d facts left, expected the 5 loaded before", nums)
+	}
+}